@@ -2,6 +2,10 @@
 package main
 
 import (
+	"context"
+	"os/signal"
+	"syscall"
+
 	"steam-observer/internal/app"
 	"steam-observer/internal/shared/config"
 	"steam-observer/internal/shared/logger"
@@ -18,14 +22,18 @@ func main() {
 	cfg := config.Load()
 	log.Infof("config loaded, http_addr=%s", cfg.HTTPAddr)
 
+	// Контекст отменяется по Ctrl+C / SIGTERM (docker stop, Railway redeploy)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	server := app.NewServer(cfg, log)
 
 	defer func() {
 		log.Info("shutting down...")
-		server.Container.DB.Close()
+		server.Container.Close()
 	}()
 
-	if err := server.Run(); err != nil {
+	if err := server.Run(ctx); err != nil {
 		log.Errorf("server error: %v", err)
 	}
 }
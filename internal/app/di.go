@@ -9,11 +9,27 @@ import (
 	authapp "steam-observer/internal/modules/auth/app"
 	"steam-observer/internal/modules/auth/ports/out_ports"
 	dashboardhttp "steam-observer/internal/modules/dashboard/adapters/in/http"
+	dashboardmarket "steam-observer/internal/modules/dashboard/adapters/out/market"
 	dashboardapp "steam-observer/internal/modules/dashboard/app"
 	"steam-observer/internal/modules/dashboard/ports/in_ports"
+	"steam-observer/internal/modules/market/adapters/out/gamedata"
+	marketnotify "steam-observer/internal/modules/market/adapters/out/notifications"
+	marketpg "steam-observer/internal/modules/market/adapters/out/postgres"
+	"steam-observer/internal/modules/market/adapters/out/pricesources"
+	"steam-observer/internal/modules/market/adapters/out/rates"
+	"steam-observer/internal/modules/market/adapters/out/steam"
 	marketapp "steam-observer/internal/modules/market/app"
+	marketdomain "steam-observer/internal/modules/market/domain"
+	marketout "steam-observer/internal/modules/market/ports/out_ports"
+	notifypg "steam-observer/internal/modules/notifications/adapters/out/postgres"
+	notifysmtp "steam-observer/internal/modules/notifications/adapters/out/smtp"
+	"steam-observer/internal/modules/notifications/adapters/out/telegram"
+	"steam-observer/internal/modules/notifications/adapters/out/webhook"
+	notifyapp "steam-observer/internal/modules/notifications/app"
+	notifyout "steam-observer/internal/modules/notifications/ports/out_ports"
 	"steam-observer/internal/shared/config"
 	"steam-observer/internal/shared/db"
+	"steam-observer/internal/shared/httpclient"
 	"steam-observer/internal/shared/logger"
 )
 
@@ -24,6 +40,30 @@ type Container struct {
 	AuthService      authapp.AuthService
 	TokenProvider    out_ports.TokenProvider
 	MarketService    marketapp.MarketService
+	CatalogService   marketapp.CatalogService
+	SteamMarket      marketout.SteamMarketClient
+	CurrencyService  marketapp.CurrencyService
+	InventoryService marketapp.InventoryService
+	PortfolioService marketapp.PortfolioService
+	FeeService       marketapp.FeeService
+	PriceService     marketapp.PriceService
+	OrderBookService marketapp.OrderBookService
+	ArbitrageService marketapp.ArbitrageService
+	WatchlistService marketapp.WatchlistService
+	TransferService  marketapp.TransferService
+	AlertService     marketapp.AlertService
+	StreamService    marketapp.StreamService
+	AnomalyService   marketapp.AnomalyService
+	ForecastService  marketapp.ForecastService
+	LiquidityService marketapp.LiquidityService
+	BackfillService  marketapp.BackfillService
+	ContainerService marketapp.ContainerService
+	TradeUpService   marketapp.TradeUpService
+	PricePoller      *marketapp.PricePoller
+	OrderBookPoller  *marketapp.OrderBookPoller
+	LiquidityPoller  *marketapp.LiquidityPoller
+	BackfillWorker   *marketapp.BackfillWorker
+	NotifyService    notifyapp.NotificationService
 	DashboardService in_ports.DashboardService
 	DashboardHandler *dashboardhttp.DashboardHandler
 }
@@ -44,6 +84,39 @@ func NewContainer(cfg *config.Config, log logger.Logger) *Container {
 	userRepo := authpg.NewUserRepository(pg.Pool)
 	oauthClient := google.NewClient(cfg.Google)
 	tokenProvider := jwt_provider.NewJWTProvider(cfg.JWT)
+	trackedItemRepo := marketpg.NewTrackedItemRepository(pg.Pool)
+	// Один HTTP клиент на все адаптеры Steam: лимит у Steam общий на IP
+	steamHTTP := httpclient.New(cfg.Steam.Timeout, cfg.Steam.RateLimit, log.WithField("client", "steam"))
+	steamMarketClient := steam.NewMarketClient(cfg.Steam, steamHTTP)
+	steamInventoryClient := steam.NewInventoryClient(cfg.Steam, steamHTTP)
+	priceSnapshotRepo := marketpg.NewPriceSnapshotRepository(pg.Pool)
+	alertRepo := marketpg.NewAlertRepository(pg.Pool)
+	exchangeRateRepo := marketpg.NewExchangeRateRepository(pg.Pool)
+	preferencesRepo := marketpg.NewUserPreferencesRepository(pg.Pool)
+	lotRepo := marketpg.NewLotRepository(pg.Pool)
+	catalogRepo := marketpg.NewCatalogRepository(pg.Pool)
+	orderBookRepo := marketpg.NewOrderBookRepository(pg.Pool)
+	watchlistRepo := marketpg.NewWatchlistRepository(pg.Pool)
+	anomalyRepo := marketpg.NewAnomalyRepository(pg.Pool)
+	liquidityRepo := marketpg.NewLiquidityRepository(pg.Pool)
+	backfillJobRepo := marketpg.NewBackfillJobRepository(pg.Pool)
+	priceHistoryRepo := marketpg.NewPriceHistoryRepository(pg.Pool)
+	rateSource := newRateSource(cfg.Currency, log)
+	// Без кэша: единственный потребитель - поллер, ключи он уже дедуплицирует
+	steamPrices := pricesources.NewSteamSource(steamMarketClient, marketdomain.Currency(cfg.Poller.Currency))
+	marketplaces := newMarketplaceSources(cfg.Markets, log)
+	gameData, err := newGameData(cfg.GameData)
+	if err != nil {
+		log.Errorf("failed to load game data: %v", err)
+		panic(err)
+	}
+	channelRepo := notifypg.NewChannelRepository(pg.Pool)
+	deliveryRepo := notifypg.NewDeliveryRepository(pg.Pool)
+	notifiers := []notifyout.Notifier{
+		webhook.NewNotifier(cfg.Notify.WebhookTimeout, cfg.Notify.WebhookAllowPrivate),
+		telegram.NewNotifier(cfg.Notify.Telegram, cfg.Notify.WebhookTimeout),
+		notifysmtp.NewNotifier(cfg.Notify.SMTP, cfg.Notify.WebhookTimeout),
+	}
 
 	// 3. Application: State Store (NEW!)
 	stateStore := authapp.NewInMemoryStateStore()
@@ -58,10 +131,215 @@ func NewContainer(cfg *config.Config, log logger.Logger) *Container {
 		log.WithField("module", "auth"),
 	)
 
-	marketService := marketapp.NewMarketService()
-	dashboardService := dashboardapp.NewDashboardService()
+	catalogService := marketapp.NewCatalogService(
+		catalogRepo,
+		trackedItemRepo,
+		steamMarketClient,
+		log.WithField("module", "catalog"),
+	)
+	marketService := marketapp.NewMarketService(
+		trackedItemRepo,
+		watchlistRepo,
+		liquidityRepo,
+		catalogService,
+		log.WithField("module", "market"),
+	)
+	canonicalCurrency, err := marketdomain.ParseCurrency(cfg.Currency.Canonical)
+	if err != nil {
+		log.Errorf("invalid CANONICAL_CURRENCY: %v", err)
+		panic(err)
+	}
+	currencyService := marketapp.NewCurrencyService(
+		canonicalCurrency,
+		rateSource,
+		exchangeRateRepo,
+		preferencesRepo,
+		cfg.Currency.RefreshInterval,
+		log.WithField("module", "currency"),
+	)
+	priceService := marketapp.NewPriceService(
+		trackedItemRepo,
+		priceSnapshotRepo,
+		priceHistoryRepo,
+		currencyService,
+	)
+	forecastService := marketapp.NewForecastService(
+		marketdomain.DefaultForecastModels(),
+		trackedItemRepo,
+		priceSnapshotRepo,
+		priceHistoryRepo,
+		currencyService,
+	)
+	orderBookService := marketapp.NewOrderBookService(
+		trackedItemRepo,
+		orderBookRepo,
+		steamMarketClient,
+		currencyService,
+		log.WithField("module", "order_books"),
+	)
+	liquidityService := marketapp.NewLiquidityService(
+		cfg.Liquidity,
+		trackedItemRepo,
+		liquidityRepo,
+		priceSnapshotRepo,
+		steamMarketClient,
+		currencyService,
+		log.WithField("module", "liquidity"),
+	)
+	backfillService := marketapp.NewBackfillService(
+		cfg.Backfill,
+		trackedItemRepo,
+		backfillJobRepo,
+		steamMarketClient,
+		currencyService,
+		log.WithField("module", "backfill"),
+	)
+	containerService := marketapp.NewContainerService(
+		gameData,
+		priceSnapshotRepo,
+		marketplaces,
+		currencyService,
+		log.WithField("module", "containers"),
+	)
+	tradeUpService := marketapp.NewTradeUpService(gameData, priceSnapshotRepo, marketplaces, currencyService)
+	arbitrageService := marketapp.NewArbitrageService(
+		trackedItemRepo,
+		priceSnapshotRepo,
+		marketplaces,
+		currencyService,
+		log.WithField("module", "arbitrage"),
+	)
+	inventoryService := marketapp.NewInventoryService(
+		steamInventoryClient,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		currencyService,
+		catalogService,
+		log.WithField("module", "inventory"),
+	)
+	portfolioService := marketapp.NewPortfolioService(
+		lotRepo,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		currencyService,
+		log.WithField("module", "portfolio"),
+	)
+	watchlistService := marketapp.NewWatchlistService(
+		watchlistRepo,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		currencyService,
+		log.WithField("module", "watchlists"),
+	)
+	transferService := marketapp.NewTransferService(
+		trackedItemRepo,
+		lotRepo,
+		alertRepo,
+		catalogService,
+		currencyService,
+		log.WithField("module", "transfer"),
+	)
+	feeService := marketapp.NewFeeService()
+	notifyService := notifyapp.NewNotificationService(
+		cfg.Notify,
+		channelRepo,
+		deliveryRepo,
+		notifiers,
+		log.WithField("module", "notifications"),
+	)
+
+	alertService := marketapp.NewAlertService(
+		alertRepo,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		currencyService,
+		marketnotify.NewAlertNotifier(notifyService),
+		log.WithField("module", "alerts"),
+	)
+	anomalyService := marketapp.NewAnomalyService(
+		cfg.Anomalies,
+		anomalyRepo,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		currencyService,
+		log.WithField("module", "anomalies"),
+	)
+	streamService := marketapp.NewStreamService(
+		cfg.Stream,
+		trackedItemRepo,
+		currencyService,
+		log.WithField("module", "stream"),
+	)
+
+	dashboardService := dashboardapp.NewDashboardService(
+		dashboardmarket.NewAnomalySource(anomalyService),
+		log.WithField("module", "dashboard"),
+	)
 	dashboardHandler := dashboardhttp.NewDashboardHandler(dashboardService)
 
+	// 5. Background workers
+	pricePoller := marketapp.NewPricePoller(
+		cfg.Poller,
+		trackedItemRepo,
+		priceSnapshotRepo,
+		steamPrices,
+		currencyService,
+		log.WithField("worker", "price_poller"),
+	)
+	orderBookPoller := marketapp.NewOrderBookPoller(
+		cfg.OrderBooks,
+		trackedItemRepo,
+		orderBookService,
+		log.WithField("worker", "order_book_poller"),
+	)
+	liquidityPoller := marketapp.NewLiquidityPoller(
+		cfg.Liquidity,
+		trackedItemRepo,
+		liquidityRepo,
+		liquidityService,
+		log.WithField("worker", "liquidity_poller"),
+	)
+	backfillWorker := marketapp.NewBackfillWorker(
+		cfg.Backfill,
+		trackedItemRepo,
+		backfillJobRepo,
+		backfillService,
+		log.WithField("worker", "backfill"),
+	)
+
+	// Курсы нужны poller'у для приведения цен к канонической валюте
+	currencyService.Start()
+
+	// Карточки каталога для предметов, добавленных до его появления
+	if err := catalogService.Backfill(ctx); err != nil {
+		log.Errorf("catalog backfill: %v", err)
+	}
+
+	// Доставки, не завершённые до прошлого рестарта
+	notifyService.Start()
+
+	// Алерты оцениваются на каждом новом снимке цены
+	pricePoller.AddObserver(alertService)
+	// Цены и срабатывания - в открытые SSE соединения
+	pricePoller.AddObserver(streamService)
+	alertService.AddObserver(streamService)
+	if cfg.Anomalies.Enabled {
+		pricePoller.AddObserver(anomalyService)
+	}
+	if cfg.Poller.Enabled {
+		pricePoller.Start()
+	}
+	if cfg.OrderBooks.Enabled {
+		orderBookPoller.Start()
+	}
+	if cfg.Liquidity.Enabled {
+		liquidityPoller.Start()
+	}
+	// Задачи, прерванные прошлым рестартом, продолжаются с сохранённого курсора
+	if cfg.Backfill.Enabled {
+		backfillWorker.Start()
+	}
+
 	log.Info("DI container initialized successfully")
 
 	return &Container{
@@ -71,7 +349,94 @@ func NewContainer(cfg *config.Config, log logger.Logger) *Container {
 		AuthService:      authService,
 		TokenProvider:    tokenProvider,
 		MarketService:    marketService,
+		CatalogService:   catalogService,
+		SteamMarket:      steamMarketClient,
+		CurrencyService:  currencyService,
+		InventoryService: inventoryService,
+		PortfolioService: portfolioService,
+		FeeService:       feeService,
+		PriceService:     priceService,
+		OrderBookService: orderBookService,
+		ArbitrageService: arbitrageService,
+		WatchlistService: watchlistService,
+		TransferService:  transferService,
+		AlertService:     alertService,
+		StreamService:    streamService,
+		AnomalyService:   anomalyService,
+		ForecastService:  forecastService,
+		LiquidityService: liquidityService,
+		BackfillService:  backfillService,
+		ContainerService: containerService,
+		TradeUpService:   tradeUpService,
+		PricePoller:      pricePoller,
+		OrderBookPoller:  orderBookPoller,
+		LiquidityPoller:  liquidityPoller,
+		BackfillWorker:   backfillWorker,
+		NotifyService:    notifyService,
 		DashboardService: dashboardService,
 		DashboardHandler: dashboardHandler,
 	}
 }
+
+// Close - останавливает фоновые воркеры и освобождает ресурсы
+// Порядок важен: сначала poller'ы (цены порождают алерты и доставки),
+// потом доставка уведомлений, и только потом пул соединений
+func (c *Container) Close() {
+	c.PricePoller.Stop()
+	c.OrderBookPoller.Stop()
+	c.LiquidityPoller.Stop()
+	c.BackfillWorker.Stop()
+	c.CurrencyService.Stop()
+	c.NotifyService.Stop()
+	c.DB.Close()
+}
+
+// newGameData - справочник кейсов из GAME_DATA_FILE или встроенный
+func newGameData(cfg config.GameDataConfig) (marketout.GameData, error) {
+	if cfg.File != "" {
+		return gamedata.NewFile(cfg.File)
+	}
+	return gamedata.NewEmbedded()
+}
+
+// newRateSource - источник курсов по EXCHANGE_RATES_SOURCE
+// Неизвестное значение - не повод падать: откатываемся на встроенную таблицу
+func newRateSource(cfg config.CurrencyConfig, log logger.Logger) marketout.RateSource {
+	switch cfg.RatesSource {
+	case "file":
+		return rates.NewFileSource(cfg.RatesFile)
+	case "static", "":
+		return rates.NewStaticSource()
+	default:
+		log.Warnf("unknown EXCHANGE_RATES_SOURCE %q, using static rates", cfg.RatesSource)
+		return rates.NewStaticSource()
+	}
+}
+
+// newMarketplaceSources - включённые сторонние площадки
+// Площадка с неизвестной валютой пропускается: без неё сравнение работает, а падать из-за этого незачем
+func newMarketplaceSources(cfg config.MarketplacesConfig, log logger.Logger) []marketout.PriceSource {
+	var sources []marketout.PriceSource
+
+	for _, m := range []struct {
+		name string
+		cfg  config.MarketplaceConfig
+		new  func(config.MarketplaceConfig, marketdomain.Currency, *httpclient.Client) marketout.PriceSource
+	}{
+		{"skinport", cfg.Skinport, pricesources.NewSkinportSource},
+		{"csgomarket", cfg.CSGOMarket, pricesources.NewCSGOMarketSource},
+	} {
+		if !m.cfg.Enabled {
+			continue
+		}
+		currency, err := marketdomain.ParseCurrency(m.cfg.Currency)
+		if err != nil {
+			log.Warnf("%s disabled: invalid currency %q: %v", m.name, m.cfg.Currency, err)
+			continue
+		}
+		client := httpclient.New(cfg.Timeout, cfg.RateLimit, log.WithField("client", m.name))
+		sources = append(sources, pricesources.NewCachedSource(m.new(m.cfg, currency, client), cfg.PriceTTL))
+	}
+
+	return sources
+}
@@ -6,6 +6,7 @@ import (
 	authhttp "steam-observer/internal/modules/auth/adapters/in/http"
 	dashboardhttp "steam-observer/internal/modules/dashboard/adapters/in/http"
 	markethttp "steam-observer/internal/modules/market/adapters/in/http"
+	notifyhttp "steam-observer/internal/modules/notifications/adapters/in/http"
 	"steam-observer/internal/shared/http/middleware"
 )
 
@@ -19,6 +20,7 @@ func RegisterRoutes(mux *http.ServeMux, c *Container) {
 	mux.HandleFunc("/auth/google/callback", authHandler.GoogleCallback)
 
 	authMW := middleware.Auth(c.TokenProvider, c.Logger.WithField("middleware", "auth"))
+	adminMW := middleware.Admin(c.Config.AdminIDs, c.Logger.WithField("middleware", "admin"))
 
 	// Dashboard routes
 	dashboardHandler := dashboardhttp.NewDashboardHandler(c.DashboardService)
@@ -26,7 +28,111 @@ func RegisterRoutes(mux *http.ServeMux, c *Container) {
 
 	// Protected routes
 	marketHandler := markethttp.NewMarketHandler(c.MarketService)
-	mux.Handle("/market/tracked", authMW(http.HandlerFunc(marketHandler.ListTracked)))
+	mux.Handle("GET /market/tracked", authMW(http.HandlerFunc(marketHandler.ListTracked)))
+	mux.Handle("POST /market/tracked", authMW(http.HandlerFunc(marketHandler.CreateTracked)))
+	mux.Handle("GET /market/tracked/{id}", authMW(http.HandlerFunc(marketHandler.GetTracked)))
+	mux.Handle("PATCH /market/tracked/{id}", authMW(http.HandlerFunc(marketHandler.UpdateTracked)))
+	mux.Handle("DELETE /market/tracked/{id}", authMW(http.HandlerFunc(marketHandler.DeleteTracked)))
+
+	watchlistHandler := markethttp.NewWatchlistHandler(c.WatchlistService)
+	mux.Handle("GET /market/watchlists", authMW(http.HandlerFunc(watchlistHandler.ListWatchlists)))
+	mux.Handle("POST /market/watchlists", authMW(http.HandlerFunc(watchlistHandler.CreateWatchlist)))
+	mux.Handle("PATCH /market/watchlists/{id}", authMW(http.HandlerFunc(watchlistHandler.UpdateWatchlist)))
+	mux.Handle("DELETE /market/watchlists/{id}", authMW(http.HandlerFunc(watchlistHandler.DeleteWatchlist)))
+	mux.Handle("PUT /market/watchlists/{id}/items/{item_id}", authMW(http.HandlerFunc(watchlistHandler.AddItem)))
+	mux.Handle("DELETE /market/watchlists/{id}/items/{item_id}", authMW(http.HandlerFunc(watchlistHandler.RemoveItem)))
+	mux.Handle("POST /market/watchlists/{id}/share", authMW(http.HandlerFunc(watchlistHandler.Share)))
+	mux.Handle("DELETE /market/watchlists/{id}/share", authMW(http.HandlerFunc(watchlistHandler.Unshare)))
+
+	// Public routes: опубликованный список доступен по токену без авторизации
+	mux.HandleFunc("GET /public/watchlists/{token}", watchlistHandler.GetShared)
+
+	catalogHandler := markethttp.NewCatalogHandler(c.CatalogService)
+	mux.Handle("GET /market/catalog", authMW(http.HandlerFunc(catalogHandler.ListCatalog)))
+	mux.Handle("GET /market/catalog/parse", authMW(http.HandlerFunc(catalogHandler.ParseName)))
+	mux.Handle("GET /market/search", authMW(http.HandlerFunc(catalogHandler.Search)))
+
+	priceHandler := markethttp.NewPriceHandler(c.PriceService)
+	mux.Handle("GET /market/items/{id}/candles", authMW(http.HandlerFunc(priceHandler.GetCandles)))
+
+	orderBookHandler := markethttp.NewOrderBookHandler(c.OrderBookService)
+	mux.Handle("GET /market/items/{id}/orderbook", authMW(http.HandlerFunc(orderBookHandler.GetMetrics)))
+	mux.Handle("GET /market/items/{id}/orderbook/depth", authMW(http.HandlerFunc(orderBookHandler.GetDepthChart)))
+	mux.Handle("GET /market/items/{id}/orderbook/history", authMW(http.HandlerFunc(orderBookHandler.GetHistory)))
+
+	arbitrageHandler := markethttp.NewArbitrageHandler(c.ArbitrageService)
+	mux.Handle("GET /market/venues", authMW(http.HandlerFunc(arbitrageHandler.ListVenues)))
+	mux.Handle("GET /market/items/{id}/prices", authMW(http.HandlerFunc(arbitrageHandler.ComparePrices)))
+	mux.Handle("GET /market/arbitrage", authMW(http.HandlerFunc(arbitrageHandler.FindArbitrage)))
+
+	currencyHandler := markethttp.NewCurrencyHandler(c.CurrencyService)
+	mux.Handle("GET /market/preferences", authMW(http.HandlerFunc(currencyHandler.GetPreferences)))
+	mux.Handle("PUT /market/preferences", authMW(http.HandlerFunc(currencyHandler.UpdatePreferences)))
+	mux.Handle("GET /market/rates", authMW(http.HandlerFunc(currencyHandler.GetRates)))
+
+	inventoryHandler := markethttp.NewInventoryHandler(c.InventoryService)
+	mux.Handle("POST /market/inventory/import", authMW(http.HandlerFunc(inventoryHandler.ImportInventory)))
+
+	feeHandler := markethttp.NewFeeHandler(c.FeeService)
+	mux.Handle("GET /market/fees", authMW(http.HandlerFunc(feeHandler.CalculateFees)))
+
+	portfolioHandler := markethttp.NewPortfolioHandler(c.PortfolioService)
+	mux.Handle("GET /market/lots", authMW(http.HandlerFunc(portfolioHandler.ListLots)))
+	mux.Handle("POST /market/lots", authMW(http.HandlerFunc(portfolioHandler.CreateLot)))
+	mux.Handle("DELETE /market/lots/{id}", authMW(http.HandlerFunc(portfolioHandler.DeleteLot)))
+	mux.Handle("GET /market/portfolio", authMW(http.HandlerFunc(portfolioHandler.GetPortfolio)))
+
+	transferHandler := markethttp.NewTransferHandler(c.TransferService)
+	mux.Handle("GET /market/export", authMW(http.HandlerFunc(transferHandler.Export)))
+	mux.Handle("GET /market/export/{kind}", authMW(http.HandlerFunc(transferHandler.ExportKind)))
+	mux.Handle("POST /market/import", authMW(http.HandlerFunc(transferHandler.Import)))
+	mux.Handle("POST /market/import/{kind}", authMW(http.HandlerFunc(transferHandler.ImportKind)))
+
+	anomalyHandler := markethttp.NewAnomalyHandler(c.AnomalyService)
+	mux.Handle("GET /market/anomalies", authMW(http.HandlerFunc(anomalyHandler.ListAnomalies)))
+	mux.Handle("GET /market/items/{id}/anomalies", authMW(http.HandlerFunc(anomalyHandler.ListItemAnomalies)))
+	mux.Handle("POST /market/items/{id}/anomalies/scan", authMW(http.HandlerFunc(anomalyHandler.ScanItem)))
+
+	liquidityHandler := markethttp.NewLiquidityHandler(c.LiquidityService)
+	mux.Handle("GET /market/items/{id}/liquidity", authMW(http.HandlerFunc(liquidityHandler.GetLiquidity)))
+
+	backfillHandler := markethttp.NewBackfillHandler(c.BackfillService)
+	mux.Handle("GET /market/items/{id}/backfill", authMW(http.HandlerFunc(backfillHandler.GetItemBackfill)))
+
+	containerHandler := markethttp.NewContainerHandler(c.ContainerService)
+	mux.Handle("GET /market/containers", authMW(http.HandlerFunc(containerHandler.ListContainers)))
+	mux.Handle("GET /market/containers/{id}/ev", authMW(http.HandlerFunc(containerHandler.GetEV)))
+
+	tradeUpHandler := markethttp.NewTradeUpHandler(c.TradeUpService)
+	mux.Handle("GET /market/collections", authMW(http.HandlerFunc(tradeUpHandler.ListCollections)))
+	mux.Handle("POST /market/tradeups", authMW(http.HandlerFunc(tradeUpHandler.Evaluate)))
+
+	forecastHandler := markethttp.NewForecastHandler(c.ForecastService)
+	mux.Handle("GET /market/forecast/models", authMW(http.HandlerFunc(forecastHandler.ListModels)))
+	mux.Handle("GET /market/items/{id}/forecast", authMW(http.HandlerFunc(forecastHandler.GetForecast)))
+
+	streamHandler := markethttp.NewStreamHandler(c.StreamService, c.Config.Stream.Heartbeat)
+	mux.Handle("GET /market/stream", authMW(http.HandlerFunc(streamHandler.Stream)))
+
+	alertHandler := markethttp.NewAlertHandler(c.AlertService)
+	mux.Handle("GET /market/alerts", authMW(http.HandlerFunc(alertHandler.ListRules)))
+	mux.Handle("POST /market/alerts", authMW(http.HandlerFunc(alertHandler.CreateRule)))
+	mux.Handle("PATCH /market/alerts/{id}", authMW(http.HandlerFunc(alertHandler.UpdateRule)))
+	mux.Handle("DELETE /market/alerts/{id}", authMW(http.HandlerFunc(alertHandler.DeleteRule)))
+	mux.Handle("GET /market/alerts/history", authMW(http.HandlerFunc(alertHandler.ListFired)))
+
+	// Notification channels
+	notifyHandler := notifyhttp.NewNotificationHandler(c.NotifyService)
+	mux.Handle("GET /notifications/channels", authMW(http.HandlerFunc(notifyHandler.ListChannels)))
+	mux.Handle("POST /notifications/channels", authMW(http.HandlerFunc(notifyHandler.RegisterChannel)))
+	mux.Handle("POST /notifications/channels/{id}/verify", authMW(http.HandlerFunc(notifyHandler.VerifyChannel)))
+	mux.Handle("POST /notifications/channels/{id}/resend", authMW(http.HandlerFunc(notifyHandler.ResendVerification)))
+	mux.Handle("DELETE /notifications/channels/{id}", authMW(http.HandlerFunc(notifyHandler.DeleteChannel)))
+	mux.Handle("GET /notifications/deliveries", authMW(http.HandlerFunc(notifyHandler.ListDeliveries)))
+
+	// Admin routes (ADMIN_USER_IDS)
+	mux.Handle("GET /admin/backfill/jobs", authMW(adminMW(http.HandlerFunc(backfillHandler.ListJobs))))
+	mux.Handle("POST /admin/backfill/jobs", authMW(adminMW(http.HandlerFunc(backfillHandler.TriggerBackfill))))
 
 	c.Logger.Info("routes registered successfully")
 }
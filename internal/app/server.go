@@ -2,7 +2,10 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"steam-observer/internal/shared/config"
 	"steam-observer/internal/shared/http/middleware"
@@ -35,7 +38,44 @@ func NewServer(cfg *config.Config, log logger.Logger) *Server {
 	}
 }
 
-func (s *Server) Run() error {
-	s.logger.Infof("starting http server on %s", s.addr)
-	return http.ListenAndServe(s.addr, s.router)
+// shutdownTimeout - сколько ждём завершения активных запросов при остановке
+const shutdownTimeout = 15 * time.Second
+
+// Run - запускает HTTP сервер и блокируется до отмены ctx (SIGINT/SIGTERM)
+// После отмены перестаёт принимать соединения и даёт активным запросам завершиться
+func (s *Server) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    s.addr,
+		Handler: s.router,
+	}
+	// Shutdown не прерывает активные запросы, а SSE потоки сами не завершаются
+	srv.RegisterOnShutdown(s.Container.StreamService.Close)
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Infof("starting http server on %s", s.addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("shutdown signal received, stopping http server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	// ListenAndServe после Shutdown возвращает http.ErrServerClosed - это штатно
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
 }
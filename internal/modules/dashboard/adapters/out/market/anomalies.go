@@ -0,0 +1,47 @@
+package market
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/dashboard/domain"
+	"steam-observer/internal/modules/dashboard/ports/out_ports"
+	marketdomain "steam-observer/internal/modules/market/domain"
+	marketports "steam-observer/internal/modules/market/ports/in_ports"
+)
+
+// anomalySource - мост dashboard → market
+// На дашборд попадают только заметные аномалии: low там был бы шумом
+type anomalySource struct {
+	service marketports.AnomalyService
+}
+
+// NewAnomalySource - создаёт адаптер аномалий из модуля market
+func NewAnomalySource(service marketports.AnomalyService) out_ports.AnomalySource {
+	return &anomalySource{service: service}
+}
+
+func (s *anomalySource) RecentAnomalies(ctx context.Context, userID string, since time.Time, limit int) ([]domain.RecentAnomaly, error) {
+	anomalies, err := s.service.ListAnomalies(ctx, userID, marketports.AnomalyQuery{
+		From:     since,
+		Severity: marketdomain.SeverityMedium,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make([]domain.RecentAnomaly, len(anomalies))
+	for i, a := range anomalies {
+		recent[i] = domain.RecentAnomaly{
+			TrackedItemID:  a.TrackedItemID,
+			MarketHashName: a.MarketHashName,
+			Detector:       string(a.Detector),
+			Severity:       string(a.Severity),
+			Direction:      a.Direction,
+			Score:          a.Score,
+			ObservedAt:     a.ObservedAt,
+		}
+	}
+	return recent, nil
+}
@@ -2,22 +2,45 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"steam-observer/internal/modules/dashboard/domain"
 	"steam-observer/internal/modules/dashboard/ports/in_ports"
+	"steam-observer/internal/modules/dashboard/ports/out_ports"
+	"steam-observer/internal/shared/logger"
 )
 
-type dashboardServiceImpl struct{}
+// Виджет аномалий: за какой период и сколько последних показывать
+const (
+	anomaliesPeriod = 7 * 24 * time.Hour
+	anomaliesLimit  = 10
+)
+
+type dashboardServiceImpl struct {
+	anomalies out_ports.AnomalySource
+	logger    logger.Logger
+}
 
-func NewDashboardService() in_ports.DashboardService {
-	return &dashboardServiceImpl{}
+func NewDashboardService(anomalies out_ports.AnomalySource, log logger.Logger) in_ports.DashboardService {
+	return &dashboardServiceImpl{
+		anomalies: anomalies,
+		logger:    log,
+	}
 }
 
 func (s *dashboardServiceImpl) GetDashboard(ctx context.Context, userID string) (*domain.Dashboard, error) {
+	// Дашборд должен открываться, даже если виджет не загрузился
+	anomalies, err := s.anomalies.RecentAnomalies(ctx, userID, time.Now().Add(-anomaliesPeriod), anomaliesLimit)
+	if err != nil {
+		s.logger.Errorf("load recent anomalies, user_id=%s: %v", userID, err)
+		anomalies = []domain.RecentAnomaly{}
+	}
+
 	// ==========================================
 	// Заглушечные данные для дашборда
 	// ==========================================
 	return &domain.Dashboard{
+		Anomalies: anomalies,
 		Sections: []domain.DashboardSection{
 			{
 				ID:          "market-1",
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type SectionType string
 
 const (
@@ -17,6 +19,18 @@ type DashboardSection struct {
 	IsEnabled   bool        `json:"is_enabled"`
 }
 
+// RecentAnomaly - недавняя аномалия цены по предмету пользователя (виджет секции market)
+type RecentAnomaly struct {
+	TrackedItemID  string    `json:"tracked_item_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Detector       string    `json:"detector"`
+	Severity       string    `json:"severity"`
+	Direction      string    `json:"direction"`
+	Score          float64   `json:"score"`
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
 type Dashboard struct {
-	Sections []DashboardSection `json:"sections"`
+	Sections  []DashboardSection `json:"sections"`
+	Anomalies []RecentAnomaly    `json:"anomalies"`
 }
@@ -0,0 +1,14 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/dashboard/domain"
+)
+
+// AnomalySource - недавние аномалии цен по предметам пользователя
+// Дашборд не знает, как они ищутся: это забота модуля market
+type AnomalySource interface {
+	RecentAnomalies(ctx context.Context, userID string, since time.Time, limit int) ([]domain.RecentAnomaly, error)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type AlertHandler struct {
+	service in_ports.AlertService
+}
+
+func NewAlertHandler(service in_ports.AlertService) *AlertHandler {
+	return &AlertHandler{service: service}
+}
+
+// ListRules - GET /market/alerts
+func (h *AlertHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	rules, err := h.service.ListRules(r.Context(), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list alert rules")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// CreateRule - POST /market/alerts
+func (h *AlertHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.CreateAlertRuleInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	rule, err := h.service.CreateRule(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to create alert rule")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+// UpdateRule - PATCH /market/alerts/{id}
+func (h *AlertHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.UpdateAlertRuleInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	rule, err := h.service.UpdateRule(r.Context(), userID, r.PathValue("id"), input)
+	if err != nil {
+		writeServiceError(w, err, "failed to update alert rule")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// DeleteRule - DELETE /market/alerts/{id}
+func (h *AlertHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to delete alert rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListFired - GET /market/alerts/history?limit=50
+func (h *AlertHandler) ListFired(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 0 // 0 → лимит по умолчанию в сервисе
+	if s := r.URL.Query().Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	alerts, err := h.service.ListFired(r.Context(), userID, limit)
+	if err != nil {
+		writeServiceError(w, err, "failed to list fired alerts")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, alerts)
+}
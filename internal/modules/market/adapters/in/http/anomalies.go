@@ -0,0 +1,116 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type AnomalyHandler struct {
+	service in_ports.AnomalyService
+}
+
+func NewAnomalyHandler(service in_ports.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{service: service}
+}
+
+// ListAnomalies - GET /market/anomalies?severity=medium&from=&to=&limit=50&currency=
+// severity - минимальный уровень (low | medium | high); from/to - RFC3339 или unix секунды
+func (h *AnomalyHandler) ListAnomalies(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query, ok := parseAnomalyQuery(w, r)
+	if !ok {
+		return
+	}
+
+	anomalies, err := h.service.ListAnomalies(r.Context(), userID, query)
+	if err != nil {
+		writeServiceError(w, err, "failed to list anomalies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, anomalies)
+}
+
+// ListItemAnomalies - GET /market/items/{id}/anomalies (параметры как у /market/anomalies)
+func (h *AnomalyHandler) ListItemAnomalies(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query, ok := parseAnomalyQuery(w, r)
+	if !ok {
+		return
+	}
+
+	anomalies, err := h.service.ListItemAnomalies(r.Context(), userID, r.PathValue("id"), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to list anomalies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, anomalies)
+}
+
+// ScanItem - POST /market/items/{id}/anomalies/scan
+// Пересчитывает аномалии по сохранённой истории предмета
+func (h *AnomalyHandler) ScanItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	scan, err := h.service.ScanItem(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to scan price history")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, scan)
+}
+
+// parseAnomalyQuery - общие query параметры списков аномалий; при ошибке отвечает 400 сам
+func parseAnomalyQuery(w http.ResponseWriter, r *http.Request) (in_ports.AnomalyQuery, bool) {
+	q := r.URL.Query()
+
+	var (
+		query in_ports.AnomalyQuery
+		err   error
+	)
+
+	if query.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+		return query, false
+	}
+	if query.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+		return query, false
+	}
+	if s := q.Get("severity"); s != "" {
+		if query.Severity, err = domain.ParseAnomalySeverity(s); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return query, false
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		if query.Limit, err = strconv.Atoi(s); err != nil || query.Limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return query, false
+		}
+	}
+	if code := q.Get("currency"); code != "" {
+		if query.Currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return query, false
+		}
+	}
+
+	return query, true
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type ArbitrageHandler struct {
+	service in_ports.ArbitrageService
+}
+
+func NewArbitrageHandler(service in_ports.ArbitrageService) *ArbitrageHandler {
+	return &ArbitrageHandler{service: service}
+}
+
+// ListVenues - GET /market/venues
+func (h *ArbitrageHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.service.ListVenues())
+}
+
+// ComparePrices - GET /market/items/{id}/prices?currency=USD
+func (h *ArbitrageHandler) ComparePrices(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var currency domain.Currency
+	if code := r.URL.Query().Get("currency"); code != "" {
+		var err error
+		if currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	comparison, err := h.service.ComparePrices(r.Context(), userID, r.PathValue("id"), currency)
+	if err != nil {
+		writeServiceError(w, err, "failed to compare prices")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comparison)
+}
+
+// FindArbitrage - GET /market/arbitrage?min_profit_pct=5&min_profit=100&venues=steam,skinport&currency=USD&limit=50
+// min_profit - абсолютный минимум выгоды в сотых долях currency
+func (h *ArbitrageHandler) FindArbitrage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	var query in_ports.ArbitrageQuery
+
+	if s := q.Get("min_profit_pct"); s != "" {
+		pct, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidParam("min_profit_pct").Error())
+			return
+		}
+		query.MinProfitPercent = &pct
+	}
+	if s := q.Get("min_profit"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidParam("min_profit").Error())
+			return
+		}
+		query.MinProfit = v
+	}
+	if s := q.Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidParam("limit").Error())
+			return
+		}
+		query.Limit = v
+	}
+	if s := q.Get("venues"); s != "" {
+		for _, venue := range strings.Split(s, ",") {
+			if venue = strings.TrimSpace(venue); venue != "" {
+				query.Venues = append(query.Venues, venue)
+			}
+		}
+	}
+	if code := q.Get("currency"); code != "" {
+		currency, err := domain.ParseCurrency(code)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query.Currency = currency
+	}
+
+	report, err := h.service.FindArbitrage(r.Context(), userID, query)
+	if err != nil {
+		writeServiceError(w, err, "failed to find arbitrage")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
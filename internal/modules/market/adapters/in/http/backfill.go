@@ -0,0 +1,87 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type BackfillHandler struct {
+	service in_ports.BackfillService
+}
+
+func NewBackfillHandler(service in_ports.BackfillService) *BackfillHandler {
+	return &BackfillHandler{service: service}
+}
+
+// GetItemBackfill - GET /market/items/{id}/backfill
+// Статус последней загрузки истории цен предмета; 404 если воркер до предмета ещё не дошёл
+func (h *BackfillHandler) GetItemBackfill(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetItemBackfill(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to get backfill status")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// ListJobs - GET /admin/backfill/jobs?status=failed&limit=50
+func (h *BackfillHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var (
+		filter domain.BackfillJobFilter
+		err    error
+	)
+	if s := q.Get("status"); s != "" {
+		if filter.Status, err = domain.ParseBackfillStatus(s); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		if filter.Limit, err = strconv.Atoi(s); err != nil || filter.Limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	jobs, err := h.service.ListJobs(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, err, "failed to list backfill jobs")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+// TriggerBackfill - POST /admin/backfill/jobs
+// Body: {"app_id":730,"market_hash_name":"AK-47 | Redline (Field-Tested)"}
+// Загрузка всей истории заново; 409 если незавершённая задача по предмету уже есть
+func (h *BackfillHandler) TriggerBackfill(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var key domain.ItemKey
+	if !decodeJSON(w, r, &key) {
+		return
+	}
+
+	job, err := h.service.TriggerBackfill(r.Context(), adminID, key)
+	if err != nil {
+		writeServiceError(w, err, "failed to queue backfill")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type CatalogHandler struct {
+	service in_ports.CatalogService
+}
+
+func NewCatalogHandler(service in_ports.CatalogService) *CatalogHandler {
+	return &CatalogHandler{service: service}
+}
+
+// ListCatalog - GET /market/catalog?app_id=730&type=weapon&weapon=AK-47&exterior=Field-Tested&rarity=Covert&stattrak=true&souvenir=false&limit=100&offset=0
+func (h *CatalogHandler) ListCatalog(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	filter, err := parseCatalogFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, err := h.service.ListCatalog(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, err, "failed to list catalog")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+// Search - GET /market/search?q=redline&appid=730&exterior=Field-Tested&stattrak=false&limit=20&remote=true
+// Фильтры те же, что у /market/catalog; remote=true дополняет каталог поиском Steam
+func (h *CatalogHandler) Search(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	filter, err := parseCatalogFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := domain.CatalogSearchQuery{
+		Query:  r.URL.Query().Get("q"),
+		Filter: filter,
+	}
+	if s := r.URL.Query().Get("remote"); s != "" {
+		if query.Remote, err = strconv.ParseBool(s); err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidParam("remote").Error())
+			return
+		}
+	}
+
+	results, err := h.service.Search(r.Context(), userID, query)
+	if err != nil {
+		writeServiceError(w, err, "failed to search catalog")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// ParseName - GET /market/catalog/parse?name=StatTrak™ AK-47 | Redline (Field-Tested)
+func (h *CatalogHandler) ParseName(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.service.ParseName(name))
+}
+
+// parseCatalogFilter - query параметры → domain.CatalogFilter
+// appid принимается наравне с app_id - так параметр называет сам Steam
+func parseCatalogFilter(r *http.Request) (domain.CatalogFilter, error) {
+	q := r.URL.Query()
+	filter := domain.CatalogFilter{
+		Type:   domain.ItemType(q.Get("type")),
+		Weapon: q.Get("weapon"),
+		Rarity: q.Get("rarity"),
+	}
+
+	if s := q.Get("exterior"); s != "" {
+		exterior, ok := domain.ParseExterior(s)
+		if !ok {
+			return filter, errInvalidParam("exterior")
+		}
+		filter.Exterior = exterior
+	}
+
+	for _, param := range []struct {
+		name string
+		dst  *int
+	}{
+		{"app_id", &filter.AppID},
+		{"appid", &filter.AppID},
+		{"limit", &filter.Limit},
+		{"offset", &filter.Offset},
+	} {
+		if s := q.Get(param.name); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				return filter, errInvalidParam(param.name)
+			}
+			*param.dst = v
+		}
+	}
+
+	for _, param := range []struct {
+		name string
+		dst  **bool
+	}{
+		{"stattrak", &filter.StatTrak},
+		{"souvenir", &filter.Souvenir},
+	} {
+		if s := q.Get(param.name); s != "" {
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				return filter, errInvalidParam(param.name)
+			}
+			*param.dst = &v
+		}
+	}
+
+	return filter, nil
+}
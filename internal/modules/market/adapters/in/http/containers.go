@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type ContainerHandler struct {
+	service in_ports.ContainerService
+}
+
+func NewContainerHandler(service in_ports.ContainerService) *ContainerHandler {
+	return &ContainerHandler{service: service}
+}
+
+// ListContainers - GET /market/containers
+func (h *ContainerHandler) ListContainers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.service.ListContainers(r.Context()))
+}
+
+// GetEV - GET /market/containers/{id}/ev?venue=steam&currency=
+// venue - площадка, по ценам которой считается EV (по умолчанию steam)
+func (h *ContainerHandler) GetEV(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	query := in_ports.ContainerEVQuery{Venue: q.Get("venue")}
+	if code := q.Get("currency"); code != "" {
+		var err error
+		if query.Currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	ev, err := h.service.ContainerEV(r.Context(), userID, r.PathValue("id"), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to calculate container value")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ev)
+}
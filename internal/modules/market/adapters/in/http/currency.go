@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type CurrencyHandler struct {
+	service in_ports.CurrencyService
+}
+
+func NewCurrencyHandler(service in_ports.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{service: service}
+}
+
+// GetPreferences - GET /market/preferences
+func (h *CurrencyHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, err, "failed to load preferences")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences - PUT /market/preferences
+// Body: {"display_currency":"EUR"}
+func (h *CurrencyHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.UpdatePreferencesInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	prefs, err := h.service.UpdatePreferences(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to update preferences")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// GetRates - GET /market/rates
+func (h *CurrencyHandler) GetRates(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	rates, err := h.service.GetRates(r.Context())
+	if err != nil {
+		writeServiceError(w, err, "failed to load exchange rates")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rates)
+}
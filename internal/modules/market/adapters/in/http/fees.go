@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type FeeHandler struct {
+	service in_ports.FeeService
+}
+
+func NewFeeHandler(service in_ports.FeeService) *FeeHandler {
+	return &FeeHandler{service: service}
+}
+
+// CalculateFees - GET /market/fees?app_id=730&buyer_pays=1250 (или seller_receives=1088)
+// Суммы в сотых долях валюты; валюта на расчёт не влияет
+func (h *FeeHandler) CalculateFees(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	var query in_ports.FeeQuery
+
+	for _, param := range []struct {
+		name string
+		dst  *int64
+	}{
+		{"buyer_pays", &query.BuyerPays},
+		{"seller_receives", &query.SellerReceives},
+	} {
+		if s := q.Get(param.name); s != "" {
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+param.name)
+				return
+			}
+			*param.dst = v
+		}
+	}
+
+	if s := q.Get("app_id"); s != "" {
+		appID, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid app_id")
+			return
+		}
+		query.AppID = appID
+	}
+
+	quote, err := h.service.CalculateFees(r.Context(), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to calculate fees")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
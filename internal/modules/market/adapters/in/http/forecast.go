@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type ForecastHandler struct {
+	service in_ports.ForecastService
+}
+
+func NewForecastHandler(service in_ports.ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: service}
+}
+
+// GetForecast - GET /market/items/{id}/forecast?days=7&history_days=180&models=ema,log_linear&currency=
+// days - горизонт (1..30), history_days - глубина истории для обучения, models - через запятую (без него - все)
+func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+
+	var (
+		query in_ports.ForecastQuery
+		err   error
+	)
+	if s := q.Get("days"); s != "" {
+		if query.Days, err = strconv.Atoi(s); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid days")
+			return
+		}
+	}
+	if s := q.Get("history_days"); s != "" {
+		if query.HistoryDays, err = strconv.Atoi(s); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid history_days")
+			return
+		}
+	}
+	for _, name := range strings.Split(q.Get("models"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			query.Models = append(query.Models, name)
+		}
+	}
+	if code := q.Get("currency"); code != "" {
+		if query.Currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	forecast, err := h.service.ForecastItem(r.Context(), userID, r.PathValue("id"), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to build forecast")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, forecast)
+}
+
+// ListModels - GET /market/forecast/models
+func (h *ForecastHandler) ListModels(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]string{"models": h.service.Models()})
+}
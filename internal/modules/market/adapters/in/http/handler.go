@@ -1,11 +1,12 @@
 package http
 
 import (
-	"encoding/json"
+	"cmp"
 	"net/http"
+	"strings"
 
+	"steam-observer/internal/modules/market/domain"
 	"steam-observer/internal/modules/market/ports/in_ports"
-	mw "steam-observer/internal/shared/http/middleware"
 )
 
 type MarketHandler struct {
@@ -16,21 +17,120 @@ func NewMarketHandler(service in_ports.MarketService) *MarketHandler {
 	return &MarketHandler{service: service}
 }
 
+// ListTracked - GET /market/tracked?watchlist={id}&tag=long,knife&sort=liquidity&order=asc
+// tag можно повторять; предмет должен иметь все перечисленные теги
+// sort - created_at | name | liquidity | volume | time_to_sell; order=asc|desc меняет
+// естественный порядок поля (новые, А-Я, ликвидные, быстрые - сверху)
 func (h *MarketHandler) ListTracked(w http.ResponseWriter, r *http.Request) {
-	userID, ok := mw.UserIDFromContext(r.Context())
+	userID, ok := requireUser(w, r)
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(`{"error":"missing user in context"}`))
 		return
 	}
 
-	items, err := h.service.ListTrackedItems(r.Context(), userID)
+	q := r.URL.Query()
+	filter := domain.TrackedItemFilter{WatchlistID: q.Get("watchlist")}
+	for _, value := range q["tag"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	if sort := q.Get("sort"); sort != "" {
+		var err error
+		if filter.Sort, err = domain.ParseTrackedItemSort(sort); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if order := q.Get("order"); order != "" {
+		if order != "asc" && order != "desc" {
+			writeError(w, http.StatusBadRequest, "order must be asc or desc")
+			return
+		}
+		filter.Sort = cmp.Or(filter.Sort, domain.SortCreatedAt)
+		filter.Reverse = (order == "desc") != filter.Sort.Descending()
+	}
+
+	items, err := h.service.ListTrackedItems(r.Context(), userID, filter)
+	if err != nil {
+		writeServiceError(w, err, "failed to list items")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+// GetTracked - GET /market/tracked/{id}
+func (h *MarketHandler) GetTracked(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.service.GetTrackedItem(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to get item")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// CreateTracked - POST /market/tracked
+func (h *MarketHandler) CreateTracked(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.CreateTrackedItemInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	item, err := h.service.CreateTrackedItem(r.Context(), userID, input)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(`{"error":"failed to list items"}`))
+		writeServiceError(w, err, "failed to create item")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, item)
+}
+
+// UpdateTracked - PATCH /market/tracked/{id}
+func (h *MarketHandler) UpdateTracked(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.UpdateTrackedItemInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	item, err := h.service.UpdateTrackedItem(r.Context(), userID, r.PathValue("id"), input)
+	if err != nil {
+		writeServiceError(w, err, "failed to update item")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// DeleteTracked - DELETE /market/tracked/{id}
+func (h *MarketHandler) DeleteTracked(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTrackedItem(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to delete item")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(items)
+	w.WriteHeader(http.StatusNoContent)
 }
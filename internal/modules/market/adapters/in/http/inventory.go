@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type InventoryHandler struct {
+	service in_ports.InventoryService
+}
+
+func NewInventoryHandler(service in_ports.InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+// ImportInventory - POST /market/inventory/import
+// Body: {"steam_id":"7656119...","app_id":730,"context_id":"2","track":true,"marketable_only":false}
+func (h *InventoryHandler) ImportInventory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.ImportInventoryInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	valuation, err := h.service.ImportInventory(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to import inventory")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, valuation)
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type LiquidityHandler struct {
+	service in_ports.LiquidityService
+}
+
+func NewLiquidityHandler(service in_ports.LiquidityService) *LiquidityHandler {
+	return &LiquidityHandler{service: service}
+}
+
+// GetLiquidity - GET /market/items/{id}/liquidity
+// Объём продаж, оценка времени продажи, тренд объёма и оценка ликвидности 0-100
+func (h *LiquidityHandler) GetLiquidity(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	liquidity, err := h.service.GetItemLiquidity(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to get liquidity")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, liquidity)
+}
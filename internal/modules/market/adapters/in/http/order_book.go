@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type OrderBookHandler struct {
+	service in_ports.OrderBookService
+}
+
+func NewOrderBookHandler(service in_ports.OrderBookService) *OrderBookHandler {
+	return &OrderBookHandler{service: service}
+}
+
+// GetMetrics - GET /market/items/{id}/orderbook?depth_pct=5&currency=USD
+// depth_pct - ширина окна глубины вокруг середины спреда, в процентах
+func (h *OrderBookHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query, err := parseOrderBookQuery(r, "depth_pct")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metrics, err := h.service.GetOrderBookMetrics(r.Context(), userID, r.PathValue("id"), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to load order book")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+// GetDepthChart - GET /market/items/{id}/orderbook/depth?range_pct=20&currency=USD
+func (h *OrderBookHandler) GetDepthChart(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query, err := parseOrderBookQuery(r, "range_pct")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chart, err := h.service.GetDepthChart(r.Context(), userID, r.PathValue("id"), query)
+	if err != nil {
+		writeServiceError(w, err, "failed to load depth chart")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, chart)
+}
+
+// GetHistory - GET /market/items/{id}/orderbook/history?from=&to=&depth_pct=5&currency=USD
+// from/to - RFC3339 или unix секунды, по умолчанию последние сутки
+func (h *OrderBookHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	query, err := parseOrderBookQuery(r, "depth_pct")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	from, err := parseTimeParam(q.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+		return
+	}
+	to, err := parseTimeParam(q.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+		return
+	}
+
+	history, err := h.service.GetOrderBookHistory(r.Context(), userID, r.PathValue("id"), in_ports.OrderBookHistoryQuery{
+		From:         from,
+		To:           to,
+		DepthPercent: query.Percent,
+		Currency:     query.Currency,
+	})
+	if err != nil {
+		writeServiceError(w, err, "failed to load order book history")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
+
+// parseOrderBookQuery - процент из параметра percentParam и currency
+func parseOrderBookQuery(r *http.Request, percentParam string) (in_ports.OrderBookQuery, error) {
+	q := r.URL.Query()
+	var query in_ports.OrderBookQuery
+
+	if s := q.Get(percentParam); s != "" {
+		pct, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return query, errInvalidParam(percentParam)
+		}
+		query.Percent = pct
+	}
+
+	if code := q.Get("currency"); code != "" {
+		currency, err := domain.ParseCurrency(code)
+		if err != nil {
+			return query, err
+		}
+		query.Currency = currency
+	}
+
+	return query, nil
+}
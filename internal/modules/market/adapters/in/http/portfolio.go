@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type PortfolioHandler struct {
+	service in_ports.PortfolioService
+}
+
+func NewPortfolioHandler(service in_ports.PortfolioService) *PortfolioHandler {
+	return &PortfolioHandler{service: service}
+}
+
+// ListLots - GET /market/lots?item_id=
+func (h *PortfolioHandler) ListLots(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	lots, err := h.service.ListLots(r.Context(), userID, r.URL.Query().Get("item_id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to list lots")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, lots)
+}
+
+// CreateLot - POST /market/lots
+// Body: {"tracked_item_id":"...","side":"buy","quantity":2,"unit_price":1250,"fee":0,"currency":"USD","executed_at":"2025-01-02T15:04:05Z"}
+func (h *PortfolioHandler) CreateLot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.CreateLotInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	lot, err := h.service.CreateLot(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to create lot")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, lot)
+}
+
+// DeleteLot - DELETE /market/lots/{id}
+func (h *PortfolioHandler) DeleteLot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteLot(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to delete lot")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPortfolio - GET /market/portfolio
+func (h *PortfolioHandler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	portfolio, err := h.service.GetPortfolio(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, err, "failed to calculate portfolio")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, portfolio)
+}
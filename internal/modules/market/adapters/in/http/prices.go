@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type PriceHandler struct {
+	service in_ports.PriceService
+}
+
+func NewPriceHandler(service in_ports.PriceService) *PriceHandler {
+	return &PriceHandler{service: service}
+}
+
+// GetCandles - GET /market/items/{id}/candles?interval=1h&from=&to=&currency=
+// from/to - RFC3339 ("2025-01-02T15:04:05Z") или unix timestamp в секундах
+// currency - ISO код; без него цены в валюте отображения пользователя
+func (h *PriceHandler) GetCandles(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+
+	intervalStr := q.Get("interval")
+	if intervalStr == "" {
+		intervalStr = string(domain.Interval1h)
+	}
+	interval, err := domain.ParseCandleInterval(intervalStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, err := parseTimeParam(q.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+		return
+	}
+	to, err := parseTimeParam(q.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+		return
+	}
+
+	var currency domain.Currency
+	if code := q.Get("currency"); code != "" {
+		if currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	series, err := h.service.GetCandles(r.Context(), userID, r.PathValue("id"), in_ports.CandlesQuery{
+		Interval: interval,
+		From:     from,
+		To:       to,
+		Currency: currency,
+	})
+	if err != nil {
+		writeServiceError(w, err, "failed to load candles")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, series)
+}
+
+// parseTimeParam - RFC3339 или unix секунды; пустая строка → нулевое время
+func parseTimeParam(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
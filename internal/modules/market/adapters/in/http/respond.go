@@ -0,0 +1,93 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	mw "steam-observer/internal/shared/http/middleware"
+)
+
+// maxBodyBytes - ограничение размера тела запроса (защита от огромных payload)
+const maxBodyBytes = 1 << 20 // 1 MiB
+
+// errorResponse - единый формат ошибки: {"error":"..."}
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON - отдаёт v как JSON с указанным статусом
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError - отдаёт ошибку в формате {"error":"..."}
+// json.Encoder экранирует сообщение (в отличие от ручной склейки строк)
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+// writeServiceError - переводит ошибку сервиса в HTTP статус
+//   - domain.ErrValidation → 400 с текстом ошибки (он безопасен и полезен клиенту)
+//   - ErrNotFound → 404
+//   - ErrAlreadyExists → 409
+//   - domain.ErrNoRate → 503 (курсы ещё не загружены или нет курса валюты)
+//   - ErrInventoryPrivate → 403, ErrSteamRateLimited → 503 (ответы Steam, клиент может их исправить или переждать)
+//   - ErrSteamNoData → 404 (Steam не знает предмет или по нему нет данных)
+//   - domain.ErrTooManyStreams → 429 (открыто слишком много потоков событий)
+//   - всё остальное → 500 с общим сообщением (детали БД наружу не отдаём)
+func writeServiceError(w http.ResponseWriter, err error, fallbackMsg string) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, out_ports.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, out_ports.ErrAlreadyExists):
+		writeError(w, http.StatusConflict, "already exists")
+	case errors.Is(err, domain.ErrNoRate):
+		writeError(w, http.StatusServiceUnavailable, "exchange rate is not available")
+	case errors.Is(err, out_ports.ErrInventoryPrivate):
+		writeError(w, http.StatusForbidden, "steam inventory is private or does not exist")
+	case errors.Is(err, out_ports.ErrSteamRateLimited):
+		writeError(w, http.StatusServiceUnavailable, "steam rate limit reached, try again later")
+	case errors.Is(err, out_ports.ErrSteamNoData):
+		writeError(w, http.StatusNotFound, "steam has no data for this item")
+	case errors.Is(err, domain.ErrTooManyStreams):
+		writeError(w, http.StatusTooManyRequests, "too many open streams")
+	default:
+		writeError(w, http.StatusInternalServerError, fallbackMsg)
+	}
+}
+
+// decodeJSON - читает тело запроса в dst
+// Неизвестные поля считаем ошибкой клиента: опечатка в имени поля не должна молча игнорироваться
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// errInvalidParam - ошибка разбора query параметра (отдаётся клиенту как 400)
+func errInvalidParam(name string) error {
+	return fmt.Errorf("invalid %s", name)
+}
+
+// requireUser - достаёт userID из контекста (кладёт middleware.Auth)
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, ok := mw.UserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing user in context")
+		return "", false
+	}
+	return userID, true
+}
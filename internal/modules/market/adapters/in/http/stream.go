@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+// streamRetry - через сколько клиент (EventSource) переподключается после обрыва
+const streamRetry = 3 * time.Second
+
+type StreamHandler struct {
+	service   in_ports.StreamService
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(service in_ports.StreamService, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &StreamHandler{service: service, heartbeat: heartbeat}
+}
+
+// Stream - GET /market/stream?currency=EUR
+// Server-Sent Events: event "price" (PriceUpdate), "alert" (FiredAlert), "resync" (перечитать состояние).
+// Переподключение - с заголовком Last-Event-ID (или ?last_event_id=, если клиент не умеет заголовки).
+// Пока событий нет, раз в heartbeat приходит комментарий ": ping".
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+
+	var opts in_ports.StreamOptions
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = q.Get("last_event_id")
+	}
+	if lastEventID != "" {
+		var err error
+		if opts.LastEventID, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidParam("last_event_id").Error())
+			return
+		}
+	}
+	if code := q.Get("currency"); code != "" {
+		var err error
+		if opts.Currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	sub, err := h.service.Subscribe(r.Context(), userID, opts)
+	if err != nil {
+		writeServiceError(w, err, "failed to open stream")
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	// Поток живёт дольше любого таймаута записи сервера; ErrNotSupported значит, что таймаута и нет
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx не должен буферизовать поток
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Клиент не успевал читать или сервер останавливается - клиент переподключится сам
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent - одно событие в формате SSE; JSON без переводов строк, поэтому data в одну строку
+func writeEvent(w http.ResponseWriter, event domain.StreamEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
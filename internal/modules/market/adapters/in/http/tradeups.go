@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type TradeUpHandler struct {
+	service in_ports.TradeUpService
+}
+
+func NewTradeUpHandler(service in_ports.TradeUpService) *TradeUpHandler {
+	return &TradeUpHandler{service: service}
+}
+
+// ListCollections - GET /market/collections
+func (h *TradeUpHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.service.ListCollections(r.Context()))
+}
+
+// Evaluate - POST /market/tradeups
+// Body: {"inputs":[{"market_hash_name":"AK-47 | Redline (Field-Tested)","float":0.21,"price":1250}, ...],"venue":"steam","currency":"USD"}
+// price входа необязателен: без него берётся текущая цена площадки
+func (h *TradeUpHandler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.EvaluateTradeUpInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	tradeUp, err := h.service.EvaluateTradeUp(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to evaluate trade-up")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tradeUp)
+}
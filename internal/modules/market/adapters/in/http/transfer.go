@@ -0,0 +1,255 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+// maxImportBytes - импорт принимает файлы крупнее обычного тела запроса
+const maxImportBytes = 10 << 20 // 10 MiB
+
+// Форматы выгрузки
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// Виды записей в пути /market/export/{kind} и /market/import/{kind}
+const (
+	kindItems  = "items"
+	kindLots   = "lots"
+	kindAlerts = "alerts"
+)
+
+type TransferHandler struct {
+	service in_ports.TransferService
+}
+
+func NewTransferHandler(service in_ports.TransferService) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+// Export - GET /market/export
+// Все данные пользователя одним JSON (его же принимает POST /market/import)
+func (h *TransferHandler) Export(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.Export(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, err, "failed to export data")
+		return
+	}
+
+	setAttachment(w, "steam-observer.json")
+	writeJSON(w, http.StatusOK, data)
+}
+
+// ExportKind - GET /market/export/{kind}?format=csv
+// kind - items | lots | alerts; format - json (по умолчанию) или csv
+func (h *TransferHandler) ExportKind(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	kind := r.PathValue("kind")
+	if !validKind(kind) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	format, err := parseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.service.Export(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, err, "failed to export data")
+		return
+	}
+
+	setAttachment(w, kind+"."+format)
+	if format == formatJSON {
+		switch kind {
+		case kindItems:
+			writeJSON(w, http.StatusOK, data.Items)
+		case kindLots:
+			writeJSON(w, http.StatusOK, data.Lots)
+		case kindAlerts:
+			writeJSON(w, http.StatusOK, data.Alerts)
+		}
+		return
+	}
+
+	var table [][]string
+	switch kind {
+	case kindItems:
+		table = itemsToCSV(data.Items)
+	case kindLots:
+		table = lotsToCSV(data.Lots)
+	case kindAlerts:
+		table = alertsToCSV(data.Alerts)
+	}
+	writeCSV(w, table)
+}
+
+// Import - POST /market/import?mode=skip&dry_run=true
+// Тело - выгрузка GET /market/export; отчёт отдаётся со статусом 200, даже если часть записей с ошибками
+func (h *TransferHandler) Import(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	opts, err := parseImportOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var data domain.DataExport
+	if !decodeImportJSON(w, r, &data) {
+		return
+	}
+	if data.Version > domain.ExportVersion {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported export version %d", data.Version))
+		return
+	}
+
+	h.runImport(w, r, userID, data, opts)
+}
+
+// ImportKind - POST /market/import/{kind}?format=csv&mode=merge&dry_run=true
+// Тело - JSON массив записей или CSV с заголовком (колонки как в GET /market/export/{kind}?format=csv).
+// Формат берётся из format, а без него - из Content-Type (text/csv → CSV, иначе JSON).
+func (h *TransferHandler) ImportKind(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	kind := r.PathValue("kind")
+	if !validKind(kind) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	opts, err := parseImportOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+			format = formatCSV
+		}
+	}
+	if format, err = parseFormat(format); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var data domain.DataExport
+	if format == formatJSON {
+		var dst any
+		switch kind {
+		case kindItems:
+			dst = &data.Items
+		case kindLots:
+			dst = &data.Lots
+		case kindAlerts:
+			dst = &data.Alerts
+		}
+		if !decodeImportJSON(w, r, dst) {
+			return
+		}
+	} else {
+		body := http.MaxBytesReader(w, r.Body, maxImportBytes)
+		switch kind {
+		case kindItems:
+			data.Items, err = itemsFromCSV(body)
+		case kindLots:
+			data.Lots, err = lotsFromCSV(body)
+		case kindAlerts:
+			data.Alerts, err = alertsFromCSV(body)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	h.runImport(w, r, userID, data, opts)
+}
+
+func (h *TransferHandler) runImport(w http.ResponseWriter, r *http.Request, userID string, data domain.DataExport, opts in_ports.ImportOptions) {
+	report, err := h.service.Import(r.Context(), userID, data, opts)
+	if err != nil {
+		writeServiceError(w, err, "failed to import data")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// parseImportOptions - ?mode=skip|overwrite|merge&dry_run=true
+func parseImportOptions(r *http.Request) (in_ports.ImportOptions, error) {
+	q := r.URL.Query()
+
+	mode, err := domain.ParseImportMode(q.Get("mode"))
+	if err != nil {
+		return in_ports.ImportOptions{}, err
+	}
+
+	var dryRun bool
+	if raw := q.Get("dry_run"); raw != "" {
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			return in_ports.ImportOptions{}, errInvalidParam("dry_run")
+		}
+	}
+
+	return in_ports.ImportOptions{Mode: mode, DryRun: dryRun}, nil
+}
+
+// decodeImportJSON - как decodeJSON, но с лимитом maxImportBytes
+func decodeImportJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+func parseFormat(s string) (string, error) {
+	switch s {
+	case "", formatJSON:
+		return formatJSON, nil
+	case formatCSV:
+		return formatCSV, nil
+	default:
+		return "", errInvalidParam("format")
+	}
+}
+
+func validKind(kind string) bool {
+	return kind == kindItems || kind == kindLots || kind == kindAlerts
+}
+
+// setAttachment - браузер сохранит ответ файлом, а не покажет его
+func setAttachment(w http.ResponseWriter, filename string) {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
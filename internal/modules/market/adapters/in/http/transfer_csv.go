@@ -0,0 +1,327 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// Колонки CSV; при импорте порядок не важен, колонки ищутся по заголовку
+var (
+	itemColumns  = []string{"app_id", "market_hash_name", "name", "notes", "tags"}
+	lotColumns   = []string{"id", "app_id", "market_hash_name", "side", "quantity", "unit_price", "fee", "currency", "executed_at", "notes"}
+	alertColumns = []string{"id", "app_id", "market_hash_name", "type", "threshold", "currency", "window_seconds", "mode", "cooldown_seconds", "enabled"}
+)
+
+// csvTimeLayouts - кроме RFC3339 принимаем то, что обычно выдают таблицы (время в UTC)
+var csvTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// utf8BOM - Excel добавляет его в начало CSV в UTF-8
+const utf8BOM = "\ufeff"
+
+// writeCSV - отдаёт таблицу (первая строка - заголовок)
+func writeCSV(w http.ResponseWriter, table [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = csv.NewWriter(w).WriteAll(table)
+}
+
+func itemsToCSV(items []domain.ItemRecord) [][]string {
+	table := [][]string{itemColumns}
+	for _, item := range items {
+		table = append(table, []string{
+			strconv.Itoa(item.AppID),
+			item.MarketHashName,
+			item.Name,
+			item.Notes,
+			strings.Join(item.Tags, ","), // Запятая в теге запрещена, так что разделитель однозначен
+		})
+	}
+	return table
+}
+
+func lotsToCSV(lots []domain.LotRecord) [][]string {
+	table := [][]string{lotColumns}
+	for _, lot := range lots {
+		table = append(table, []string{
+			lot.ID,
+			strconv.Itoa(lot.AppID),
+			lot.MarketHashName,
+			string(lot.Side),
+			strconv.FormatInt(lot.Quantity, 10),
+			strconv.FormatInt(lot.UnitPrice, 10),
+			strconv.FormatInt(lot.Fee, 10),
+			lot.Currency.Code(),
+			lot.ExecutedAt.UTC().Format(time.RFC3339),
+			lot.Notes,
+		})
+	}
+	return table
+}
+
+func alertsToCSV(alerts []domain.AlertRecord) [][]string {
+	table := [][]string{alertColumns}
+	for _, alert := range alerts {
+		var currency, cooldown, enabled string
+		if alert.Currency != nil {
+			currency = alert.Currency.Code()
+		}
+		if alert.CooldownSeconds != nil {
+			cooldown = strconv.FormatInt(*alert.CooldownSeconds, 10)
+		}
+		if alert.Enabled != nil {
+			enabled = strconv.FormatBool(*alert.Enabled)
+		}
+		table = append(table, []string{
+			alert.ID,
+			strconv.Itoa(alert.AppID),
+			alert.MarketHashName,
+			string(alert.Type),
+			strconv.FormatFloat(alert.Threshold, 'f', -1, 64),
+			currency,
+			strconv.FormatInt(alert.WindowSeconds, 10),
+			string(alert.Mode),
+			cooldown,
+			enabled,
+		})
+	}
+	return table
+}
+
+func itemsFromCSV(r io.Reader) ([]domain.ItemRecord, error) {
+	table, err := readCSV(r, itemColumns, "market_hash_name")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.ItemRecord, 0, len(table.rows))
+	for i := range table.rows {
+		row := table.row(i)
+		item := domain.ItemRecord{
+			MarketHashName: row.get("market_hash_name"),
+			Name:           row.get("name"),
+			Notes:          row.get("notes"),
+		}
+		row.parseInt("app_id", &item.AppID)
+		if tags := row.get("tags"); tags != "" {
+			item.Tags = strings.Split(tags, ",")
+		}
+		if row.err != nil {
+			return nil, row.err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func lotsFromCSV(r io.Reader) ([]domain.LotRecord, error) {
+	table, err := readCSV(r, lotColumns, "market_hash_name", "side", "quantity", "unit_price")
+	if err != nil {
+		return nil, err
+	}
+
+	lots := make([]domain.LotRecord, 0, len(table.rows))
+	for i := range table.rows {
+		row := table.row(i)
+		lot := domain.LotRecord{
+			ID:             row.get("id"),
+			MarketHashName: row.get("market_hash_name"),
+			Side:           domain.LotSide(strings.ToLower(row.get("side"))),
+			Notes:          row.get("notes"),
+		}
+		row.parseInt("app_id", &lot.AppID)
+		row.parseInt64("quantity", &lot.Quantity)
+		row.parseInt64("unit_price", &lot.UnitPrice)
+		row.parseInt64("fee", &lot.Fee)
+		row.parseCurrency("currency", &lot.Currency)
+		row.parseTime("executed_at", &lot.ExecutedAt)
+		if row.err != nil {
+			return nil, row.err
+		}
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
+
+func alertsFromCSV(r io.Reader) ([]domain.AlertRecord, error) {
+	table, err := readCSV(r, alertColumns, "market_hash_name", "type", "threshold")
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]domain.AlertRecord, 0, len(table.rows))
+	for i := range table.rows {
+		row := table.row(i)
+		alert := domain.AlertRecord{
+			ID:             row.get("id"),
+			MarketHashName: row.get("market_hash_name"),
+			Type:           domain.AlertType(strings.ToLower(row.get("type"))),
+			Mode:           domain.AlertMode(strings.ToLower(row.get("mode"))),
+		}
+		row.parseInt("app_id", &alert.AppID)
+		row.parseFloat("threshold", &alert.Threshold)
+		row.parseInt64("window_seconds", &alert.WindowSeconds)
+		if row.get("currency") != "" {
+			alert.Currency = new(domain.Currency)
+			row.parseCurrency("currency", alert.Currency)
+		}
+		if row.get("cooldown_seconds") != "" {
+			alert.CooldownSeconds = new(int64)
+			row.parseInt64("cooldown_seconds", alert.CooldownSeconds)
+		}
+		if row.get("enabled") != "" {
+			alert.Enabled = new(bool)
+			row.parseBool("enabled", alert.Enabled)
+		}
+		if row.err != nil {
+			return nil, row.err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+// csvTable - разобранный CSV: индекс колонок по заголовку и строки данных
+type csvTable struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+// readCSV - читает CSV с заголовком
+// Неизвестная колонка - ошибка (скорее всего опечатка), отсутствующая необязательная - пустые значения
+func readCSV(r io.Reader, known []string, required ...string) (*csvTable, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv header is missing")
+		}
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+
+	table := &csvTable{columns: make(map[string]int, len(header))}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, utf8BOM)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		if _, dup := table.columns[name]; dup {
+			return nil, fmt.Errorf("duplicate csv column %q", name)
+		}
+		table.columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := table.columns[name]; !ok {
+			return nil, fmt.Errorf("csv column %q is required", name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		if len(table.rows) == domain.MaxImportRecords {
+			return nil, fmt.Errorf("at most %d rows per import", domain.MaxImportRecords)
+		}
+		table.rows = append(table.rows, record)
+	}
+
+	return table, nil
+}
+
+// row - строка данных i (с 0); в ошибках номер строки с 1, как в отчёте импорта
+func (t *csvTable) row(i int) *csvRow {
+	return &csvRow{table: t, num: i + 1, record: t.rows[i]}
+}
+
+// csvRow - доступ к ячейкам по имени колонки
+// Первая ошибка разбора запоминается в err, остальные вызовы parse* её не перетирают
+type csvRow struct {
+	table  *csvTable
+	num    int
+	record []string
+	err    error
+}
+
+func (r *csvRow) get(column string) string {
+	i, ok := r.table.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// parse - разбирает непустую ячейку; пустая оставляет dst нулевым
+func (r *csvRow) parse(column string, parse func(string) error) {
+	value := r.get(column)
+	if r.err != nil || value == "" {
+		return
+	}
+	if err := parse(value); err != nil {
+		r.err = fmt.Errorf("row %d: %w", r.num, errInvalidParam(column))
+	}
+}
+
+func (r *csvRow) parseInt(column string, dst *int) {
+	r.parse(column, func(s string) (err error) {
+		*dst, err = strconv.Atoi(s)
+		return err
+	})
+}
+
+func (r *csvRow) parseInt64(column string, dst *int64) {
+	r.parse(column, func(s string) (err error) {
+		*dst, err = strconv.ParseInt(s, 10, 64)
+		return err
+	})
+}
+
+func (r *csvRow) parseFloat(column string, dst *float64) {
+	r.parse(column, func(s string) (err error) {
+		*dst, err = strconv.ParseFloat(s, 64)
+		return err
+	})
+}
+
+func (r *csvRow) parseBool(column string, dst *bool) {
+	r.parse(column, func(s string) (err error) {
+		*dst, err = strconv.ParseBool(s)
+		return err
+	})
+}
+
+func (r *csvRow) parseCurrency(column string, dst *domain.Currency) {
+	r.parse(column, func(s string) error {
+		return dst.UnmarshalText([]byte(s))
+	})
+}
+
+func (r *csvRow) parseTime(column string, dst *time.Time) {
+	r.parse(column, func(s string) error {
+		for _, layout := range csvTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				*dst = t.UTC()
+				return nil
+			}
+		}
+		return errors.New("unsupported time format")
+	})
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+func TestReadCSV(t *testing.T) {
+	known := []string{"id", "name", "price"}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+		rows    int
+	}{
+		{"header only", "name,price\n", "", 0},
+		{"bom, case and spaces in header", utf8BOM + " Name , PRICE\nfoo,1\n", "", 1},
+		{"optional column missing", "name\nfoo\nbar\n", "", 2},
+		{"empty input", "", "csv header is missing", 0},
+		{"unknown column", "name,prise\nfoo,1\n", `unknown csv column "prise"`, 0},
+		{"duplicate column", "name,Name\nfoo,bar\n", `duplicate csv column "name"`, 0},
+		{"required column missing", "id,price\n1,2\n", `csv column "name" is required`, 0},
+		{"wrong field count", "name,price\nfoo\n", "invalid csv", 0},
+	}
+
+	for _, tt := range tests {
+		table, err := readCSV(strings.NewReader(tt.input), known, "name")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(table.rows) != tt.rows {
+			t.Errorf("%s: %d rows, want %d", tt.name, len(table.rows), tt.rows)
+		}
+	}
+}
+
+func TestReadCSVRowLimit(t *testing.T) {
+	input := "name\n" + strings.Repeat("foo\n", domain.MaxImportRecords+1)
+	if _, err := readCSV(strings.NewReader(input), []string{"name"}, "name"); err == nil {
+		t.Errorf("readCSV with %d rows: want an error", domain.MaxImportRecords+1)
+	}
+}
+
+func TestItemsFromCSV(t *testing.T) {
+	input := "market_hash_name,app_id,tags,notes\n" +
+		"AK-47 | Redline (Field-Tested),730,\"rifles,long\",keep\n" +
+		"Recoil Case,,,\n"
+
+	items, err := itemsFromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("itemsFromCSV: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if got := items[0]; got.AppID != 730 || got.Notes != "keep" || len(got.Tags) != 2 || got.Tags[1] != "long" {
+		t.Errorf("item 0 = %+v", got)
+	}
+	if got := items[1]; got.AppID != 0 || got.Tags != nil {
+		t.Errorf("item 1 = %+v, want empty optional fields", got)
+	}
+
+	if _, err := itemsFromCSV(strings.NewReader("market_hash_name,app_id\nfoo,cs2\n")); err == nil ||
+		err.Error() != "row 1: invalid app_id" {
+		t.Errorf("bad app_id: error = %v, want %q", err, "row 1: invalid app_id")
+	}
+}
+
+func TestLotsFromCSV(t *testing.T) {
+	input := "market_hash_name,side,quantity,unit_price,fee,currency,executed_at\n" +
+		"Recoil Case,BUY,10,25,1,eur,2024-05-01 12:30:00\n" +
+		"Recoil Case,sell,4,40,,,2024-05-02\n"
+
+	lots, err := lotsFromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("lotsFromCSV: %v", err)
+	}
+	want := []domain.LotRecord{
+		{MarketHashName: "Recoil Case", Side: domain.LotBuy, Quantity: 10, UnitPrice: 25, Fee: 1,
+			Currency: domain.CurrencyEUR, ExecutedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)},
+		{MarketHashName: "Recoil Case", Side: domain.LotSell, Quantity: 4, UnitPrice: 40,
+			ExecutedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for i := range want {
+		if lots[i] != want[i] {
+			t.Errorf("lot %d = %+v, want %+v", i, lots[i], want[i])
+		}
+	}
+
+	header := "market_hash_name,side,quantity,unit_price,currency,executed_at\n"
+	bad := []struct {
+		name, row, wantErr string
+	}{
+		{"quantity", "Recoil Case,buy,ten,25,usd,2024-05-01", "row 1: invalid quantity"},
+		{"price", "Recoil Case,buy,1,2.5,usd,2024-05-01", "row 1: invalid unit_price"},
+		{"currency", "Recoil Case,buy,1,25,doubloons,2024-05-01", "row 1: invalid currency"},
+		{"time", "Recoil Case,buy,1,25,usd,01.05.2024", "row 1: invalid executed_at"},
+	}
+	for _, tt := range bad {
+		if _, err := lotsFromCSV(strings.NewReader(header + tt.row + "\n")); err == nil || err.Error() != tt.wantErr {
+			t.Errorf("bad %s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, err := lotsFromCSV(strings.NewReader("market_hash_name,side,quantity\nRecoil Case,buy,1\n")); err == nil {
+		t.Error("lots without unit_price: want an error")
+	}
+}
+
+func TestAlertsFromCSV(t *testing.T) {
+	input := "market_hash_name,type,threshold,currency,cooldown_seconds,enabled,mode\n" +
+		"Recoil Case,Price_Below,0.5,usd,600,false,once\n" +
+		"Recoil Case,price_above,2,,,,\n"
+
+	alerts, err := alertsFromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("alertsFromCSV: %v", err)
+	}
+
+	first := alerts[0]
+	if first.Type != domain.AlertPriceBelow || first.Threshold != 0.5 || first.Mode != domain.AlertModeOnce ||
+		first.Currency == nil || *first.Currency != domain.CurrencyUSD ||
+		first.CooldownSeconds == nil || *first.CooldownSeconds != 600 ||
+		first.Enabled == nil || *first.Enabled {
+		t.Errorf("alert 0 = %+v", first)
+	}
+	// Пустые ячейки - значения по умолчанию, а не нули
+	if second := alerts[1]; second.Currency != nil || second.CooldownSeconds != nil || second.Enabled != nil {
+		t.Errorf("alert 1 = %+v, want nil optional fields", second)
+	}
+
+	header := "market_hash_name,type,threshold,enabled\n"
+	for _, row := range []string{"Recoil Case,price_below,cheap,", "Recoil Case,price_below,1,maybe"} {
+		if _, err := alertsFromCSV(strings.NewReader(header + row + "\n")); err == nil {
+			t.Errorf("%q: want an error", row)
+		}
+	}
+	if _, err := alertsFromCSV(strings.NewReader("market_hash_name,type,threshold,comment\nx,price_below,1,hi\n")); err == nil {
+		t.Error("unknown column: want an error")
+	}
+}
+
+func TestLotsCSVRoundTrip(t *testing.T) {
+	lots := []domain.LotRecord{{
+		ID: "lot-1", AppID: 730, MarketHashName: "Recoil Case", Side: domain.LotBuy, Quantity: 3, UnitPrice: 25,
+		Fee: 2, Currency: domain.CurrencyRUB, ExecutedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), Notes: "a, \"quoted\" note",
+	}}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(lotsToCSV(lots)); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	got, err := lotsFromCSV(&buf)
+	if err != nil {
+		t.Fatalf("lotsFromCSV: %v", err)
+	}
+	if len(got) != 1 || got[0] != lots[0] {
+		t.Errorf("round trip = %+v, want %+v", got, lots)
+	}
+}
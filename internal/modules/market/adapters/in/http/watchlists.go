@@ -0,0 +1,173 @@
+package http
+
+import (
+	"net/http"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type WatchlistHandler struct {
+	service in_ports.WatchlistService
+}
+
+func NewWatchlistHandler(service in_ports.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{service: service}
+}
+
+// ListWatchlists - GET /market/watchlists
+func (h *WatchlistHandler) ListWatchlists(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	watchlists, err := h.service.ListWatchlists(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, err, "failed to list watchlists")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, watchlists)
+}
+
+// CreateWatchlist - POST /market/watchlists
+func (h *WatchlistHandler) CreateWatchlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.CreateWatchlistInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	watchlist, err := h.service.CreateWatchlist(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to create watchlist")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, watchlist)
+}
+
+// UpdateWatchlist - PATCH /market/watchlists/{id}
+func (h *WatchlistHandler) UpdateWatchlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.UpdateWatchlistInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	watchlist, err := h.service.UpdateWatchlist(r.Context(), userID, r.PathValue("id"), input)
+	if err != nil {
+		writeServiceError(w, err, "failed to update watchlist")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, watchlist)
+}
+
+// DeleteWatchlist - DELETE /market/watchlists/{id}
+func (h *WatchlistHandler) DeleteWatchlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWatchlist(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to delete watchlist")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddItem - PUT /market/watchlists/{id}/items/{item_id}
+func (h *WatchlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.AddItem(r.Context(), userID, r.PathValue("id"), r.PathValue("item_id")); err != nil {
+		writeServiceError(w, err, "failed to add item to watchlist")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveItem - DELETE /market/watchlists/{id}/items/{item_id}
+func (h *WatchlistHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveItem(r.Context(), userID, r.PathValue("id"), r.PathValue("item_id")); err != nil {
+		writeServiceError(w, err, "failed to remove item from watchlist")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Share - POST /market/watchlists/{id}/share
+// Ответ содержит share_token; ссылка для чтения - GET /public/watchlists/{share_token}
+func (h *WatchlistHandler) Share(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.service.Share(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to share watchlist")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, watchlist)
+}
+
+// Unshare - DELETE /market/watchlists/{id}/share
+func (h *WatchlistHandler) Unshare(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.service.Unshare(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err, "failed to unshare watchlist")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, watchlist)
+}
+
+// GetShared - GET /public/watchlists/{token}?currency=EUR
+// Без авторизации: доступ даёт только знание токена
+func (h *WatchlistHandler) GetShared(w http.ResponseWriter, r *http.Request) {
+	var currency domain.Currency
+	if code := r.URL.Query().Get("currency"); code != "" {
+		var err error
+		if currency, err = domain.ParseCurrency(code); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	watchlist, err := h.service.GetShared(r.Context(), r.PathValue("token"), currency)
+	if err != nil {
+		writeServiceError(w, err, "failed to load watchlist")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, watchlist)
+}
@@ -0,0 +1,141 @@
+package gamedata
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// embedded - справочник, собранный в бинарник (cs2.json рядом с этим файлом)
+//
+//go:embed cs2.json
+var embedded []byte
+
+// dataFile - JSON формат справочника
+//
+//	{
+//	  "collections": [{"id": "...", "name": "...", "items": [{"name": "AK-47 | Redline", "rarity": "classified", "min_float": 0.1, "max_float": 0.7}]}],
+//	  "containers": [{"id": "...", "market_hash_name": "...", "collection": "...", "key_price": 249, "key_currency": "USD", "stattrak": true, "rare": [...]}]
+//	}
+type dataFile struct {
+	Collections []domain.Collection `json:"collections"`
+	Containers  []domain.Container  `json:"containers"`
+}
+
+// gameData - справочник в памяти
+type gameData struct {
+	collectionList []domain.Collection
+	containers     []domain.Container
+	byID           map[string]*domain.Container
+	collections    map[string]*domain.Collection
+	skins          map[string]skinRef
+}
+
+// skinRef - скин коллекции для поиска по имени
+type skinRef struct {
+	skin       *domain.SkinItem
+	collection *domain.Collection
+}
+
+// NewEmbedded - встроенный справочник
+func NewEmbedded() (out_ports.GameData, error) {
+	return parse(embedded, "embedded game data")
+}
+
+// NewFile - справочник из JSON файла path (формат как у встроенного)
+// Позволяет обновить кейсы и коллекции без пересборки
+func NewFile(path string) (out_ports.GameData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read game data file: %w", err)
+	}
+	return parse(data, path)
+}
+
+// parse - декодирует и проверяет справочник; битые данные - ошибка старта, а не неверный EV
+func parse(data []byte, source string) (*gameData, error) {
+	var file dataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", source, err)
+	}
+
+	g := &gameData{
+		collectionList: file.Collections,
+		containers:     file.Containers,
+		byID:           make(map[string]*domain.Container, len(file.Containers)),
+		collections:    make(map[string]*domain.Collection, len(file.Collections)),
+		skins:          make(map[string]skinRef),
+	}
+
+	for i := range file.Collections {
+		collection := &file.Collections[i]
+		if _, ok := g.collections[collection.ID]; ok {
+			return nil, fmt.Errorf("%s: duplicate collection %q", source, collection.ID)
+		}
+		for j := range collection.Items {
+			item := &collection.Items[j]
+			if !item.Rarity.Valid() || item.Rarity == domain.RarityRareSpecial {
+				return nil, fmt.Errorf("%s: collection %s: invalid rarity %q of %q", source, collection.ID, item.Rarity, item.Name)
+			}
+			// Контракт обмена ищет коллекцию входа по имени скина, поэтому имя должно быть однозначным
+			if other, ok := g.skins[item.Name]; ok {
+				return nil, fmt.Errorf("%s: %q is in both %s and %s", source, item.Name, other.collection.ID, collection.ID)
+			}
+			g.skins[item.Name] = skinRef{skin: item, collection: collection}
+		}
+		g.collections[collection.ID] = collection
+	}
+
+	for i := range g.containers {
+		container := &g.containers[i]
+		if _, ok := g.byID[container.ID]; ok {
+			return nil, fmt.Errorf("%s: duplicate container %q", source, container.ID)
+		}
+		collection, ok := g.collections[container.Collection]
+		if !ok {
+			return nil, fmt.Errorf("%s: container %s: unknown collection %q", source, container.ID, container.Collection)
+		}
+		if err := container.Validate(collection); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		g.byID[container.ID] = container
+	}
+
+	return g, nil
+}
+
+func (g *gameData) Containers() []domain.Container {
+	return g.containers
+}
+
+func (g *gameData) Container(id string) (*domain.Container, error) {
+	container, ok := g.byID[id]
+	if !ok {
+		return nil, out_ports.ErrNotFound
+	}
+	return container, nil
+}
+
+func (g *gameData) Collections() []domain.Collection {
+	return g.collectionList
+}
+
+func (g *gameData) Collection(id string) (*domain.Collection, error) {
+	collection, ok := g.collections[id]
+	if !ok {
+		return nil, out_ports.ErrNotFound
+	}
+	return collection, nil
+}
+
+func (g *gameData) FindSkin(name string) (*domain.SkinItem, *domain.Collection, error) {
+	ref, ok := g.skins[name]
+	if !ok {
+		return nil, nil, out_ports.ErrNotFound
+	}
+	return ref.skin, ref.collection, nil
+}
@@ -0,0 +1,29 @@
+package notifications
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	notifydomain "steam-observer/internal/modules/notifications/domain"
+	notifyports "steam-observer/internal/modules/notifications/ports/in_ports"
+)
+
+// alertNotifier - мост market → notifications
+// Переводит FiredAlert в нейтральное сообщение и отдаёт его в NotificationService
+type alertNotifier struct {
+	service notifyports.NotificationService
+}
+
+// NewAlertNotifier - создаёт адаптер доставки алертов через модуль notifications
+func NewAlertNotifier(service notifyports.NotificationService) out_ports.AlertNotifier {
+	return &alertNotifier{service: service}
+}
+
+// NotifyAlert - ставит алерт в доставку во все подтверждённые каналы пользователя
+func (n *alertNotifier) NotifyAlert(ctx context.Context, fired *domain.FiredAlert) error {
+	return n.service.NotifyUser(ctx, fired.UserID, notifydomain.Message{
+		Subject: "Steam Observer alert: " + fired.MarketHashName,
+		Body:    fired.Message,
+	})
+}
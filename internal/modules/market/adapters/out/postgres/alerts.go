@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// alertRepository - PostgreSQL реализация AlertRepository
+type alertRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAlertRepository - создаёт репозиторий правил алертов
+func NewAlertRepository(pool *pgxpool.Pool) out_ports.AlertRepository {
+	return &alertRepository{pool: pool}
+}
+
+const alertRuleColumns = `r.id, r.user_id, r.tracked_item_id, r.type, r.threshold, r.currency, r.window_seconds,
+               r.mode, r.cooldown_seconds, r.enabled, r.last_fired_at, r.created_at, r.updated_at`
+
+const firedAlertColumns = `id, rule_id, user_id, tracked_item_id, app_id, market_hash_name, type,
+               threshold, currency, price, reference, observed, message, fired_at`
+
+// ListRulesByUser - все правила пользователя, новые сверху
+func (r *alertRepository) ListRulesByUser(ctx context.Context, userID string) ([]domain.AlertRule, error) {
+	query := `
+        SELECT ` + alertRuleColumns + `
+        FROM public.alert_rules r
+        WHERE r.user_id = $1
+        ORDER BY r.created_at DESC
+    `
+
+	return r.queryRules(ctx, query, userID)
+}
+
+// FindRuleByID - правило пользователя по ID
+func (r *alertRepository) FindRuleByID(ctx context.Context, userID, ruleID string) (*domain.AlertRule, error) {
+	query := `
+        SELECT ` + alertRuleColumns + `
+        FROM public.alert_rules r
+        WHERE r.id = $1 AND r.user_id = $2
+    `
+
+	rule, err := scanAlertRule(r.pool.QueryRow(ctx, query, ruleID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query alert rule by id: %w", err)
+	}
+
+	return rule, nil
+}
+
+// ListEnabledRulesByItem - включённые правила всех пользователей на предмет
+func (r *alertRepository) ListEnabledRulesByItem(ctx context.Context, key domain.ItemKey) ([]domain.AlertRule, error) {
+	// Правило привязано к tracked_item пользователя, а снимок - к предмету,
+	// поэтому идём через tracked_items
+	query := `
+        SELECT ` + alertRuleColumns + `
+        FROM public.alert_rules r
+        JOIN public.tracked_items t ON t.id = r.tracked_item_id
+        WHERE t.app_id = $1 AND t.market_hash_name = $2 AND r.enabled
+    `
+
+	return r.queryRules(ctx, query, key.AppID, key.MarketHashName)
+}
+
+// CreateRule - сохраняет новое правило
+func (r *alertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("invalid alert rule: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.alert_rules
+            (id, user_id, tracked_item_id, type, threshold, currency, window_seconds, mode, cooldown_seconds,
+             enabled, last_fired_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+        RETURNING created_at, updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.ID,
+		rule.UserID,
+		rule.TrackedItemID,
+		string(rule.Type),
+		rule.Threshold,
+		int(rule.Currency),
+		rule.WindowSeconds,
+		string(rule.Mode),
+		rule.CooldownSeconds,
+		rule.Enabled,
+		rule.LastFiredAt,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert alert rule: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRule - сохраняет изменяемые поля правила
+func (r *alertRepository) UpdateRule(ctx context.Context, rule *domain.AlertRule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("invalid alert rule: %w", err)
+	}
+
+	// type и tracked_item_id не меняются: другое условие = другое правило
+	// last_fired_at пишет только MarkFired: иначе правка правила во время раунда poller'а
+	// откатила бы срабатывание и сбросила cooldown
+	query := `
+        UPDATE public.alert_rules
+        SET threshold = $3, currency = $4, window_seconds = $5, mode = $6, cooldown_seconds = $7,
+            enabled = $8, updated_at = NOW()
+        WHERE id = $1 AND user_id = $2
+        RETURNING updated_at, last_fired_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.ID,
+		rule.UserID,
+		rule.Threshold,
+		int(rule.Currency),
+		rule.WindowSeconds,
+		string(rule.Mode),
+		rule.CooldownSeconds,
+		rule.Enabled,
+	).Scan(&rule.UpdatedAt, &rule.LastFiredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out_ports.ErrNotFound
+		}
+		return fmt.Errorf("update alert rule: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFired - точечный UPDATE вместо UpdateRule: poller держит копию правила с начала
+// раунда, и запись целой строки откатила бы правку пользователя, сделанную за это время.
+// Условия enabled и cooldown проверяются по текущей строке
+func (r *alertRepository) MarkFired(ctx context.Context, ruleID string, firedAt time.Time, disable bool) error {
+	query := `
+        UPDATE public.alert_rules
+        SET last_fired_at = $2, enabled = enabled AND NOT $3, updated_at = NOW()
+        WHERE id = $1
+          AND enabled
+          AND (last_fired_at IS NULL OR last_fired_at <= $2 - make_interval(secs => cooldown_seconds))
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query, ruleID, firedAt.UTC(), disable)
+	if err != nil {
+		return fmt.Errorf("mark alert rule fired: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteRule - удаляет правило пользователя
+func (r *alertRepository) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`DELETE FROM public.alert_rules WHERE id = $1 AND user_id = $2`,
+		ruleID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete alert rule: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveFired - добавляет запись в историю срабатываний
+func (r *alertRepository) SaveFired(ctx context.Context, fired *domain.FiredAlert) error {
+	query := `
+        INSERT INTO public.fired_alerts (` + firedAlertColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		fired.ID,
+		fired.RuleID,
+		fired.UserID,
+		fired.TrackedItemID,
+		fired.AppID,
+		fired.MarketHashName,
+		string(fired.Type),
+		fired.Threshold,
+		int(fired.Currency),
+		fired.Price,
+		fired.Reference,
+		fired.Observed,
+		fired.Message,
+		fired.FiredAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert fired alert: %w", err)
+	}
+
+	return nil
+}
+
+// ListFiredByUser - история срабатываний пользователя, новые сверху
+func (r *alertRepository) ListFiredByUser(ctx context.Context, userID string, limit int) ([]domain.FiredAlert, error) {
+	query := `
+        SELECT ` + firedAlertColumns + `
+        FROM public.fired_alerts
+        WHERE user_id = $1
+        ORDER BY fired_at DESC
+        LIMIT $2
+    `
+
+	rows, err := r.pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query fired alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []domain.FiredAlert{}
+	for rows.Next() {
+		var a domain.FiredAlert
+		var alertType string
+		var currency int
+		err := rows.Scan(
+			&a.ID,
+			&a.RuleID,
+			&a.UserID,
+			&a.TrackedItemID,
+			&a.AppID,
+			&a.MarketHashName,
+			&alertType,
+			&a.Threshold,
+			&currency,
+			&a.Price,
+			&a.Reference,
+			&a.Observed,
+			&a.Message,
+			&a.FiredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan fired alert: %w", err)
+		}
+		a.Type = domain.AlertType(alertType)
+		a.Currency = domain.Currency(currency)
+		alerts = append(alerts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate fired alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// queryRules - общий Query + Scan для списков правил
+func (r *alertRepository) queryRules(ctx context.Context, query string, args ...any) ([]domain.AlertRule, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []domain.AlertRule{}
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan alert rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate alert rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// scanAlertRule - общий Scan для pgx.Row и pgx.Rows
+// Порядок полей совпадает с alertRuleColumns
+func scanAlertRule(row pgx.Row) (*domain.AlertRule, error) {
+	var rule domain.AlertRule
+	var alertType, mode string
+	var currency int
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.TrackedItemID,
+		&alertType,
+		&rule.Threshold,
+		&currency,
+		&rule.WindowSeconds,
+		&mode,
+		&rule.CooldownSeconds,
+		&rule.Enabled,
+		&rule.LastFiredAt,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rule.Type = domain.AlertType(alertType)
+	rule.Mode = domain.AlertMode(mode)
+	rule.Currency = domain.Currency(currency)
+	return &rule, nil
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// anomalyRepository - PostgreSQL реализация AnomalyRepository
+type anomalyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnomalyRepository - создаёт репозиторий аномалий цен
+func NewAnomalyRepository(pool *pgxpool.Pool) out_ports.AnomalyRepository {
+	return &anomalyRepository{pool: pool}
+}
+
+const anomalyColumns = `id, snapshot_id, app_id, market_hash_name, detector, severity, direction,
+               score, value, baseline, currency, observed_at, detected_at`
+
+// Save - вставляет аномалии одним batch'ем, заполняет ID и DetectedAt у новых
+func (r *anomalyRepository) Save(ctx context.Context, anomalies []domain.Anomaly) (int, error) {
+	if len(anomalies) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for i := range anomalies {
+		a := &anomalies[i]
+		batch.Queue(`
+            INSERT INTO public.price_anomalies
+                (snapshot_id, app_id, market_hash_name, detector, severity, direction,
+                 score, value, baseline, currency, observed_at, detected_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+            ON CONFLICT (snapshot_id, detector) DO NOTHING
+            RETURNING id, detected_at
+        `,
+			a.SnapshotID,
+			a.AppID,
+			a.MarketHashName,
+			string(a.Detector),
+			string(a.Severity),
+			a.Direction,
+			a.Score,
+			a.Value,
+			a.Baseline,
+			int(a.Currency),
+			a.ObservedAt.UTC(),
+		)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	inserted := 0
+	for i := range anomalies {
+		err := results.QueryRow().Scan(&anomalies[i].ID, &anomalies[i].DetectedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue // Уже найдена раньше
+		}
+		if err != nil {
+			return inserted, fmt.Errorf("insert price anomaly: %w", err)
+		}
+		inserted++
+	}
+
+	return inserted, nil
+}
+
+// ListByKeys - аномалии предметов по фильтру, новые сверху
+func (r *anomalyRepository) ListByKeys(ctx context.Context, keys []domain.ItemKey, filter out_ports.AnomalyFilter) ([]domain.Anomaly, error) {
+	anomalies := []domain.Anomaly{}
+	if len(keys) == 0 {
+		return anomalies, nil
+	}
+
+	appIDs := make([]int32, len(keys))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		appIDs[i] = int32(key.AppID)
+		names[i] = key.MarketHashName
+	}
+
+	var from, to *time.Time
+	if !filter.From.IsZero() {
+		t := filter.From.UTC()
+		from = &t
+	}
+	if !filter.To.IsZero() {
+		t := filter.To.UTC()
+		to = &t
+	}
+
+	severities := []string{} // nil pgx передал бы как NULL, и cardinality вернул бы NULL
+	for _, severity := range filter.Severities {
+		severities = append(severities, string(severity))
+	}
+
+	query := `
+        SELECT ` + anomalyColumns + `
+        FROM public.price_anomalies
+        WHERE (app_id, market_hash_name) IN (
+            SELECT * FROM unnest($1::int[], $2::text[])
+        )
+          AND ($3::timestamp IS NULL OR observed_at >= $3)
+          AND ($4::timestamp IS NULL OR observed_at < $4)
+          AND (cardinality($5::text[]) = 0 OR severity = ANY($5))
+        ORDER BY observed_at DESC, id DESC
+        LIMIT $6
+    `
+
+	rows, err := r.pool.Query(ctx, query, appIDs, names, from, to, severities, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query price anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		anomaly, err := scanAnomaly(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan price anomaly: %w", err)
+		}
+		anomalies = append(anomalies, *anomaly)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate price anomalies: %w", err)
+	}
+
+	return anomalies, nil
+}
+
+// scanAnomaly - общий Scan для pgx.Row и pgx.Rows (порядок = anomalyColumns)
+func scanAnomaly(row pgx.Row) (*domain.Anomaly, error) {
+	var (
+		a        domain.Anomaly
+		detector string
+		severity string
+		currency int
+	)
+	err := row.Scan(
+		&a.ID,
+		&a.SnapshotID,
+		&a.AppID,
+		&a.MarketHashName,
+		&detector,
+		&severity,
+		&a.Direction,
+		&a.Score,
+		&a.Value,
+		&a.Baseline,
+		&currency,
+		&a.ObservedAt,
+		&a.DetectedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Detector = domain.AnomalyDetector(detector)
+	a.Severity = domain.AnomalySeverity(severity)
+	a.Currency = domain.Currency(currency)
+	return &a, nil
+}
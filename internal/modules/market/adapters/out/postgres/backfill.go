@@ -0,0 +1,301 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// backfillJobRepository - PostgreSQL реализация BackfillJobRepository
+type backfillJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackfillJobRepository - создаёт репозиторий задач загрузки истории
+func NewBackfillJobRepository(pool *pgxpool.Pool) out_ports.BackfillJobRepository {
+	return &backfillJobRepository{pool: pool}
+}
+
+const backfillJobColumns = `id, app_id, market_hash_name, status, trigger, requested_by, cursor_at, points,
+               total_points, attempts, last_error, next_attempt_at, created_at, updated_at, started_at, finished_at`
+
+// Create - INSERT; уникальный индекс по незавершённым задачам → ErrAlreadyExists
+func (r *backfillJobRepository) Create(ctx context.Context, job *domain.BackfillJob) error {
+	query := `
+        INSERT INTO public.price_backfill_jobs
+            (app_id, market_hash_name, status, trigger, requested_by, next_attempt_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		job.AppID,
+		job.MarketHashName,
+		string(job.Status),
+		string(job.Trigger),
+		job.RequestedBy,
+		job.NextAttemptAt.UTC(),
+		job.CreatedAt.UTC(),
+		job.UpdatedAt.UTC(),
+	).Scan(&job.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return out_ports.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert backfill job: %w", err)
+	}
+
+	return nil
+}
+
+// EnqueueMissing - задачи для предметов без единой задачи (в том числе завершённой)
+func (r *backfillJobRepository) EnqueueMissing(ctx context.Context, keys []domain.ItemKey) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	appIDs := make([]int32, len(keys))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		appIDs[i] = int32(key.AppID)
+		names[i] = key.MarketHashName
+	}
+
+	// Время передаём явно: колонки без часового пояса, а NOW() - в зоне сервера БД
+	query := `
+        INSERT INTO public.price_backfill_jobs
+            (app_id, market_hash_name, status, trigger, next_attempt_at, created_at, updated_at)
+        SELECT k.app_id, k.market_hash_name, $3, $4, $5, $5, $5
+        FROM unnest($1::int[], $2::text[]) AS k(app_id, market_hash_name)
+        WHERE NOT EXISTS (
+            SELECT 1 FROM public.price_backfill_jobs j
+            WHERE j.app_id = k.app_id AND j.market_hash_name = k.market_hash_name
+        )
+        ON CONFLICT DO NOTHING
+    `
+
+	tag, err := r.pool.Exec(ctx, query, appIDs, names,
+		string(domain.BackfillPending), string(domain.BackfillOnTrack), time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("enqueue backfill jobs: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimNext - SKIP LOCKED: задачу не возьмут два воркера одновременно
+func (r *backfillJobRepository) ClaimNext(ctx context.Context, now time.Time) (*domain.BackfillJob, error) {
+	query := `
+        UPDATE public.price_backfill_jobs
+        SET status = $2, started_at = COALESCE(started_at, $1), updated_at = $1
+        WHERE id = (
+            SELECT id FROM public.price_backfill_jobs
+            WHERE status = $3 AND next_attempt_at <= $1
+            ORDER BY next_attempt_at, id
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + backfillJobColumns
+
+	job, err := scanBackfillJob(r.pool.QueryRow(ctx, query,
+		now.UTC(), string(domain.BackfillRunning), string(domain.BackfillPending)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("claim backfill job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ResetRunning - вызывается при старте, когда ни один воркер ещё не работает
+func (r *backfillJobRepository) ResetRunning(ctx context.Context) (int, error) {
+	query := `
+        UPDATE public.price_backfill_jobs
+        SET status = $1, updated_at = $3
+        WHERE status = $2
+    `
+
+	tag, err := r.pool.Exec(ctx, query,
+		string(domain.BackfillPending), string(domain.BackfillRunning), time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("reset running backfill jobs: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// SaveChunk - точки и курсор в одной транзакции: курсор не может убежать вперёд сохранённых точек
+func (r *backfillJobRepository) SaveChunk(ctx context.Context, job *domain.BackfillJob, currency domain.Currency, chunk []domain.PriceHistoryPoint) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	batch := &pgx.Batch{}
+	for _, point := range chunk {
+		batch.Queue(`
+            INSERT INTO public.price_history (app_id, market_hash_name, observed_at, currency, price, volume)
+            VALUES ($1, $2, $3, $4, $5, $6)
+            ON CONFLICT (app_id, market_hash_name, observed_at) DO UPDATE SET
+                currency = EXCLUDED.currency,
+                price = EXCLUDED.price,
+                volume = EXCLUDED.volume
+        `, job.AppID, job.MarketHashName, point.Time.UTC(), int(currency), point.Price, point.Volume)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("insert price history: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE public.price_backfill_jobs
+        SET cursor_at = $2, points = $3, total_points = $4, updated_at = $5
+        WHERE id = $1
+    `, job.ID, utcOrNil(job.Cursor), job.Points, job.TotalPoints, job.UpdatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("update backfill cursor: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// Update - статус и счётчики задачи
+func (r *backfillJobRepository) Update(ctx context.Context, job *domain.BackfillJob) error {
+	query := `
+        UPDATE public.price_backfill_jobs
+        SET status = $2, cursor_at = $3, points = $4, total_points = $5, attempts = $6, last_error = $7,
+            next_attempt_at = $8, updated_at = $9, started_at = $10, finished_at = $11
+        WHERE id = $1
+    `
+
+	tag, err := r.pool.Exec(ctx, query,
+		job.ID,
+		string(job.Status),
+		utcOrNil(job.Cursor),
+		job.Points,
+		job.TotalPoints,
+		job.Attempts,
+		job.LastError,
+		job.NextAttemptAt.UTC(),
+		job.UpdatedAt.UTC(),
+		utcOrNil(job.StartedAt),
+		utcOrNil(job.FinishedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("update backfill job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindLatest - последняя по времени создания задача предмета
+func (r *backfillJobRepository) FindLatest(ctx context.Context, key domain.ItemKey) (*domain.BackfillJob, error) {
+	query := `
+        SELECT ` + backfillJobColumns + `
+        FROM public.price_backfill_jobs
+        WHERE app_id = $1 AND market_hash_name = $2
+        ORDER BY created_at DESC, id DESC
+        LIMIT 1
+    `
+
+	job, err := scanBackfillJob(r.pool.QueryRow(ctx, query, key.AppID, key.MarketHashName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query backfill job: %w", err)
+	}
+
+	return job, nil
+}
+
+// List - задачи по убыванию времени создания; пустой Status - любые
+func (r *backfillJobRepository) List(ctx context.Context, filter domain.BackfillJobFilter) ([]domain.BackfillJob, error) {
+	query := `
+        SELECT ` + backfillJobColumns + `
+        FROM public.price_backfill_jobs
+        WHERE $1 = '' OR status = $1
+        ORDER BY created_at DESC, id DESC
+        LIMIT $2
+    `
+
+	rows, err := r.pool.Query(ctx, query, string(filter.Status), filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query backfill jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.BackfillJob{}
+	for rows.Next() {
+		job, err := scanBackfillJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan backfill job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate backfill jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// utcOrNil - nullable TIMESTAMP: nil → NULL, иначе время в UTC
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// scanBackfillJob - общий Scan для pgx.Row и pgx.Rows (порядок = backfillJobColumns)
+func scanBackfillJob(row pgx.Row) (*domain.BackfillJob, error) {
+	var (
+		job             domain.BackfillJob
+		status, trigger string
+	)
+	err := row.Scan(
+		&job.ID,
+		&job.AppID,
+		&job.MarketHashName,
+		&status,
+		&trigger,
+		&job.RequestedBy,
+		&job.Cursor,
+		&job.Points,
+		&job.TotalPoints,
+		&job.Attempts,
+		&job.LastError,
+		&job.NextAttemptAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Status = domain.BackfillStatus(status)
+	job.Trigger = domain.BackfillTrigger(trigger)
+	return &job, nil
+}
@@ -0,0 +1,308 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// catalogRepository - PostgreSQL реализация CatalogRepository
+type catalogRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCatalogRepository - создаёт репозиторий каталога предметов
+func NewCatalogRepository(pool *pgxpool.Pool) out_ports.CatalogRepository {
+	return &catalogRepository{pool: pool}
+}
+
+const catalogColumns = `app_id, market_hash_name, name, icon_url, type, weapon, skin, rarity, exterior,
+               stattrak, souvenir, created_at, updated_at`
+
+// Upsert - создаёт или обновляет карточку
+func (r *catalogRepository) Upsert(ctx context.Context, item *domain.CatalogItem) error {
+	query := `
+        INSERT INTO public.item_catalog
+            (app_id, market_hash_name, name, icon_url, type, weapon, skin, rarity, exterior,
+             stattrak, souvenir, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+        ON CONFLICT (app_id, market_hash_name) DO UPDATE
+        SET name = EXCLUDED.name,
+            icon_url = COALESCE(NULLIF(EXCLUDED.icon_url, ''), item_catalog.icon_url),
+            type = EXCLUDED.type,
+            weapon = EXCLUDED.weapon,
+            skin = EXCLUDED.skin,
+            rarity = COALESCE(NULLIF(EXCLUDED.rarity, ''), item_catalog.rarity),
+            exterior = EXCLUDED.exterior,
+            stattrak = EXCLUDED.stattrak,
+            souvenir = EXCLUDED.souvenir,
+            updated_at = NOW()
+        RETURNING created_at, updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		item.AppID,
+		item.MarketHashName,
+		item.Name,
+		item.IconURL,
+		string(item.Type),
+		item.Weapon,
+		item.Skin,
+		item.Rarity,
+		string(item.Exterior),
+		item.StatTrak,
+		item.Souvenir,
+	).Scan(&item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert catalog item: %w", err)
+	}
+
+	return nil
+}
+
+// FindByNameFold - карточка по имени без учёта регистра
+func (r *catalogRepository) FindByNameFold(ctx context.Context, appID int, marketHashName string) (*domain.CatalogItem, error) {
+	query := `
+        SELECT ` + catalogColumns + `
+        FROM public.item_catalog
+        WHERE app_id = $1 AND lower(market_hash_name) = lower($2)
+        LIMIT 1
+    `
+
+	item, err := scanCatalogItem(r.pool.QueryRow(ctx, query, appID, marketHashName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query catalog item: %w", err)
+	}
+
+	return item, nil
+}
+
+// searchSimilarityThreshold - порог word_similarity для поиска
+// Стандартные 0.6 отсекают опечатки вроде "redlnie" → "Redline" (0.5)
+const searchSimilarityThreshold = 0.35
+
+// List - карточки по фильтру
+func (r *catalogRepository) List(ctx context.Context, filter domain.CatalogFilter) ([]domain.CatalogItem, error) {
+	conditions, args := catalogFilterConditions(filter)
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM public.item_catalog
+        %s
+        ORDER BY market_hash_name ASC
+        LIMIT $%d OFFSET $%d
+    `, catalogColumns, where, len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query catalog: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.CatalogItem{}
+	for rows.Next() {
+		item, err := scanCatalogItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan catalog item: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catalog: %w", err)
+	}
+
+	return items, nil
+}
+
+// Search - нечёткий поиск по каталогу
+//
+// Кандидаты отбираются оператором <% (word_similarity), он идёт по GIN индексу
+// из 010_catalog_search.sql; порог задаётся на время транзакции через set_config.
+// Подстрока проверяется отдельно: короткий запрос ("ak") может не набрать порог.
+func (r *catalogRepository) Search(ctx context.Context, q domain.CatalogSearchQuery) ([]domain.CatalogSearchResult, error) {
+	conditions, args := catalogFilterConditions(q.Filter)
+
+	args = append(args, q.Query)
+	queryParam := len(args)
+	conditions = append(conditions, fmt.Sprintf(
+		"($%[1]d <%% market_hash_name OR strpos(lower(market_hash_name), lower($%[1]d)) > 0)", queryParam))
+
+	args = append(args, q.Filter.Limit, q.Filter.Offset)
+	query := fmt.Sprintf(`
+        SELECT %[1]s,
+               GREATEST(word_similarity($%[3]d, market_hash_name), similarity($%[3]d, market_hash_name)) AS score
+        FROM public.item_catalog
+        WHERE %[2]s
+        ORDER BY starts_with(lower(market_hash_name), lower($%[3]d)) DESC,
+                 strpos(lower(market_hash_name), lower($%[3]d)) > 0 DESC,
+                 score DESC,
+                 market_hash_name ASC
+        LIMIT $%[4]d OFFSET $%[5]d
+    `, catalogColumns, strings.Join(conditions, " AND "), queryParam, len(args)-1, len(args))
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`,
+		strconv.FormatFloat(searchSimilarityThreshold, 'f', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("set similarity threshold: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search catalog: %w", err)
+	}
+	defer rows.Close()
+
+	results := []domain.CatalogSearchResult{}
+	for rows.Next() {
+		var res domain.CatalogSearchResult
+		var itemType, exterior string
+		err := rows.Scan(
+			&res.AppID,
+			&res.MarketHashName,
+			&res.Name,
+			&res.IconURL,
+			&itemType,
+			&res.Weapon,
+			&res.Skin,
+			&res.Rarity,
+			&exterior,
+			&res.StatTrak,
+			&res.Souvenir,
+			&res.CreatedAt,
+			&res.UpdatedAt,
+			&res.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan catalog search result: %w", err)
+		}
+		res.Type = domain.ItemType(itemType)
+		res.Exterior = domain.Exterior(exterior)
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catalog search results: %w", err)
+	}
+
+	return results, nil
+}
+
+// ListMissingTracked - отслеживаемые предметы без карточки в каталоге
+func (r *catalogRepository) ListMissingTracked(ctx context.Context) ([]domain.ItemKey, error) {
+	query := `
+        SELECT DISTINCT t.app_id, t.market_hash_name
+        FROM public.tracked_items t
+        LEFT JOIN public.item_catalog c
+            ON c.app_id = t.app_id AND c.market_hash_name = t.market_hash_name
+        WHERE c.app_id IS NULL
+    `
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query tracked items missing from catalog: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.ItemKey{}
+	for rows.Next() {
+		var key domain.ItemKey
+		if err := rows.Scan(&key.AppID, &key.MarketHashName); err != nil {
+			return nil, fmt.Errorf("scan item key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// catalogFilterConditions - условия WHERE из непустых полей фильтра
+// Значения - только через параметры ($1..$N по порядку args)
+func catalogFilterConditions(filter domain.CatalogFilter) ([]string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.AppID != 0 {
+		add("app_id = $%d", filter.AppID)
+	}
+	if filter.Type != "" {
+		add("type = $%d", string(filter.Type))
+	}
+	if filter.Weapon != "" {
+		add("lower(weapon) = lower($%d)", filter.Weapon)
+	}
+	if filter.Exterior != "" {
+		add("exterior = $%d", string(filter.Exterior))
+	}
+	if filter.Rarity != "" {
+		add("lower(rarity) = lower($%d)", filter.Rarity)
+	}
+	if filter.StatTrak != nil {
+		add("stattrak = $%d", *filter.StatTrak)
+	}
+	if filter.Souvenir != nil {
+		add("souvenir = $%d", *filter.Souvenir)
+	}
+
+	return conditions, args
+}
+
+// scanCatalogItem - общий Scan для pgx.Row и pgx.Rows (порядок = catalogColumns)
+func scanCatalogItem(row pgx.Row) (*domain.CatalogItem, error) {
+	var c domain.CatalogItem
+	var itemType, exterior string
+	err := row.Scan(
+		&c.AppID,
+		&c.MarketHashName,
+		&c.Name,
+		&c.IconURL,
+		&itemType,
+		&c.Weapon,
+		&c.Skin,
+		&c.Rarity,
+		&exterior,
+		&c.StatTrak,
+		&c.Souvenir,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Type = domain.ItemType(itemType)
+	c.Exterior = domain.Exterior(exterior)
+	return &c, nil
+}
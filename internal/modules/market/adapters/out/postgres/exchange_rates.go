@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// exchangeRateRepository - PostgreSQL реализация ExchangeRateRepository
+type exchangeRateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExchangeRateRepository - создаёт репозиторий курсов валют
+func NewExchangeRateRepository(pool *pgxpool.Pool) out_ports.ExchangeRateRepository {
+	return &exchangeRateRepository{pool: pool}
+}
+
+// Save - заменяет курсы одной транзакцией: читатели не видят смесь старых и новых
+func (r *exchangeRateRepository) Save(ctx context.Context, rates *domain.ExchangeRates) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM public.exchange_rates`); err != nil {
+		return fmt.Errorf("clear exchange rates: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for currency, rate := range rates.Rates {
+		batch.Queue(`
+            INSERT INTO public.exchange_rates (currency, base, rate, source, updated_at)
+            VALUES ($1, $2, $3, $4, $5)
+        `, int(currency), int(rates.Base), rate, rates.Source, rates.UpdatedAt.UTC())
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("insert exchange rates: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// Load - сохранённые курсы; ErrNotFound если их ещё нет
+func (r *exchangeRateRepository) Load(ctx context.Context) (*domain.ExchangeRates, error) {
+	rows, err := r.pool.Query(ctx, `
+        SELECT currency, base, rate, source, updated_at
+        FROM public.exchange_rates
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("query exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		base      int
+		source    string
+		updatedAt time.Time
+	)
+	rates := map[domain.Currency]float64{}
+	for rows.Next() {
+		var currency int
+		var rate float64
+		if err := rows.Scan(&currency, &base, &rate, &source, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan exchange rate: %w", err)
+		}
+		rates[domain.Currency(currency)] = rate
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate exchange rates: %w", err)
+	}
+
+	if len(rates) == 0 {
+		return nil, out_ports.ErrNotFound
+	}
+
+	loaded := domain.NewExchangeRates(domain.Currency(base), rates, updatedAt)
+	loaded.Source = source
+	return loaded, nil
+}
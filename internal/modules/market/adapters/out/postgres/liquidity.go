@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// liquidityRepository - PostgreSQL реализация LiquidityRepository
+type liquidityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLiquidityRepository - создаёт репозиторий метрик ликвидности
+func NewLiquidityRepository(pool *pgxpool.Pool) out_ports.LiquidityRepository {
+	return &liquidityRepository{pool: pool}
+}
+
+const liquidityColumns = `app_id, market_hash_name, source, days, sales, daily_volume, volume_7d,
+               volume_trend, active_days, time_to_sell_hours, score, computed_at`
+
+// Save - upsert по (app_id, market_hash_name)
+func (r *liquidityRepository) Save(ctx context.Context, l *domain.ItemLiquidity) error {
+	query := `
+        INSERT INTO public.item_liquidity (` + liquidityColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        ON CONFLICT (app_id, market_hash_name) DO UPDATE SET
+            source = EXCLUDED.source,
+            days = EXCLUDED.days,
+            sales = EXCLUDED.sales,
+            daily_volume = EXCLUDED.daily_volume,
+            volume_7d = EXCLUDED.volume_7d,
+            volume_trend = EXCLUDED.volume_trend,
+            active_days = EXCLUDED.active_days,
+            time_to_sell_hours = EXCLUDED.time_to_sell_hours,
+            score = EXCLUDED.score,
+            computed_at = EXCLUDED.computed_at
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		l.AppID,
+		l.MarketHashName,
+		string(l.Source),
+		l.Days,
+		l.Sales,
+		l.DailyVolume,
+		l.Volume7d,
+		l.VolumeTrend,
+		l.ActiveDays,
+		l.TimeToSellHours,
+		l.Score,
+		l.ComputedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("save item liquidity: %w", err)
+	}
+
+	return nil
+}
+
+// Find - метрики одного предмета
+func (r *liquidityRepository) Find(ctx context.Context, key domain.ItemKey) (*domain.ItemLiquidity, error) {
+	query := `
+        SELECT ` + liquidityColumns + `
+        FROM public.item_liquidity
+        WHERE app_id = $1 AND market_hash_name = $2
+    `
+
+	liquidity, err := scanLiquidity(r.pool.QueryRow(ctx, query, key.AppID, key.MarketHashName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query item liquidity: %w", err)
+	}
+
+	return liquidity, nil
+}
+
+// ListByKeys - метрики набора предметов одним запросом
+func (r *liquidityRepository) ListByKeys(ctx context.Context, keys []domain.ItemKey) ([]domain.ItemLiquidity, error) {
+	list := []domain.ItemLiquidity{}
+	if len(keys) == 0 {
+		return list, nil
+	}
+
+	appIDs := make([]int32, len(keys))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		appIDs[i] = int32(key.AppID)
+		names[i] = key.MarketHashName
+	}
+
+	query := `
+        SELECT ` + liquidityColumns + `
+        FROM public.item_liquidity
+        WHERE (app_id, market_hash_name) IN (
+            SELECT * FROM unnest($1::int[], $2::text[])
+        )
+    `
+
+	rows, err := r.pool.Query(ctx, query, appIDs, names)
+	if err != nil {
+		return nil, fmt.Errorf("query item liquidity: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		liquidity, err := scanLiquidity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan item liquidity: %w", err)
+		}
+		list = append(list, *liquidity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item liquidity: %w", err)
+	}
+
+	return list, nil
+}
+
+// scanLiquidity - общий Scan для pgx.Row и pgx.Rows (порядок = liquidityColumns)
+func scanLiquidity(row pgx.Row) (*domain.ItemLiquidity, error) {
+	var (
+		l      domain.ItemLiquidity
+		source string
+	)
+	err := row.Scan(
+		&l.AppID,
+		&l.MarketHashName,
+		&source,
+		&l.Days,
+		&l.Sales,
+		&l.DailyVolume,
+		&l.Volume7d,
+		&l.VolumeTrend,
+		&l.ActiveDays,
+		&l.TimeToSellHours,
+		&l.Score,
+		&l.ComputedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	l.Source = domain.LiquiditySource(source)
+	return &l, nil
+}
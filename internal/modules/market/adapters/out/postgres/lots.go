@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// lotRepository - PostgreSQL реализация LotRepository
+type lotRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLotRepository - создаёт репозиторий сделок портфеля
+func NewLotRepository(pool *pgxpool.Pool) out_ports.LotRepository {
+	return &lotRepository{pool: pool}
+}
+
+const lotColumns = `id, user_id, tracked_item_id, side, quantity, unit_price, fee, currency,
+               executed_at, notes, created_at`
+
+// ListByUser - все сделки пользователя
+func (r *lotRepository) ListByUser(ctx context.Context, userID string) ([]domain.Lot, error) {
+	query := `
+        SELECT ` + lotColumns + `
+        FROM public.portfolio_lots
+        WHERE user_id = $1
+        ORDER BY executed_at ASC, created_at ASC
+    `
+	return r.queryLots(ctx, query, userID)
+}
+
+// ListByItem - сделки по предмету
+func (r *lotRepository) ListByItem(ctx context.Context, userID, trackedItemID string) ([]domain.Lot, error) {
+	query := `
+        SELECT ` + lotColumns + `
+        FROM public.portfolio_lots
+        WHERE user_id = $1 AND tracked_item_id = $2
+        ORDER BY executed_at ASC, created_at ASC
+    `
+	return r.queryLots(ctx, query, userID, trackedItemID)
+}
+
+// FindByID - сделка пользователя по ID
+func (r *lotRepository) FindByID(ctx context.Context, userID, lotID string) (*domain.Lot, error) {
+	query := `
+        SELECT ` + lotColumns + `
+        FROM public.portfolio_lots
+        WHERE id = $1 AND user_id = $2
+    `
+
+	lot, err := scanLot(r.pool.QueryRow(ctx, query, lotID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query lot by id: %w", err)
+	}
+
+	return lot, nil
+}
+
+// Create - сохраняет сделку
+func (r *lotRepository) Create(ctx context.Context, lot *domain.Lot) error {
+	if err := lot.Validate(); err != nil {
+		return fmt.Errorf("invalid lot: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.portfolio_lots (` + lotColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		lot.ID,
+		lot.UserID,
+		lot.TrackedItemID,
+		string(lot.Side),
+		lot.Quantity,
+		lot.UnitPrice,
+		lot.Fee,
+		int(lot.Currency),
+		lot.ExecutedAt.UTC(),
+		lot.Notes,
+		lot.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert lot: %w", err)
+	}
+
+	return nil
+}
+
+// Update - сохраняет поля сделки (предмет сделки не меняется)
+func (r *lotRepository) Update(ctx context.Context, lot *domain.Lot) error {
+	if err := lot.Validate(); err != nil {
+		return fmt.Errorf("invalid lot: %w", err)
+	}
+
+	query := `
+        UPDATE public.portfolio_lots
+        SET side = $3, quantity = $4, unit_price = $5, fee = $6, currency = $7, executed_at = $8, notes = $9
+        WHERE id = $1 AND user_id = $2
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query,
+		lot.ID,
+		lot.UserID,
+		string(lot.Side),
+		lot.Quantity,
+		lot.UnitPrice,
+		lot.Fee,
+		int(lot.Currency),
+		lot.ExecutedAt.UTC(),
+		lot.Notes,
+	)
+	if err != nil {
+		return fmt.Errorf("update lot: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete - удаляет сделку пользователя
+func (r *lotRepository) Delete(ctx context.Context, userID, lotID string) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`DELETE FROM public.portfolio_lots WHERE id = $1 AND user_id = $2`,
+		lotID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete lot: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// queryLots - общий Query + Scan для списков сделок
+func (r *lotRepository) queryLots(ctx context.Context, query string, args ...any) ([]domain.Lot, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query lots: %w", err)
+	}
+	defer rows.Close()
+
+	lots := []domain.Lot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan lot: %w", err)
+		}
+		lots = append(lots, *lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lots: %w", err)
+	}
+
+	return lots, nil
+}
+
+// scanLot - общий Scan для pgx.Row и pgx.Rows (порядок = lotColumns)
+func scanLot(row pgx.Row) (*domain.Lot, error) {
+	var l domain.Lot
+	var side string
+	var currency int
+	err := row.Scan(
+		&l.ID,
+		&l.UserID,
+		&l.TrackedItemID,
+		&side,
+		&l.Quantity,
+		&l.UnitPrice,
+		&l.Fee,
+		&currency,
+		&l.ExecutedAt,
+		&l.Notes,
+		&l.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	l.Side = domain.LotSide(side)
+	l.Currency = domain.Currency(currency)
+	return &l, nil
+}
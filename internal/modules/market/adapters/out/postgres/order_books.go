@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// orderBookRepository - PostgreSQL реализация OrderBookRepository
+type orderBookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderBookRepository - создаёт репозиторий снимков стаканов
+func NewOrderBookRepository(pool *pgxpool.Pool) out_ports.OrderBookRepository {
+	return &orderBookRepository{pool: pool}
+}
+
+// highest_bid / lowest_ask не читаем: они выводятся из уровней и хранятся для запросов в SQL
+const orderBookColumns = `app_id, market_hash_name, currency, bid_orders, ask_orders, bids, asks, observed_at`
+
+// Save - сохраняет снимок стакана
+func (r *orderBookRepository) Save(ctx context.Context, book *domain.OrderBook) error {
+	bids, err := json.Marshal(levelsOrEmpty(book.Bids))
+	if err != nil {
+		return fmt.Errorf("marshal bids: %w", err)
+	}
+	asks, err := json.Marshal(levelsOrEmpty(book.Asks))
+	if err != nil {
+		return fmt.Errorf("marshal asks: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.order_book_snapshots
+            (app_id, market_hash_name, currency, highest_bid, lowest_ask, bid_orders, ask_orders, bids, asks, observed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+
+	_, err = r.pool.Exec(ctx, query,
+		book.AppID,
+		book.MarketHashName,
+		int(book.Currency),
+		nullIfZero(book.HighestBid()),
+		nullIfZero(book.LowestAsk()),
+		book.BidOrders,
+		book.AskOrders,
+		bids,
+		asks,
+		book.ObservedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert order book snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Latest - последний снимок предмета
+func (r *orderBookRepository) Latest(ctx context.Context, key domain.ItemKey) (*domain.OrderBook, error) {
+	query := `
+        SELECT ` + orderBookColumns + `
+        FROM public.order_book_snapshots
+        WHERE app_id = $1 AND market_hash_name = $2
+        ORDER BY observed_at DESC
+        LIMIT 1
+    `
+
+	book, err := scanOrderBook(r.pool.QueryRow(ctx, query, key.AppID, key.MarketHashName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query latest order book: %w", err)
+	}
+
+	return book, nil
+}
+
+// ListRange - снимки предмета за [from, to) по возрастанию времени
+func (r *orderBookRepository) ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.OrderBook, error) {
+	query := `
+        SELECT ` + orderBookColumns + `
+        FROM public.order_book_snapshots
+        WHERE app_id = $1 AND market_hash_name = $2
+          AND observed_at >= $3 AND observed_at < $4
+        ORDER BY observed_at ASC
+    `
+
+	rows, err := r.pool.Query(ctx, query, key.AppID, key.MarketHashName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("query order books: %w", err)
+	}
+	defer rows.Close()
+
+	books := []domain.OrderBook{}
+	for rows.Next() {
+		book, err := scanOrderBook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan order book: %w", err)
+		}
+		books = append(books, *book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate order books: %w", err)
+	}
+
+	return books, nil
+}
+
+// FindItemNameID - сохранённый item_nameid предмета
+func (r *orderBookRepository) FindItemNameID(ctx context.Context, key domain.ItemKey) (int64, error) {
+	var itemNameID int64
+	err := r.pool.QueryRow(ctx,
+		`SELECT item_nameid FROM public.item_name_ids WHERE app_id = $1 AND market_hash_name = $2`,
+		key.AppID, key.MarketHashName,
+	).Scan(&itemNameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, out_ports.ErrNotFound
+		}
+		return 0, fmt.Errorf("query item_nameid: %w", err)
+	}
+
+	return itemNameID, nil
+}
+
+// SaveItemNameID - запоминает item_nameid предмета
+func (r *orderBookRepository) SaveItemNameID(ctx context.Context, key domain.ItemKey, itemNameID int64) error {
+	query := `
+        INSERT INTO public.item_name_ids (app_id, market_hash_name, item_nameid)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (app_id, market_hash_name) DO UPDATE SET item_nameid = EXCLUDED.item_nameid
+    `
+
+	if _, err := r.pool.Exec(ctx, query, key.AppID, key.MarketHashName, itemNameID); err != nil {
+		return fmt.Errorf("save item_nameid: %w", err)
+	}
+
+	return nil
+}
+
+// scanOrderBook - общий Scan для pgx.Row и pgx.Rows (порядок = orderBookColumns)
+func scanOrderBook(row pgx.Row) (*domain.OrderBook, error) {
+	var b domain.OrderBook
+	var currency int
+	var bids, asks []byte
+	err := row.Scan(
+		&b.AppID,
+		&b.MarketHashName,
+		&currency,
+		&b.BidOrders,
+		&b.AskOrders,
+		&bids,
+		&asks,
+		&b.ObservedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	b.Currency = domain.Currency(currency)
+
+	if err := json.Unmarshal(bids, &b.Bids); err != nil {
+		return nil, fmt.Errorf("decode bids: %w", err)
+	}
+	if err := json.Unmarshal(asks, &b.Asks); err != nil {
+		return nil, fmt.Errorf("decode asks: %w", err)
+	}
+
+	return &b, nil
+}
+
+// levelsOrEmpty - nil срез → [] (в JSONB колонке NOT NULL, null нам не нужен)
+func levelsOrEmpty(levels []domain.OrderBookLevel) []domain.OrderBookLevel {
+	if levels == nil {
+		return []domain.OrderBookLevel{}
+	}
+	return levels
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// userPreferencesRepository - PostgreSQL реализация UserPreferencesRepository
+type userPreferencesRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserPreferencesRepository - создаёт репозиторий настроек пользователей
+func NewUserPreferencesRepository(pool *pgxpool.Pool) out_ports.UserPreferencesRepository {
+	return &userPreferencesRepository{pool: pool}
+}
+
+// Get - настройки пользователя; ErrNotFound если строки ещё нет
+func (r *userPreferencesRepository) Get(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	query := `
+        SELECT user_id, display_currency, COALESCE(steam_id, ''), updated_at
+        FROM public.user_preferences
+        WHERE user_id = $1
+    `
+
+	var prefs domain.UserPreferences
+	var currency int
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&prefs.UserID, &currency, &prefs.SteamID, &prefs.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query user preferences: %w", err)
+	}
+	prefs.DisplayCurrency = domain.Currency(currency)
+
+	return &prefs, nil
+}
+
+// Upsert - создаёт или перезаписывает настройки пользователя
+func (r *userPreferencesRepository) Upsert(ctx context.Context, prefs *domain.UserPreferences) error {
+	if err := prefs.Validate(); err != nil {
+		return fmt.Errorf("invalid user preferences: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.user_preferences (user_id, display_currency, steam_id, updated_at)
+        VALUES ($1, $2, NULLIF($3, ''), NOW())
+        ON CONFLICT (user_id) DO UPDATE
+        SET display_currency = EXCLUDED.display_currency, steam_id = EXCLUDED.steam_id, updated_at = NOW()
+        RETURNING updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query, prefs.UserID, int(prefs.DisplayCurrency), prefs.SteamID).Scan(&prefs.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert user preferences: %w", err)
+	}
+
+	return nil
+}
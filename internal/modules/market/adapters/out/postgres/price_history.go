@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// priceHistoryRepository - PostgreSQL реализация PriceHistoryRepository
+type priceHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPriceHistoryRepository - создаёт репозиторий загруженной истории цен
+func NewPriceHistoryRepository(pool *pgxpool.Pool) out_ports.PriceHistoryRepository {
+	return &priceHistoryRepository{pool: pool}
+}
+
+// ListRange - точки истории за [from, to) в виде снимков (без lowest_price)
+func (r *priceHistoryRepository) ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.PriceSnapshot, error) {
+	query := `
+        SELECT app_id, market_hash_name, currency, price, volume, observed_at
+        FROM public.price_history
+        WHERE app_id = $1 AND market_hash_name = $2
+          AND observed_at >= $3 AND observed_at < $4
+        ORDER BY observed_at ASC
+    `
+
+	rows, err := r.pool.Query(ctx, query, key.AppID, key.MarketHashName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("query price history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []domain.PriceSnapshot{}
+	for rows.Next() {
+		var (
+			s        domain.PriceSnapshot
+			currency int
+		)
+		if err := rows.Scan(&s.AppID, &s.MarketHashName, &currency, &s.MedianPrice, &s.Volume, &s.ObservedAt); err != nil {
+			return nil, fmt.Errorf("scan price history: %w", err)
+		}
+		s.Currency = domain.Currency(currency)
+		history = append(history, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate price history: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// priceSnapshotColumns - COALESCE: NULL цены в домене представлены нулём
+const priceSnapshotColumns = `id, app_id, market_hash_name, currency,
+               COALESCE(lowest_price, 0), COALESCE(median_price, 0), volume, observed_at`
+
+// priceSnapshotRepository - PostgreSQL реализация PriceSnapshotRepository
+type priceSnapshotRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPriceSnapshotRepository - создаёт репозиторий снимков цен
+func NewPriceSnapshotRepository(pool *pgxpool.Pool) out_ports.PriceSnapshotRepository {
+	return &priceSnapshotRepository{pool: pool}
+}
+
+// Save - сохраняет снимок, заполняет snapshot.ID
+func (r *priceSnapshotRepository) Save(ctx context.Context, snapshot *domain.PriceSnapshot) error {
+	query := `
+        INSERT INTO public.price_snapshots
+            (app_id, market_hash_name, currency, lowest_price, median_price, volume, observed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		snapshot.AppID,
+		snapshot.MarketHashName,
+		int(snapshot.Currency),
+		nullIfZero(snapshot.LowestPrice), // 0 = "нет данных" → NULL
+		nullIfZero(snapshot.MedianPrice),
+		snapshot.Volume,
+		snapshot.ObservedAt.UTC(),
+	).Scan(&snapshot.ID)
+	if err != nil {
+		return fmt.Errorf("insert price snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ListRange - снимки предмета за [from, to) по возрастанию времени
+func (r *priceSnapshotRepository) ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.PriceSnapshot, error) {
+	query := `
+        SELECT ` + priceSnapshotColumns + `
+        FROM public.price_snapshots
+        WHERE app_id = $1 AND market_hash_name = $2
+          AND observed_at >= $3 AND observed_at < $4
+        ORDER BY observed_at ASC
+    `
+
+	rows, err := r.pool.Query(ctx, query, key.AppID, key.MarketHashName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("query price snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []domain.PriceSnapshot{}
+	for rows.Next() {
+		snapshot, err := scanPriceSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan price snapshot: %w", err)
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate price snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// LatestByKeys - последний снимок каждого из предметов
+// Предметы без снимков в результат не попадают
+func (r *priceSnapshotRepository) LatestByKeys(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.PriceSnapshot, error) {
+	latest := make(map[domain.ItemKey]domain.PriceSnapshot, len(keys))
+	if len(keys) == 0 {
+		return latest, nil
+	}
+
+	appIDs := make([]int32, len(keys))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		appIDs[i] = int32(key.AppID)
+		names[i] = key.MarketHashName
+	}
+
+	query := `
+        SELECT DISTINCT ON (app_id, market_hash_name) ` + priceSnapshotColumns + `
+        FROM public.price_snapshots
+        WHERE (app_id, market_hash_name) IN (
+            SELECT * FROM unnest($1::int[], $2::text[])
+        )
+        ORDER BY app_id, market_hash_name, observed_at DESC
+    `
+
+	rows, err := r.pool.Query(ctx, query, appIDs, names)
+	if err != nil {
+		return nil, fmt.Errorf("query latest price snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		snapshot, err := scanPriceSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan price snapshot: %w", err)
+		}
+		latest[snapshot.Key()] = *snapshot
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate price snapshots: %w", err)
+	}
+
+	return latest, nil
+}
+
+// nullIfZero - 0 → NULL для nullable числовых колонок
+func nullIfZero(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// scanPriceSnapshot - общий Scan для pgx.Row и pgx.Rows
+// Порядок полей совпадает с priceSnapshotColumns
+func scanPriceSnapshot(row pgx.Row) (*domain.PriceSnapshot, error) {
+	var s domain.PriceSnapshot
+	var currency int
+	err := row.Scan(
+		&s.ID,
+		&s.AppID,
+		&s.MarketHashName,
+		&currency,
+		&s.LowestPrice,
+		&s.MedianPrice,
+		&s.Volume,
+		&s.ObservedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.Currency = domain.Currency(currency)
+	return &s, nil
+}
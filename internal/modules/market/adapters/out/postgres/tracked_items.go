@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// pgUniqueViolation - код ошибки PostgreSQL для нарушения UNIQUE constraint
+const pgUniqueViolation = "23505"
+
+// trackedItemRepository - PostgreSQL реализация TrackedItemRepository
+type trackedItemRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTrackedItemRepository - создаёт репозиторий отслеживаемых предметов
+// Принимает pgxpool.Pool который управляется в shared/db/db.go
+func NewTrackedItemRepository(pool *pgxpool.Pool) out_ports.TrackedItemRepository {
+	return &trackedItemRepository{pool: pool}
+}
+
+// watchlist_ids - подзапросом: списков у предмета единицы, а JOIN размножил бы строки
+const trackedItemColumns = `id, user_id, app_id, market_hash_name, name, notes, tags,
+               ARRAY(SELECT wi.watchlist_id FROM public.watchlist_items wi
+                     WHERE wi.tracked_item_id = tracked_items.id ORDER BY wi.watchlist_id) AS watchlist_ids,
+               created_at, updated_at`
+
+// ListByUser - все предметы пользователя, новые сверху
+func (r *trackedItemRepository) ListByUser(ctx context.Context, userID string) ([]domain.TrackedItem, error) {
+	query := `
+        SELECT ` + trackedItemColumns + `
+        FROM public.tracked_items
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+    `
+
+	return r.queryItems(ctx, query, userID)
+}
+
+// ListFiltered - предметы пользователя по фильтру, новые сверху
+func (r *trackedItemRepository) ListFiltered(ctx context.Context, userID string, filter domain.TrackedItemFilter) ([]domain.TrackedItem, error) {
+	conditions := []string{"user_id = $1"}
+	args := []any{userID}
+
+	if filter.WatchlistID != "" {
+		args = append(args, filter.WatchlistID)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+            SELECT 1 FROM public.watchlist_items wi
+            WHERE wi.tracked_item_id = tracked_items.id AND wi.watchlist_id = $%d)`, len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(args)))
+	}
+
+	query := `
+        SELECT ` + trackedItemColumns + `
+        FROM public.tracked_items
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        ORDER BY created_at DESC
+    `
+
+	return r.queryItems(ctx, query, args...)
+}
+
+// queryItems - выполняет SELECT trackedItemColumns и собирает предметы
+func (r *trackedItemRepository) queryItems(ctx context.Context, query string, args ...any) ([]domain.TrackedItem, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tracked items: %w", err)
+	}
+	defer rows.Close()
+
+	// Пустой slice (а не nil) → в JSON будет [] вместо null
+	items := []domain.TrackedItem{}
+	for rows.Next() {
+		item, err := scanTrackedItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tracked item: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	// rows.Err() - ошибка, возникшая во время итерации (обрыв соединения и т.п.)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tracked items: %w", err)
+	}
+
+	return items, nil
+}
+
+// FindByID - предмет пользователя по ID
+func (r *trackedItemRepository) FindByID(ctx context.Context, userID, itemID string) (*domain.TrackedItem, error) {
+	query := `
+        SELECT ` + trackedItemColumns + `
+        FROM public.tracked_items
+        WHERE id = $1 AND user_id = $2
+    `
+
+	item, err := scanTrackedItem(r.pool.QueryRow(ctx, query, itemID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query tracked item by id: %w", err)
+	}
+
+	return item, nil
+}
+
+// Create - сохраняет новый предмет
+func (r *trackedItemRepository) Create(ctx context.Context, item *domain.TrackedItem) error {
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("invalid tracked item: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.tracked_items (id, user_id, app_id, market_hash_name, name, notes, tags, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+        RETURNING created_at, updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		item.ID,
+		item.UserID,
+		item.AppID,
+		item.MarketHashName,
+		item.Name,
+		item.Notes,
+		nonNilTags(item.Tags),
+	).Scan(&item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		// errors.As достаёт *pgconn.PgError из цепочки ошибок,
+		// чтобы по коду отличить нарушение UNIQUE от прочих проблем с БД
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return out_ports.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert tracked item: %w", err)
+	}
+
+	return nil
+}
+
+// Update - сохраняет изменяемые поля (name, notes, tags)
+func (r *trackedItemRepository) Update(ctx context.Context, item *domain.TrackedItem) error {
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("invalid tracked item: %w", err)
+	}
+
+	// app_id и market_hash_name не меняются: это идентичность предмета
+	query := `
+        UPDATE public.tracked_items
+        SET name = $3, notes = $4, tags = $5, updated_at = NOW()
+        WHERE id = $1 AND user_id = $2
+        RETURNING updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query, item.ID, item.UserID, item.Name, item.Notes, nonNilTags(item.Tags)).Scan(&item.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out_ports.ErrNotFound
+		}
+		return fmt.Errorf("update tracked item: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - удаляет предмет пользователя
+func (r *trackedItemRepository) Delete(ctx context.Context, userID, itemID string) error {
+	query := `
+        DELETE FROM public.tracked_items
+        WHERE id = $1 AND user_id = $2
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query, itemID, userID)
+	if err != nil {
+		return fmt.Errorf("delete tracked item: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListDistinctKeys - уникальные (app_id, market_hash_name) по всем пользователям
+func (r *trackedItemRepository) ListDistinctKeys(ctx context.Context) ([]domain.ItemKey, error) {
+	query := `
+        SELECT DISTINCT app_id, market_hash_name
+        FROM public.tracked_items
+        ORDER BY app_id, market_hash_name
+    `
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query distinct item keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.ItemKey{}
+	for rows.Next() {
+		var key domain.ItemKey
+		if err := rows.Scan(&key.AppID, &key.MarketHashName); err != nil {
+			return nil, fmt.Errorf("scan item key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// scanTrackedItem - общий Scan для pgx.Row и pgx.Rows
+// Порядок полей совпадает с trackedItemColumns
+func scanTrackedItem(row pgx.Row) (*domain.TrackedItem, error) {
+	var item domain.TrackedItem
+	err := row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.AppID,
+		&item.MarketHashName,
+		&item.Name,
+		&item.Notes,
+		&item.Tags,
+		&item.WatchlistIDs,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// nonNilTags - nil slice pgx пишет как NULL, а колонка tags NOT NULL
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// watchlistRepository - PostgreSQL реализация WatchlistRepository
+type watchlistRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWatchlistRepository - создаёт репозиторий списков
+func NewWatchlistRepository(pool *pgxpool.Pool) out_ports.WatchlistRepository {
+	return &watchlistRepository{pool: pool}
+}
+
+const watchlistColumns = `w.id, w.user_id, w.name, w.description, w.share_token,
+               (SELECT COUNT(*) FROM public.watchlist_items wi WHERE wi.watchlist_id = w.id) AS item_count,
+               w.created_at, w.updated_at`
+
+// ListByUser - списки пользователя по имени
+func (r *watchlistRepository) ListByUser(ctx context.Context, userID string) ([]domain.Watchlist, error) {
+	query := `
+        SELECT ` + watchlistColumns + `
+        FROM public.watchlists w
+        WHERE w.user_id = $1
+        ORDER BY w.name
+    `
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query watchlists: %w", err)
+	}
+	defer rows.Close()
+
+	watchlists := []domain.Watchlist{}
+	for rows.Next() {
+		watchlist, err := scanWatchlist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan watchlist: %w", err)
+		}
+		watchlists = append(watchlists, *watchlist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate watchlists: %w", err)
+	}
+
+	return watchlists, nil
+}
+
+// FindByID - список пользователя по ID
+func (r *watchlistRepository) FindByID(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error) {
+	query := `
+        SELECT ` + watchlistColumns + `
+        FROM public.watchlists w
+        WHERE w.id = $1 AND w.user_id = $2
+    `
+
+	return r.queryOne(ctx, query, watchlistID, userID)
+}
+
+// FindByShareToken - опубликованный список по токену ссылки
+func (r *watchlistRepository) FindByShareToken(ctx context.Context, token string) (*domain.Watchlist, error) {
+	query := `
+        SELECT ` + watchlistColumns + `
+        FROM public.watchlists w
+        WHERE w.share_token = $1
+    `
+
+	return r.queryOne(ctx, query, token)
+}
+
+func (r *watchlistRepository) queryOne(ctx context.Context, query string, args ...any) (*domain.Watchlist, error) {
+	watchlist, err := scanWatchlist(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query watchlist: %w", err)
+	}
+	return watchlist, nil
+}
+
+// Create - сохраняет новый список
+func (r *watchlistRepository) Create(ctx context.Context, watchlist *domain.Watchlist) error {
+	if err := watchlist.Validate(); err != nil {
+		return fmt.Errorf("invalid watchlist: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.watchlists (id, user_id, name, description, share_token, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+        RETURNING created_at, updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		watchlist.ID,
+		watchlist.UserID,
+		watchlist.Name,
+		watchlist.Description,
+		watchlist.ShareToken,
+	).Scan(&watchlist.CreatedAt, &watchlist.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return out_ports.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert watchlist: %w", err)
+	}
+
+	return nil
+}
+
+// Update - сохраняет name, description, share_token
+func (r *watchlistRepository) Update(ctx context.Context, watchlist *domain.Watchlist) error {
+	if err := watchlist.Validate(); err != nil {
+		return fmt.Errorf("invalid watchlist: %w", err)
+	}
+
+	query := `
+        UPDATE public.watchlists
+        SET name = $3, description = $4, share_token = $5, updated_at = NOW()
+        WHERE id = $1 AND user_id = $2
+        RETURNING updated_at
+    `
+
+	err := r.pool.QueryRow(ctx, query,
+		watchlist.ID,
+		watchlist.UserID,
+		watchlist.Name,
+		watchlist.Description,
+		watchlist.ShareToken,
+	).Scan(&watchlist.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out_ports.ErrNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return out_ports.ErrAlreadyExists
+		}
+		return fmt.Errorf("update watchlist: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - удаляет список; состав удаляется каскадно
+func (r *watchlistRepository) Delete(ctx context.Context, userID, watchlistID string) error {
+	commandTag, err := r.pool.Exec(ctx, `DELETE FROM public.watchlists WHERE id = $1 AND user_id = $2`, watchlistID, userID)
+	if err != nil {
+		return fmt.Errorf("delete watchlist: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// AddItem - добавляет предмет в список (идемпотентно)
+func (r *watchlistRepository) AddItem(ctx context.Context, watchlistID, trackedItemID string) error {
+	query := `
+        INSERT INTO public.watchlist_items (watchlist_id, tracked_item_id, added_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (watchlist_id, tracked_item_id) DO NOTHING
+    `
+
+	if _, err := r.pool.Exec(ctx, query, watchlistID, trackedItemID); err != nil {
+		return fmt.Errorf("insert watchlist item: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveItem - убирает предмет из списка
+func (r *watchlistRepository) RemoveItem(ctx context.Context, watchlistID, trackedItemID string) error {
+	query := `
+        DELETE FROM public.watchlist_items
+        WHERE watchlist_id = $1 AND tracked_item_id = $2
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query, watchlistID, trackedItemID)
+	if err != nil {
+		return fmt.Errorf("delete watchlist item: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// scanWatchlist - общий Scan для pgx.Row и pgx.Rows (порядок = watchlistColumns)
+func scanWatchlist(row pgx.Row) (*domain.Watchlist, error) {
+	var w domain.Watchlist
+	err := row.Scan(
+		&w.ID,
+		&w.UserID,
+		&w.Name,
+		&w.Description,
+		&w.ShareToken,
+		&w.ItemCount,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
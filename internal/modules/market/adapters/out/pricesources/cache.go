@@ -0,0 +1,181 @@
+package pricesources
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// errFetchAborted - запрос к площадке не завершился (паника в source)
+var errFetchAborted = errors.New("price source fetch aborted")
+
+// cachedSource - кэш котировок перед площадкой
+//
+//   - котировка живёт ttl, повторный запрос того же предмета в этот срок не идёт на площадку;
+//   - "предмета на площадке нет" тоже кэшируется - иначе каждый запрос таких предметов промахивается;
+//   - одновременные промахи по одному предмету схлопываются: на площадку уходит один запрос,
+//     остальные ждут его результат (популярные скины отслеживают многие пользователи);
+//   - промахи одного вызова уходят на площадку одним FetchQuotes - прайс-лист Skinport
+//     скачивается один раз, а не на каждый предмет;
+//   - ошибки не кэшируются.
+type cachedSource struct {
+	source out_ports.PriceSource
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	entries   map[domain.ItemKey]cacheEntry
+	inflight  map[domain.ItemKey]*fetchCall
+	lastSweep time.Time
+}
+
+type cacheEntry struct {
+	quote     domain.VenueQuote
+	found     bool
+	expiresAt time.Time
+}
+
+// fetchCall - запрос к площадке, результат которого ждут все, кто промахнулся по его ключам
+type fetchCall struct {
+	keys   []domain.ItemKey
+	done   chan struct{}
+	quotes map[domain.ItemKey]domain.VenueQuote
+	err    error
+}
+
+// NewCachedSource - source с кэшем на ttl; ttl <= 0 - source как есть
+func NewCachedSource(source out_ports.PriceSource, ttl time.Duration) out_ports.PriceSource {
+	if ttl <= 0 {
+		return source
+	}
+	return &cachedSource{
+		source:    source,
+		ttl:       ttl,
+		entries:   make(map[domain.ItemKey]cacheEntry),
+		inflight:  make(map[domain.ItemKey]*fetchCall),
+		lastSweep: time.Now(),
+	}
+}
+
+func (c *cachedSource) Name() string {
+	return c.source.Name()
+}
+
+func (c *cachedSource) SellerReceives(appID int, gross int64) int64 {
+	return c.source.SellerReceives(appID, gross)
+}
+
+func (c *cachedSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(keys))
+
+	pending := keys
+	for len(pending) > 0 {
+		own, waits := c.lookup(pending, quotes)
+
+		if own != nil {
+			c.fetch(ctx, own)
+		}
+
+		// Ключи, запрос которых сорвался из-за отмены чужого ctx, запрашиваем ещё раз сами
+		pending = nil
+		for key, call := range waits {
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			if call.err != nil {
+				if call != own && isContextError(call.err) && ctx.Err() == nil {
+					pending = append(pending, key)
+					continue
+				}
+				return nil, call.err
+			}
+			if quote, ok := call.quotes[key]; ok {
+				quotes[key] = quote
+			}
+		}
+	}
+
+	return quotes, nil
+}
+
+// lookup - раскладывает keys: свежие из кэша - в quotes, остальные - в waits
+// own - новый запрос для ключей, которые ещё никто не запрашивает (nil - таких нет)
+func (c *cachedSource) lookup(keys []domain.ItemKey, quotes map[domain.ItemKey]domain.VenueQuote) (*fetchCall, map[domain.ItemKey]*fetchCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var (
+		own   *fetchCall
+		waits = make(map[domain.ItemKey]*fetchCall)
+	)
+	for _, key := range keys {
+		if entry, ok := c.entries[key]; ok && now.Before(entry.expiresAt) {
+			if entry.found {
+				quotes[key] = entry.quote
+			}
+			continue
+		}
+		if call, ok := c.inflight[key]; ok {
+			waits[key] = call
+			continue
+		}
+
+		if own == nil {
+			own = &fetchCall{done: make(chan struct{})}
+		}
+		c.inflight[key] = own
+		own.keys = append(own.keys, key)
+		waits[key] = own
+	}
+	return own, waits
+}
+
+// fetch - выполняет запрос call к площадке и кладёт результат в кэш
+// Ждущие call освобождаются и при панике в source (её ловит вызывающий, например poller)
+func (c *cachedSource) fetch(ctx context.Context, call *fetchCall) {
+	call.err = errFetchAborted
+	defer func() {
+		c.mu.Lock()
+		now := time.Now()
+		for _, key := range call.keys {
+			delete(c.inflight, key)
+			if call.err != nil {
+				continue
+			}
+			quote, found := call.quotes[key]
+			c.entries[key] = cacheEntry{quote: quote, found: found, expiresAt: now.Add(c.ttl)}
+		}
+		c.sweep(now)
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	call.quotes, call.err = c.source.FetchQuotes(ctx, call.keys)
+}
+
+// sweep - раз в ttl выбрасывает протухшие записи, чтобы кэш не рос от удалённых предметов
+// Вызывается под c.mu
+func (c *cachedSource) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,202 @@
+package pricesources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// fakeSource - PriceSource, который считает запросы и отвечает через fetch
+type fakeSource struct {
+	mu    sync.Mutex
+	calls [][]domain.ItemKey
+	fetch func(ctx context.Context, call int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error)
+}
+
+func (s *fakeSource) Name() string                        { return "fake" }
+func (s *fakeSource) SellerReceives(_ int, g int64) int64 { return g }
+
+func (s *fakeSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, slices.Clone(keys))
+	call := len(s.calls)
+	s.mu.Unlock()
+
+	return s.fetch(ctx, call, keys)
+}
+
+func (s *fakeSource) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.calls)
+}
+
+// quotesFor - котировка с ценой 100 на каждый ключ, кроме missing
+func quotesFor(keys []domain.ItemKey, missing ...domain.ItemKey) map[domain.ItemKey]domain.VenueQuote {
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(keys))
+	for _, key := range keys {
+		if !slices.Contains(missing, key) {
+			quotes[key] = domain.VenueQuote{Venue: "fake", AppID: key.AppID, MarketHashName: key.MarketHashName, LowestPrice: 100}
+		}
+	}
+	return quotes
+}
+
+var (
+	keyA = domain.ItemKey{AppID: 730, MarketHashName: "A"}
+	keyB = domain.ItemKey{AppID: 730, MarketHashName: "B"}
+)
+
+func TestCachedSourceCachesQuotesAndMisses(t *testing.T) {
+	source := &fakeSource{fetch: func(_ context.Context, _ int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+		return quotesFor(keys, keyB), nil
+	}}
+	cached := NewCachedSource(source, time.Hour)
+
+	for range 3 {
+		quotes, err := cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA, keyB})
+		if err != nil {
+			t.Fatalf("FetchQuotes: %v", err)
+		}
+		if _, ok := quotes[keyA]; !ok || len(quotes) != 1 {
+			t.Fatalf("quotes = %+v, want only A", quotes)
+		}
+	}
+
+	// Оба ключа - одним запросом, а отсутствие B тоже закэшировано
+	if source.callCount() != 1 || len(source.calls[0]) != 2 {
+		t.Errorf("source calls = %v, want a single call with both keys", source.calls)
+	}
+}
+
+func TestCachedSourceExpires(t *testing.T) {
+	source := &fakeSource{fetch: func(_ context.Context, _ int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+		return quotesFor(keys), nil
+	}}
+	cached := NewCachedSource(source, 20*time.Millisecond)
+
+	_, _ = cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA})
+	time.Sleep(30 * time.Millisecond)
+	_, _ = cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA})
+
+	if n := source.callCount(); n != 2 {
+		t.Errorf("source called %d times, want 2 after the TTL", n)
+	}
+}
+
+func TestCachedSourceDoesNotCacheErrors(t *testing.T) {
+	source := &fakeSource{fetch: func(_ context.Context, call int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+		if call == 1 {
+			return nil, errors.New("upstream is down")
+		}
+		return quotesFor(keys), nil
+	}}
+	cached := NewCachedSource(source, time.Hour)
+
+	if _, err := cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA}); err == nil {
+		t.Fatal("first FetchQuotes: want an error")
+	}
+	quotes, err := cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA})
+	if err != nil || len(quotes) != 1 {
+		t.Errorf("second FetchQuotes = %v, %v; want a quote", quotes, err)
+	}
+}
+
+func TestCachedSourceCoalescesConcurrentMisses(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	source := &fakeSource{fetch: func(_ context.Context, call int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+		if call == 1 {
+			close(started)
+			<-release
+		}
+		return quotesFor(keys), nil
+	}}
+	cached := NewCachedSource(source, time.Hour)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make(chan int, callers)
+	fetch := func() {
+		defer wg.Done()
+		quotes, err := cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA})
+		if err != nil {
+			t.Errorf("FetchQuotes: %v", err)
+		}
+		results <- len(quotes)
+	}
+
+	wg.Add(1)
+	go fetch()
+	<-started
+
+	wg.Add(callers - 1)
+	for range callers - 1 {
+		go fetch()
+	}
+	time.Sleep(20 * time.Millisecond) // Даём остальным встать в ожидание запроса первого
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for n := range results {
+		if n != 1 {
+			t.Errorf("caller got %d quotes, want 1", n)
+		}
+	}
+	if n := source.callCount(); n != 1 {
+		t.Errorf("source called %d times, want 1", n)
+	}
+}
+
+func TestCachedSourceRetriesAfterOwnerCancel(t *testing.T) {
+	started := make(chan struct{})
+	source := &fakeSource{fetch: func(ctx context.Context, call int, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+		if call == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return quotesFor(keys), nil
+	}}
+	cached := NewCachedSource(source, time.Hour)
+
+	ownerCtx, cancel := context.WithCancel(context.Background())
+	ownerErr := make(chan error, 1)
+	go func() {
+		_, err := cached.FetchQuotes(ownerCtx, []domain.ItemKey{keyA})
+		ownerErr <- err
+	}()
+	<-started
+
+	waiter := make(chan map[domain.ItemKey]domain.VenueQuote, 1)
+	go func() {
+		quotes, err := cached.FetchQuotes(context.Background(), []domain.ItemKey{keyA})
+		if err != nil {
+			t.Errorf("waiter FetchQuotes: %v", err)
+		}
+		waiter <- quotes
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-ownerErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("owner error = %v, want context.Canceled", err)
+	}
+	// Отмена чужого запроса не должна стать ошибкой ждущего
+	if quotes := <-waiter; len(quotes) != 1 {
+		t.Errorf("waiter quotes = %+v, want a quote", quotes)
+	}
+}
+
+func TestNewCachedSourceWithoutTTL(t *testing.T) {
+	source := &fakeSource{}
+	if got := NewCachedSource(source, 0); got != source {
+		t.Error("NewCachedSource with ttl 0 must return the source as is")
+	}
+}
@@ -0,0 +1,91 @@
+package pricesources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+)
+
+// csgoMarketSource - цены market.csgo.com (GET /api/v2/prices/{CUR}.json, без ключа)
+// Площадка торгует только CS2: ключи других игр пропускаются без запроса
+type csgoMarketSource struct {
+	cfg        config.MarketplaceConfig
+	currency   domain.Currency
+	httpClient *httpclient.Client
+}
+
+// NewCSGOMarketSource - источник цен market.csgo.com в валюте currency
+func NewCSGOMarketSource(cfg config.MarketplaceConfig, currency domain.Currency, httpClient *httpclient.Client) out_ports.PriceSource {
+	return &csgoMarketSource{
+		cfg:        cfg,
+		currency:   currency,
+		httpClient: httpClient,
+	}
+}
+
+// csgoMarketPrices - ответ /api/v2/prices/{CUR}.json
+// price и volume приходят строками: "12.345", "17"
+type csgoMarketPrices struct {
+	Success bool `json:"success"`
+	Items   []struct {
+		MarketHashName string `json:"market_hash_name"`
+		Price          string `json:"price"`
+		Volume         string `json:"volume"`
+	} `json:"items"`
+}
+
+func (s *csgoMarketSource) Name() string {
+	return "csgomarket"
+}
+
+func (s *csgoMarketSource) SellerReceives(_ int, gross int64) int64 {
+	return domain.SellerReceivesAtBps(gross, s.cfg.SellerFeeBps)
+}
+
+func (s *csgoMarketSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	wanted := keysByApp(keys)[domain.AppIDCS2]
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(wanted))
+	if len(wanted) == 0 {
+		return quotes, nil
+	}
+
+	var resp csgoMarketPrices
+	if err := getJSON(ctx, s.httpClient, s.cfg.BaseURL+"/api/v2/prices/"+s.currency.Code()+".json", &resp); err != nil {
+		return nil, fmt.Errorf("csgomarket prices: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("csgomarket prices: success=false")
+	}
+
+	observedAt := time.Now().UTC()
+	for _, item := range resp.Items {
+		if !wanted[item.MarketHashName] {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(item.Price, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		volume, _ := strconv.ParseInt(item.Volume, 10, 64)
+
+		key := domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: item.MarketHashName}
+		quotes[key] = domain.VenueQuote{
+			Venue:          s.Name(),
+			AppID:          key.AppID,
+			MarketHashName: key.MarketHashName,
+			Currency:       s.currency,
+			LowestPrice:    toMinorUnits(price),
+			Volume:         volume,
+			ObservedAt:     observedAt,
+		}
+	}
+
+	return quotes, nil
+}
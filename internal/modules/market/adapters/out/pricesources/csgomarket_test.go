@@ -0,0 +1,71 @@
+package pricesources
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/shared/config"
+)
+
+func TestCSGOMarketFetchQuotes(t *testing.T) {
+	url, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/prices/USD.json" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success":true,"items":[
+			{"market_hash_name":"AK-47 | Redline (Field-Tested)","price":"12.345","volume":"17"},
+			{"market_hash_name":"AWP | Asiimov (Field-Tested)","price":"0","volume":"3"},
+			{"market_hash_name":"M4A4 | Howl (Field-Tested)","price":"oops","volume":"1"}
+		]}`))
+	})
+
+	source := NewCSGOMarketSource(config.MarketplaceConfig{BaseURL: url}, domain.CurrencyUSD, client)
+	redline := domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: "AK-47 | Redline (Field-Tested)"}
+	keys := []domain.ItemKey{
+		redline,
+		{AppID: domain.AppIDCS2, MarketHashName: "AWP | Asiimov (Field-Tested)"},
+		{AppID: domain.AppIDCS2, MarketHashName: "M4A4 | Howl (Field-Tested)"},
+		{AppID: 570, MarketHashName: "AK-47 | Redline (Field-Tested)"},
+	}
+
+	quotes, err := source.FetchQuotes(context.Background(), keys)
+	if err != nil {
+		t.Fatalf("FetchQuotes: %v", err)
+	}
+	if len(quotes) != 1 {
+		t.Fatalf("got %d quotes, want only Redline: %+v", len(quotes), quotes)
+	}
+	if got := quotes[redline]; got.Venue != "csgomarket" || got.LowestPrice != 1235 || got.Volume != 17 {
+		t.Errorf("Redline quote = %+v", got)
+	}
+}
+
+func TestCSGOMarketSkipsOtherGames(t *testing.T) {
+	var requests atomic.Int32
+	url, client := newTestServer(t, func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	})
+
+	source := NewCSGOMarketSource(config.MarketplaceConfig{BaseURL: url}, domain.CurrencyUSD, client)
+	quotes, err := source.FetchQuotes(context.Background(), []domain.ItemKey{{AppID: 570, MarketHashName: "Dragonclaw Hook"}})
+	if err != nil || len(quotes) != 0 {
+		t.Errorf("FetchQuotes = %v, %v; want no quotes", quotes, err)
+	}
+	if requests.Load() != 0 {
+		t.Error("made a request for a non-CS2 item")
+	}
+}
+
+func TestCSGOMarketUnsuccessfulResponse(t *testing.T) {
+	url, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"success":false}`))
+	})
+
+	source := NewCSGOMarketSource(config.MarketplaceConfig{BaseURL: url}, domain.CurrencyUSD, client)
+	if _, err := source.FetchQuotes(context.Background(), []domain.ItemKey{{AppID: domain.AppIDCS2, MarketHashName: "x"}}); err == nil {
+		t.Error("FetchQuotes with success=false: want an error")
+	}
+}
@@ -0,0 +1,45 @@
+package pricesources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	"steam-observer/internal/shared/httpclient"
+)
+
+// maxResponseBytes - прайс-лист площадки целиком (десятки тысяч предметов) весит мегабайты
+const maxResponseBytes = 64 << 20
+
+// getJSON - GET запрос и декодирование JSON ответа в dst
+func getJSON(ctx context.Context, client *httpclient.Client, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(dst); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// toMinorUnits - 12.345 → 1235 (сотые доли валюты)
+func toMinorUnits(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
@@ -0,0 +1,60 @@
+package pricesources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// newTestServer - заглушка площадки и клиент без бюджета и повторов
+func newTestServer(t *testing.T, handler http.HandlerFunc) (string, *httpclient.Client) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server.URL, httpclient.New(5*time.Second, config.RateLimitConfig{}, logger.NewNopLogger())
+}
+
+func TestGetJSON(t *testing.T) {
+	url, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			http.Error(w, "upstream is down", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":42}`))
+	})
+
+	var dst struct{ Value int }
+	if err := getJSON(context.Background(), client, url+"/ok", &dst); err != nil || dst.Value != 42 {
+		t.Errorf("getJSON = %+v, %v; want value 42", dst, err)
+	}
+	if err := getJSON(context.Background(), client, url+"/broken", &dst); err == nil {
+		t.Error("getJSON with status 502: want an error")
+	}
+}
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want int64
+	}{
+		{12.345, 1235},
+		{0.01, 1},
+		{0.005, 1},
+		{1.1, 110},
+		{1999.99, 199999},
+	}
+
+	for _, tt := range tests {
+		if got := toMinorUnits(tt.in); got != tt.want {
+			t.Errorf("toMinorUnits(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
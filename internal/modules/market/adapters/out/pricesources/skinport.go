@@ -0,0 +1,103 @@
+package pricesources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+)
+
+// skinportSource - цены Skinport (GET /v1/items, без ключа)
+//
+// Площадка отдаёт прайс-лист игры целиком, поэтому на FetchQuotes уходит
+// по одному запросу на каждый app_id среди ключей.
+// Документация Skinport требует Accept-Encoding: br; стандартная библиотека
+// умеет только gzip, так что за строгим upstream понадобится прокси (SKINPORT_BASE_URL).
+type skinportSource struct {
+	cfg        config.MarketplaceConfig
+	currency   domain.Currency
+	httpClient *httpclient.Client
+}
+
+// NewSkinportSource - источник цен Skinport в валюте currency
+func NewSkinportSource(cfg config.MarketplaceConfig, currency domain.Currency, httpClient *httpclient.Client) out_ports.PriceSource {
+	return &skinportSource{
+		cfg:        cfg,
+		currency:   currency,
+		httpClient: httpClient,
+	}
+}
+
+// skinportItem - элемент ответа /v1/items; цены в единицах валюты, null = нет лотов
+type skinportItem struct {
+	MarketHashName string   `json:"market_hash_name"`
+	MinPrice       *float64 `json:"min_price"`
+	MedianPrice    *float64 `json:"median_price"`
+	Quantity       int64    `json:"quantity"`
+}
+
+func (s *skinportSource) Name() string {
+	return "skinport"
+}
+
+func (s *skinportSource) SellerReceives(_ int, gross int64) int64 {
+	return domain.SellerReceivesAtBps(gross, s.cfg.SellerFeeBps)
+}
+
+func (s *skinportSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(keys))
+
+	for appID, wanted := range keysByApp(keys) {
+		params := url.Values{}
+		params.Set("app_id", strconv.Itoa(appID))
+		params.Set("currency", s.currency.Code())
+		params.Set("tradable", "0")
+
+		var items []skinportItem
+		if err := getJSON(ctx, s.httpClient, s.cfg.BaseURL+"/v1/items?"+params.Encode(), &items); err != nil {
+			return nil, fmt.Errorf("skinport items app %d: %w", appID, err)
+		}
+
+		observedAt := time.Now().UTC()
+		for _, item := range items {
+			key := domain.ItemKey{AppID: appID, MarketHashName: item.MarketHashName}
+			if !wanted[key.MarketHashName] || item.MinPrice == nil {
+				continue
+			}
+
+			quote := domain.VenueQuote{
+				Venue:          s.Name(),
+				AppID:          appID,
+				MarketHashName: item.MarketHashName,
+				Currency:       s.currency,
+				LowestPrice:    toMinorUnits(*item.MinPrice),
+				Volume:         item.Quantity,
+				ObservedAt:     observedAt,
+			}
+			if item.MedianPrice != nil {
+				quote.MedianPrice = toMinorUnits(*item.MedianPrice)
+			}
+			quotes[key] = quote
+		}
+	}
+
+	return quotes, nil
+}
+
+// keysByApp - имена предметов, сгруппированные по app_id
+func keysByApp(keys []domain.ItemKey) map[int]map[string]bool {
+	byApp := make(map[int]map[string]bool)
+	for _, key := range keys {
+		if byApp[key.AppID] == nil {
+			byApp[key.AppID] = make(map[string]bool)
+		}
+		byApp[key.AppID][key.MarketHashName] = true
+	}
+	return byApp
+}
@@ -0,0 +1,84 @@
+package pricesources
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/shared/config"
+)
+
+func TestSkinportFetchQuotes(t *testing.T) {
+	var requests atomic.Int32
+	url, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/items" || q.Get("currency") != "EUR" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch q.Get("app_id") {
+		case "730":
+			_, _ = w.Write([]byte(`[
+				{"market_hash_name":"AK-47 | Redline (Field-Tested)","min_price":12.345,"median_price":13.5,"quantity":17},
+				{"market_hash_name":"AWP | Asiimov (Field-Tested)","min_price":null,"median_price":80,"quantity":0},
+				{"market_hash_name":"Not Tracked","min_price":1,"median_price":1,"quantity":1}
+			]`))
+		case "570":
+			_, _ = w.Write([]byte(`[{"market_hash_name":"Dragonclaw Hook","min_price":500,"median_price":null,"quantity":2}]`))
+		default:
+			t.Errorf("unexpected app_id %q", q.Get("app_id"))
+		}
+	})
+
+	source := NewSkinportSource(config.MarketplaceConfig{BaseURL: url, SellerFeeBps: 800}, domain.CurrencyEUR, client)
+	redline := domain.ItemKey{AppID: 730, MarketHashName: "AK-47 | Redline (Field-Tested)"}
+	asiimov := domain.ItemKey{AppID: 730, MarketHashName: "AWP | Asiimov (Field-Tested)"}
+	hook := domain.ItemKey{AppID: 570, MarketHashName: "Dragonclaw Hook"}
+
+	quotes, err := source.FetchQuotes(context.Background(), []domain.ItemKey{redline, asiimov, hook})
+	if err != nil {
+		t.Fatalf("FetchQuotes: %v", err)
+	}
+
+	// Один запрос на игру, а не на предмет
+	if n := requests.Load(); n != 2 {
+		t.Errorf("made %d requests, want 2", n)
+	}
+	if len(quotes) != 2 {
+		t.Fatalf("got %d quotes, want 2 (no lots for Asiimov, Not Tracked ignored): %+v", len(quotes), quotes)
+	}
+
+	got := quotes[redline]
+	if got.Venue != "skinport" || got.Currency != domain.CurrencyEUR || got.LowestPrice != 1235 ||
+		got.MedianPrice != 1350 || got.Volume != 17 || got.ObservedAt.IsZero() {
+		t.Errorf("Redline quote = %+v", got)
+	}
+	if got := quotes[hook]; got.LowestPrice != 50000 || got.MedianPrice != 0 {
+		t.Errorf("Dragonclaw Hook quote = %+v", got)
+	}
+}
+
+func TestSkinportFetchQuotesError(t *testing.T) {
+	url, client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	})
+
+	source := NewSkinportSource(config.MarketplaceConfig{BaseURL: url}, domain.CurrencyEUR, client)
+	if _, err := source.FetchQuotes(context.Background(), []domain.ItemKey{{AppID: 730, MarketHashName: "x"}}); err == nil {
+		t.Error("FetchQuotes with status 429: want an error")
+	}
+}
+
+func TestSkinportSellerReceives(t *testing.T) {
+	source := NewSkinportSource(config.MarketplaceConfig{SellerFeeBps: 800}, domain.CurrencyEUR, nil)
+
+	// 8% от 1001 = 80.08 → комиссия округляется вверх до 81
+	if got := source.SellerReceives(730, 1001); got != 920 {
+		t.Errorf("SellerReceives(1001) = %d, want 920", got)
+	}
+	if got := source.SellerReceives(730, 0); got != 0 {
+		t.Errorf("SellerReceives(0) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,51 @@
+package pricesources
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// steamSource - цены Steam Community Market через priceoverview
+//
+// Один запрос на предмет, поэтому источник подходит для poller'а,
+// но не для массовых сравнений - там Steam берётся из сохранённых снимков.
+type steamSource struct {
+	client   out_ports.SteamMarketClient
+	currency domain.Currency
+}
+
+// NewSteamSource - источник цен Steam в валюте currency
+func NewSteamSource(client out_ports.SteamMarketClient, currency domain.Currency) out_ports.PriceSource {
+	return &steamSource{client: client, currency: currency}
+}
+
+func (s *steamSource) Name() string {
+	return domain.VenueSteam
+}
+
+// SellerReceives - цена за вычетом комиссий Steam и издателя
+func (s *steamSource) SellerReceives(appID int, gross int64) int64 {
+	return domain.FeeScheduleFor(appID).SellerReceivesAt(gross)
+}
+
+// FetchQuotes - по запросу на ключ; предметы без данных пропускаются,
+// любая другая ошибка (429, сеть) прерывает обход
+func (s *steamSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(keys))
+	for _, key := range keys {
+		overview, err := s.client.GetPriceOverview(ctx, key.AppID, key.MarketHashName, s.currency)
+		if errors.Is(err, out_ports.ErrSteamNoData) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		quotes[key] = domain.NewVenueQuote(domain.NewPriceSnapshot(overview, time.Now().UTC()))
+	}
+	return quotes, nil
+}
@@ -0,0 +1,68 @@
+package pricesources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// fakeSteamClient - GetPriceOverview по заранее заданным ответам; остальные методы не используются
+type fakeSteamClient struct {
+	out_ports.SteamMarketClient
+	overviews map[string]*domain.PriceOverview
+	errs      map[string]error
+	calls     int
+}
+
+func (c *fakeSteamClient) GetPriceOverview(_ context.Context, appID int, name string, currency domain.Currency) (*domain.PriceOverview, error) {
+	c.calls++
+	if err := c.errs[name]; err != nil {
+		return nil, err
+	}
+	overview := *c.overviews[name]
+	overview.AppID, overview.MarketHashName, overview.Currency = appID, name, currency
+	return &overview, nil
+}
+
+func TestSteamFetchQuotes(t *testing.T) {
+	client := &fakeSteamClient{
+		overviews: map[string]*domain.PriceOverview{"Redline": {LowestPrice: 1000, MedianPrice: 950, Volume: 42}},
+		errs:      map[string]error{"Unknown": out_ports.ErrSteamNoData},
+	}
+	source := NewSteamSource(client, domain.CurrencyUSD)
+
+	redline := domain.ItemKey{AppID: 730, MarketHashName: "Redline"}
+	quotes, err := source.FetchQuotes(context.Background(), []domain.ItemKey{redline, {AppID: 730, MarketHashName: "Unknown"}})
+	if err != nil {
+		t.Fatalf("FetchQuotes: %v", err)
+	}
+	if len(quotes) != 1 {
+		t.Fatalf("got %d quotes, want 1 (no data skipped)", len(quotes))
+	}
+	if got := quotes[redline]; got.Venue != domain.VenueSteam || got.LowestPrice != 1000 || got.MedianPrice != 950 || got.Volume != 42 {
+		t.Errorf("Redline quote = %+v", got)
+	}
+}
+
+func TestSteamFetchQuotesStopsOnRateLimit(t *testing.T) {
+	client := &fakeSteamClient{errs: map[string]error{"First": out_ports.ErrSteamRateLimited}}
+	source := NewSteamSource(client, domain.CurrencyUSD)
+
+	_, err := source.FetchQuotes(context.Background(), []domain.ItemKey{{AppID: 730, MarketHashName: "First"}, {AppID: 730, MarketHashName: "Second"}})
+	if !errors.Is(err, out_ports.ErrSteamRateLimited) {
+		t.Errorf("error = %v, want ErrSteamRateLimited", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("made %d calls, want 1", client.calls)
+	}
+}
+
+func TestSteamSellerReceives(t *testing.T) {
+	source := NewSteamSource(&fakeSteamClient{}, domain.CurrencyUSD)
+	if got := source.SellerReceives(730, 115); got != 100 {
+		t.Errorf("SellerReceives(115) = %d, want 100", got)
+	}
+}
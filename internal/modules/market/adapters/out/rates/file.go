@@ -0,0 +1,73 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// fileSource - курсы из локального JSON файла
+//
+// Формат:
+//
+//	{
+//	  "base": "USD",
+//	  "updated_at": "2025-03-01T00:00:00Z",
+//	  "rates": {"EUR": 0.92, "RUB": 88.5}
+//	}
+//
+// Файл перечитывается на каждом FetchRates, поэтому его можно обновлять
+// без рестарта (cron, ручная правка). updated_at необязателен - тогда берётся mtime файла.
+type fileSource struct {
+	path string
+}
+
+// NewFileSource - источник курсов из файла path
+func NewFileSource(path string) out_ports.RateSource {
+	return &fileSource{path: path}
+}
+
+// rateFile - JSON формат файла; ключи rates - ISO коды (или ID Steam)
+type rateFile struct {
+	Base      domain.Currency             `json:"base"`
+	UpdatedAt *time.Time                  `json:"updated_at"`
+	Rates     map[domain.Currency]float64 `json:"rates"`
+}
+
+func (s *fileSource) Name() string {
+	return "file"
+}
+
+func (s *fileSource) FetchRates(context.Context) (*domain.ExchangeRates, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read rates file: %w", err)
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode rates file %s: %w", s.path, err)
+	}
+	if !file.Base.IsKnown() {
+		return nil, fmt.Errorf("rates file %s: unsupported base currency %d", s.path, int(file.Base))
+	}
+	if len(file.Rates) == 0 {
+		return nil, fmt.Errorf("rates file %s: no rates", s.path)
+	}
+
+	updatedAt := time.Now().UTC()
+	if file.UpdatedAt != nil {
+		updatedAt = file.UpdatedAt.UTC()
+	} else if info, err := os.Stat(s.path); err == nil {
+		updatedAt = info.ModTime().UTC()
+	}
+
+	rates := domain.NewExchangeRates(file.Base, file.Rates, updatedAt)
+	rates.Source = s.Name()
+	return rates, nil
+}
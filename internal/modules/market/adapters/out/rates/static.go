@@ -0,0 +1,49 @@
+package rates
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// staticRatesDate - на какую дату актуальна встроенная таблица
+var staticRatesDate = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// staticRates - приблизительные курсы к USD
+// Для офлайн запуска и разработки; в проде - RatesSource = file или внешний источник
+var staticRates = map[domain.Currency]float64{
+	domain.CurrencyUSD: 1,
+	domain.CurrencyGBP: 0.80,
+	domain.CurrencyEUR: 0.96,
+	domain.CurrencyCHF: 0.91,
+	domain.CurrencyRUB: 101.7,
+	domain.CurrencyPLN: 4.13,
+	domain.CurrencyBRL: 6.18,
+	domain.CurrencyJPY: 157.2,
+	domain.CurrencyTRY: 35.36,
+	domain.CurrencyUAH: 42.03,
+	domain.CurrencyCAD: 1.44,
+	domain.CurrencyAUD: 1.61,
+	domain.CurrencyCNY: 7.30,
+	domain.CurrencyKZT: 525.0,
+}
+
+// staticSource - встроенная таблица курсов
+type staticSource struct{}
+
+// NewStaticSource - источник с фиксированной таблицей курсов (всегда доступен)
+func NewStaticSource() out_ports.RateSource {
+	return staticSource{}
+}
+
+func (staticSource) Name() string {
+	return "static"
+}
+
+func (staticSource) FetchRates(context.Context) (*domain.ExchangeRates, error) {
+	rates := domain.NewExchangeRates(domain.CurrencyUSD, staticRates, staticRatesDate)
+	rates.Source = "static"
+	return rates, nil
+}
@@ -0,0 +1,212 @@
+package steam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+)
+
+// Пагинация инвентаря: Steam отдаёт не больше 2000 предметов за запрос
+const (
+	inventoryPageSize = 2000
+	inventoryMaxPages = 15 // 30k предметов - больше, чем бывает у живых аккаунтов
+)
+
+// economyImageURL - CDN картинок предметов; в описании приходит только хвост пути
+const economyImageURL = "https://community.fastly.steamstatic.com/economy/image/"
+
+type inventoryClient struct {
+	cfg        config.SteamConfig
+	httpClient *httpclient.Client
+}
+
+// NewInventoryClient - создаёт клиент публичного инвентаря Steam
+func NewInventoryClient(cfg config.SteamConfig, httpClient *httpclient.Client) out_ports.SteamInventoryClient {
+	return &inventoryClient{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
+
+// inventoryResponse - страница ответа /inventory/{steamid}/{appid}/{contextid}
+//
+// Экземпляры (assets) и их описания (descriptions) приходят раздельно и
+// связываются по паре (classid, instanceid). market_hash_name есть только в описании.
+// У пустого инвентаря assets и descriptions отсутствуют.
+type inventoryResponse struct {
+	Success      int                    `json:"success"`
+	Assets       []inventoryAsset       `json:"assets"`
+	Descriptions []inventoryDescription `json:"descriptions"`
+	MoreItems    int                    `json:"more_items"`
+	LastAssetID  string                 `json:"last_assetid"`
+}
+
+type inventoryAsset struct {
+	AppID      int    `json:"appid"`
+	ContextID  string `json:"contextid"`
+	AssetID    string `json:"assetid"`
+	ClassID    string `json:"classid"`
+	InstanceID string `json:"instanceid"`
+	Amount     string `json:"amount"`
+}
+
+type inventoryDescription struct {
+	ClassID        string `json:"classid"`
+	InstanceID     string `json:"instanceid"`
+	Name           string `json:"name"`
+	MarketHashName string `json:"market_hash_name"`
+	Marketable     int    `json:"marketable"`
+	Tradable       int    `json:"tradable"`
+	IconURL        string `json:"icon_url"`
+	Tags           []struct {
+		Category         string `json:"category"`
+		LocalizedTagName string `json:"localized_tag_name"`
+	} `json:"tags"`
+}
+
+// rarity - значение тега Rarity ("Covert"), "" если тега нет
+func (d *inventoryDescription) rarity() string {
+	for _, tag := range d.Tags {
+		if tag.Category == "Rarity" {
+			return tag.LocalizedTagName
+		}
+	}
+	return ""
+}
+
+// GetInventory - все предметы контекста инвентаря, страница за страницей
+func (c *inventoryClient) GetInventory(ctx context.Context, steamID string, appID int, contextID string) ([]domain.InventoryAsset, error) {
+	path := fmt.Sprintf("/inventory/%s/%d/%s", url.PathEscape(steamID), appID, url.PathEscape(contextID))
+
+	assets := []domain.InventoryAsset{}
+	startAssetID := ""
+
+	for page := 0; page < inventoryMaxPages; page++ {
+		params := url.Values{}
+		params.Set("l", "english") // market_hash_name не локализуется, но name - да
+		params.Set("count", strconv.Itoa(inventoryPageSize))
+		if startAssetID != "" {
+			params.Set("start_assetid", startAssetID)
+		}
+
+		resp, err := c.getPage(ctx, path, params)
+		if err != nil {
+			return nil, fmt.Errorf("inventory %s/%d/%s: %w", steamID, appID, contextID, err)
+		}
+
+		assets = append(assets, mapInventoryPage(resp)...)
+
+		if resp.MoreItems == 0 || resp.LastAssetID == "" {
+			return assets, nil
+		}
+		startAssetID = resp.LastAssetID
+	}
+
+	return nil, fmt.Errorf("inventory %s/%d/%s: more than %d items", steamID, appID, contextID, inventoryPageSize*inventoryMaxPages)
+}
+
+// getPage - одна страница инвентаря
+//
+//   - 403 / тело "null" → ErrInventoryPrivate (так Steam отвечает на скрытый инвентарь)
+//   - 429 → ErrSteamRateLimited
+//   - success != 1 → ErrSteamNoData
+func (c *inventoryClient) getPage(ctx context.Context, path string, params url.Values) (*inventoryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// Без steamLoginSecure: с ней Steam отдал бы скрытый инвентарь аккаунта оператора
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return nil, out_ports.ErrSteamRateLimited
+	case http.StatusForbidden, http.StatusUnauthorized:
+		return nil, out_ports.ErrInventoryPrivate
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, out_ports.ErrInventoryPrivate
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("steam inventory api error: %s (status: %d)", truncate(trimmed, 200), resp.StatusCode)
+	}
+
+	var page inventoryResponse
+	if err := json.Unmarshal(trimmed, &page); err != nil {
+		return nil, fmt.Errorf("decode inventory: %w", err)
+	}
+	if page.Success != 1 {
+		return nil, out_ports.ErrSteamNoData
+	}
+
+	return &page, nil
+}
+
+// mapInventoryPage - склеивает assets с descriptions
+// Экземпляры без описания пропускаются: без market_hash_name их не оценить
+func mapInventoryPage(page *inventoryResponse) []domain.InventoryAsset {
+	type classKey struct{ classID, instanceID string }
+
+	descriptions := make(map[classKey]*inventoryDescription, len(page.Descriptions))
+	for i := range page.Descriptions {
+		d := &page.Descriptions[i]
+		descriptions[classKey{d.ClassID, d.InstanceID}] = d
+	}
+
+	assets := make([]domain.InventoryAsset, 0, len(page.Assets))
+	for _, a := range page.Assets {
+		d, ok := descriptions[classKey{a.ClassID, a.InstanceID}]
+		if !ok {
+			continue
+		}
+
+		amount, err := strconv.ParseInt(a.Amount, 10, 64)
+		if err != nil || amount < 1 {
+			amount = 1
+		}
+
+		iconURL := ""
+		if d.IconURL != "" {
+			iconURL = economyImageURL + d.IconURL
+		}
+
+		assets = append(assets, domain.InventoryAsset{
+			AssetID:        a.AssetID,
+			AppID:          a.AppID,
+			ContextID:      a.ContextID,
+			MarketHashName: d.MarketHashName,
+			Name:           d.Name,
+			Amount:         amount,
+			Marketable:     d.Marketable == 1,
+			Tradable:       d.Tradable == 1,
+			IconURL:        iconURL,
+			Rarity:         d.rarity(),
+		})
+	}
+
+	return assets
+}
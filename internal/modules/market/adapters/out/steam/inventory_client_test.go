@@ -0,0 +1,67 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+func newTestInventoryClient(t *testing.T, handler http.HandlerFunc) out_ports.SteamInventoryClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := config.SteamConfig{BaseURL: server.URL, LoginSecure: "secure-cookie", Timeout: 5 * time.Second}
+	return NewInventoryClient(cfg, httpclient.New(cfg.Timeout, cfg.RateLimit, logger.NewNopLogger()))
+}
+
+func TestGetInventoryPublicOnly(t *testing.T) {
+	client := newTestInventoryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inventory/76561198000000000/730/2" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		// Инвентарь запрашивается анонимно, даже если cookie оператора настроена
+		if _, err := r.Cookie("steamLoginSecure"); err == nil {
+			t.Error("steamLoginSecure cookie sent with an inventory request")
+		}
+
+		if r.URL.Query().Get("start_assetid") == "" {
+			_, _ = w.Write([]byte(`{"success":1,"more_items":1,"last_assetid":"1",
+				"assets":[{"appid":730,"contextid":"2","assetid":"1","classid":"10","instanceid":"0","amount":"1"}],
+				"descriptions":[{"classid":"10","instanceid":"0","market_hash_name":"AK-47 | Redline (Field-Tested)","marketable":1}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":1,
+			"assets":[{"appid":730,"contextid":"2","assetid":"2","classid":"11","instanceid":"0","amount":"1"}],
+			"descriptions":[{"classid":"11","instanceid":"0","market_hash_name":"Recoil Case","marketable":1}]}`))
+	})
+
+	assets, err := client.GetInventory(context.Background(), "76561198000000000", 730, "2")
+	if err != nil {
+		t.Fatalf("GetInventory: %v", err)
+	}
+	if len(assets) != 2 || assets[0].MarketHashName != "AK-47 | Redline (Field-Tested)" || assets[1].MarketHashName != "Recoil Case" {
+		t.Errorf("assets = %+v, want both pages", assets)
+	}
+}
+
+func TestGetInventoryPrivate(t *testing.T) {
+	for _, handler := range []http.HandlerFunc{
+		respond(http.StatusForbidden, ""),
+		respond(http.StatusOK, "null"),
+	} {
+		_, err := newTestInventoryClient(t, handler).GetInventory(context.Background(), "76561198000000000", 730, "2")
+		if !errors.Is(err, out_ports.ErrInventoryPrivate) {
+			t.Errorf("error = %v, want ErrInventoryPrivate", err)
+		}
+	}
+}
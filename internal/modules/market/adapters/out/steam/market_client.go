@@ -0,0 +1,226 @@
+package steam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+)
+
+// maxResponseBytes - ограничение на размер ответа Steam
+// pricehistory популярного предмета весит сотни KB, 8 MiB - с большим запасом
+const maxResponseBytes = 8 << 20
+
+type marketClient struct {
+	cfg        config.SteamConfig
+	httpClient *httpclient.Client
+}
+
+// NewMarketClient - создаёт клиент Steam Community Market
+// cfg.BaseURL позволяет направить запросы на httptest сервер вместо steamcommunity.com
+func NewMarketClient(cfg config.SteamConfig, httpClient *httpclient.Client) out_ports.SteamMarketClient {
+	return &marketClient{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
+
+// priceOverviewResponse - ответ /market/priceoverview/
+// Цены приходят отформатированными строками в валюте запроса: "$1.23", "1,23€", "95 pуб."
+// Любое поле кроме success может отсутствовать
+type priceOverviewResponse struct {
+	Success     bool   `json:"success"`
+	LowestPrice string `json:"lowest_price"`
+	MedianPrice string `json:"median_price"`
+	Volume      string `json:"volume"` // "1,234"
+}
+
+// priceHistoryResponse - ответ /market/pricehistory/
+// prices - массив кортежей [дата, медианная цена, количество продаж]:
+//
+//	["Jul 02 2014 01: +0", 417.777, "40"]
+type priceHistoryResponse struct {
+	Success bool                `json:"success"`
+	Prices  [][]json.RawMessage `json:"prices"`
+}
+
+// GetPriceOverview - текущие lowest/median цены и объём продаж за 24ч
+func (c *marketClient) GetPriceOverview(ctx context.Context, appID int, marketHashName string, currency domain.Currency) (*domain.PriceOverview, error) {
+	params := url.Values{}
+	params.Set("appid", strconv.Itoa(appID))
+	params.Set("currency", strconv.Itoa(int(currency)))
+	params.Set("market_hash_name", marketHashName)
+
+	body, err := c.get(ctx, "/market/priceoverview/", params)
+	if err != nil {
+		return nil, fmt.Errorf("price overview %q: %w", marketHashName, err)
+	}
+
+	var resp priceOverviewResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode price overview: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("price overview %q: %w", marketHashName, out_ports.ErrSteamNoData)
+	}
+
+	overview := &domain.PriceOverview{
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		Currency:       currency,
+	}
+
+	// Пустые поля оставляем нулями: у предмета может не быть продаж за сутки
+	if resp.LowestPrice != "" {
+		if overview.LowestPrice, err = parsePrice(resp.LowestPrice); err != nil {
+			return nil, fmt.Errorf("parse lowest_price: %w", err)
+		}
+	}
+	if resp.MedianPrice != "" {
+		if overview.MedianPrice, err = parsePrice(resp.MedianPrice); err != nil {
+			return nil, fmt.Errorf("parse median_price: %w", err)
+		}
+	}
+	if resp.Volume != "" {
+		if overview.Volume, err = parseVolume(resp.Volume); err != nil {
+			return nil, fmt.Errorf("parse volume: %w", err)
+		}
+	}
+
+	return overview, nil
+}
+
+// GetPriceHistory - вся история продаж предмета
+func (c *marketClient) GetPriceHistory(ctx context.Context, appID int, marketHashName string, currency domain.Currency) ([]domain.PriceHistoryPoint, error) {
+	params := url.Values{}
+	params.Set("appid", strconv.Itoa(appID))
+	params.Set("currency", strconv.Itoa(int(currency)))
+	params.Set("market_hash_name", marketHashName)
+
+	body, err := c.get(ctx, "/market/pricehistory/", params)
+	if err != nil {
+		return nil, fmt.Errorf("price history %q: %w", marketHashName, err)
+	}
+
+	var resp priceHistoryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode price history: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("price history %q: %w", marketHashName, out_ports.ErrSteamNoData)
+	}
+
+	points := make([]domain.PriceHistoryPoint, 0, len(resp.Prices))
+	for i, raw := range resp.Prices {
+		point, err := parseHistoryPoint(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parse price history point %d: %w", i, err)
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// get - выполняет GET запрос и возвращает тело ответа
+//
+// Бюджет запросов и повторы - на стороне httpclient; сюда приходит ответ последней попытки.
+// Обработка ответов Steam:
+//   - 429 → ErrSteamRateLimited (повторы исчерпаны или Retry-After слишком длинный)
+//   - пустое тело, "null" или "[]" → ErrSteamNoData
+//     (так Steam отвечает на неизвестные предметы и pricehistory без логина)
+//   - {"success":false} с любым статусом → отдаём тело, success проверит вызывающий
+//   - прочие не-200 → ошибка со статусом
+func (c *marketClient) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.LoginSecure != "" {
+		req.AddCookie(&http.Cookie{Name: "steamLoginSecure", Value: c.cfg.LoginSecure})
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, out_ports.ErrSteamRateLimited
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) || bytes.Equal(trimmed, []byte("[]")) {
+		return nil, fmt.Errorf("%w (status: %d)", out_ports.ErrSteamNoData, resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK && trimmed[0] != '{' {
+		return nil, fmt.Errorf("steam market api error: %s (status: %d)", truncate(trimmed, 200), resp.StatusCode)
+	}
+
+	return trimmed, nil
+}
+
+// parseHistoryPoint - разбирает кортеж ["Jul 02 2014 01: +0", 417.777, "40"]
+func parseHistoryPoint(raw []json.RawMessage) (domain.PriceHistoryPoint, error) {
+	if len(raw) < 3 {
+		return domain.PriceHistoryPoint{}, fmt.Errorf("expected 3 fields, got %d", len(raw))
+	}
+
+	var (
+		dateStr   string
+		price     float64
+		volumeStr string
+	)
+	if err := json.Unmarshal(raw[0], &dateStr); err != nil {
+		return domain.PriceHistoryPoint{}, fmt.Errorf("date: %w", err)
+	}
+	if err := json.Unmarshal(raw[1], &price); err != nil {
+		return domain.PriceHistoryPoint{}, fmt.Errorf("price: %w", err)
+	}
+	if err := json.Unmarshal(raw[2], &volumeStr); err != nil {
+		return domain.PriceHistoryPoint{}, fmt.Errorf("volume: %w", err)
+	}
+
+	t, err := parseHistoryTime(dateStr)
+	if err != nil {
+		return domain.PriceHistoryPoint{}, err
+	}
+
+	volume, err := parseVolume(volumeStr)
+	if err != nil {
+		return domain.PriceHistoryPoint{}, err
+	}
+
+	return domain.PriceHistoryPoint{
+		Time:   t,
+		Price:  int64(math.Round(price * 100)), // 417.777 → 41778 (сотые доли)
+		Volume: volume,
+	}, nil
+}
+
+// truncate - обрезает тело ответа для сообщения об ошибке
+func truncate(b []byte, n int) string {
+	if len(b) <= n {
+		return string(b)
+	}
+	return string(b[:n]) + "..."
+}
@@ -0,0 +1,160 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// newTestClient - клиент, направленный на httptest сервер, без бюджета и повторов
+func newTestClient(t *testing.T, handler http.HandlerFunc) out_ports.SteamMarketClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := config.SteamConfig{BaseURL: server.URL, LoginSecure: "secure-cookie", Timeout: 5 * time.Second}
+	return NewMarketClient(cfg, httpclient.New(cfg.Timeout, cfg.RateLimit, logger.NewNopLogger()))
+}
+
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestGetPriceOverview(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/market/priceoverview/" || q.Get("appid") != "730" || q.Get("currency") != "5" ||
+			q.Get("market_hash_name") != "AK-47 | Redline (Field-Tested)" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if cookie, err := r.Cookie("steamLoginSecure"); err != nil || cookie.Value != "secure-cookie" {
+			t.Errorf("steamLoginSecure cookie not sent")
+		}
+		_, _ = w.Write([]byte(`{"success":true,"lowest_price":"1 234,56 pуб.","median_price":"1 200,5 pуб.","volume":"1,234"}`))
+	})
+
+	overview, err := client.GetPriceOverview(context.Background(), 730, "AK-47 | Redline (Field-Tested)", domain.Currency(5))
+	if err != nil {
+		t.Fatalf("GetPriceOverview: %v", err)
+	}
+	if overview.LowestPrice != 123456 || overview.MedianPrice != 120050 || overview.Volume != 1234 {
+		t.Errorf("got lowest=%d median=%d volume=%d, want 123456 120050 1234",
+			overview.LowestPrice, overview.MedianPrice, overview.Volume)
+	}
+}
+
+func TestGetPriceOverviewWithoutSales(t *testing.T) {
+	client := newTestClient(t, respond(http.StatusOK, `{"success":true,"lowest_price":"$0.05"}`))
+
+	overview, err := client.GetPriceOverview(context.Background(), 730, "Sticker | Rare", domain.Currency(1))
+	if err != nil {
+		t.Fatalf("GetPriceOverview: %v", err)
+	}
+	if overview.LowestPrice != 5 || overview.MedianPrice != 0 || overview.Volume != 0 {
+		t.Errorf("got %+v, want lowest=5 and zero median and volume", overview)
+	}
+}
+
+func TestGetPriceOverviewErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    error // nil - любая ошибка, кроме ErrSteamNoData и ErrSteamRateLimited
+	}{
+		{"success false", respond(http.StatusOK, `{"success":false}`), out_ports.ErrSteamNoData},
+		{"success false with 500", respond(http.StatusInternalServerError, `{"success":false}`), out_ports.ErrSteamNoData},
+		{"empty body", respond(http.StatusOK, ""), out_ports.ErrSteamNoData},
+		{"whitespace body", respond(http.StatusOK, " \n"), out_ports.ErrSteamNoData},
+		{"null body", respond(http.StatusOK, "null"), out_ports.ErrSteamNoData},
+		{"empty array body", respond(http.StatusBadRequest, "[]"), out_ports.ErrSteamNoData},
+		{"rate limited", respond(http.StatusTooManyRequests, ""), out_ports.ErrSteamRateLimited},
+		{"html error page", respond(http.StatusBadGateway, "<html>Bad Gateway</html>"), nil},
+		{"broken price", respond(http.StatusOK, `{"success":true,"lowest_price":"n/a"}`), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.handler)
+
+			_, err := client.GetPriceOverview(context.Background(), 730, "AK-47 | Redline (Field-Tested)", domain.Currency(1))
+			switch {
+			case err == nil:
+				t.Fatal("expected an error")
+			case tt.want != nil && !errors.Is(err, tt.want):
+				t.Errorf("error = %v, want %v", err, tt.want)
+			case tt.want == nil && (errors.Is(err, out_ports.ErrSteamNoData) || errors.Is(err, out_ports.ErrSteamRateLimited)):
+				t.Errorf("error = %v, want a generic error", err)
+			}
+		})
+	}
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/market/pricehistory/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success":true,"prices":[
+			["Jul 02 2014 01: +0", 417.777, "40"],
+			["Jul 03 2014 01: +0", 0.03, "1,203"]
+		]}`))
+	})
+
+	points, err := client.GetPriceHistory(context.Background(), 730, "AK-47 | Redline (Field-Tested)", domain.Currency(1))
+	if err != nil {
+		t.Fatalf("GetPriceHistory: %v", err)
+	}
+
+	want := []domain.PriceHistoryPoint{
+		{Time: time.Date(2014, 7, 2, 1, 0, 0, 0, time.UTC), Price: 41778, Volume: 40},
+		{Time: time.Date(2014, 7, 3, 1, 0, 0, 0, time.UTC), Price: 3, Volume: 1203},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i := range want {
+		if !points[i].Time.Equal(want[i].Time) || points[i].Price != want[i].Price || points[i].Volume != want[i].Volume {
+			t.Errorf("point %d = %+v, want %+v", i, points[i], want[i])
+		}
+	}
+}
+
+func TestGetPriceHistoryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"without login", "[]", out_ports.ErrSteamNoData},
+		{"success false", `{"success":false}`, out_ports.ErrSteamNoData},
+		{"short tuple", `{"success":true,"prices":[["Jul 02 2014 01: +0", 1.5]]}`, nil},
+		{"bad date", `{"success":true,"prices":[["2014-07-02", 1.5, "3"]]}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, respond(http.StatusOK, tt.body))
+
+			_, err := client.GetPriceHistory(context.Background(), 730, "AK-47 | Redline (Field-Tested)", domain.Currency(1))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package steam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// itemNameIDPattern - вызов на странице лота, из которого берём item_nameid:
+//
+//	Market_LoadOrderSpread( 176185874 );
+var itemNameIDPattern = regexp.MustCompile(`Market_LoadOrderSpread\(\s*(\d+)\s*\)`)
+
+// orderHistogramResponse - ответ /market/itemordershistogram
+//
+// Графики - накопленные объёмы: [цена в единицах валюты, сколько по этой цене и лучше, подпись].
+// success приходит числом (1), счётчики - строкой "1,234" или числом, поэтому RawMessage
+type orderHistogramResponse struct {
+	Success        json.RawMessage     `json:"success"`
+	BuyOrderCount  json.RawMessage     `json:"buy_order_count"`
+	SellOrderCount json.RawMessage     `json:"sell_order_count"`
+	BuyOrderGraph  [][]json.RawMessage `json:"buy_order_graph"`
+	SellOrderGraph [][]json.RawMessage `json:"sell_order_graph"`
+}
+
+// GetItemNameID - item_nameid со страницы лота
+func (c *marketClient) GetItemNameID(ctx context.Context, appID int, marketHashName string) (int64, error) {
+	path := "/market/listings/" + strconv.Itoa(appID) + "/" + url.PathEscape(marketHashName)
+
+	body, err := c.get(ctx, path, url.Values{})
+	if err != nil {
+		return 0, fmt.Errorf("listing page %q: %w", marketHashName, err)
+	}
+
+	match := itemNameIDPattern.FindSubmatch(body)
+	if match == nil {
+		return 0, fmt.Errorf("item_nameid for %q: %w", marketHashName, out_ports.ErrSteamNoData)
+	}
+
+	id, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse item_nameid: %w", err)
+	}
+	return id, nil
+}
+
+// GetOrderBook - верхушка стакана по item_nameid
+func (c *marketClient) GetOrderBook(ctx context.Context, itemNameID int64, currency domain.Currency) (*domain.OrderBook, error) {
+	params := url.Values{}
+	params.Set("country", "US")
+	params.Set("language", "english")
+	params.Set("currency", strconv.Itoa(int(currency)))
+	params.Set("item_nameid", strconv.FormatInt(itemNameID, 10))
+	params.Set("two_factor", "0")
+	params.Set("norender", "1")
+
+	body, err := c.get(ctx, "/market/itemordershistogram", params)
+	if err != nil {
+		return nil, fmt.Errorf("order histogram %d: %w", itemNameID, err)
+	}
+
+	var resp orderHistogramResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode order histogram: %w", err)
+	}
+	if s := string(resp.Success); s != "1" && s != "true" {
+		return nil, fmt.Errorf("order histogram %d: %w", itemNameID, out_ports.ErrSteamNoData)
+	}
+
+	book := &domain.OrderBook{Currency: currency}
+	if book.Bids, err = parseOrderGraph(resp.BuyOrderGraph); err != nil {
+		return nil, fmt.Errorf("parse buy_order_graph: %w", err)
+	}
+	if book.Asks, err = parseOrderGraph(resp.SellOrderGraph); err != nil {
+		return nil, fmt.Errorf("parse sell_order_graph: %w", err)
+	}
+	if book.BidOrders, err = parseOrderCount(resp.BuyOrderCount); err != nil {
+		return nil, fmt.Errorf("parse buy_order_count: %w", err)
+	}
+	if book.AskOrders, err = parseOrderCount(resp.SellOrderCount); err != nil {
+		return nil, fmt.Errorf("parse sell_order_count: %w", err)
+	}
+
+	return book, nil
+}
+
+// parseOrderGraph - накопленный график Steam → уровни с количеством на каждом
+func parseOrderGraph(graph [][]json.RawMessage) ([]domain.OrderBookLevel, error) {
+	levels := make([]domain.OrderBookLevel, 0, len(graph))
+	var prevCumulative int64
+
+	for i, point := range graph {
+		if len(point) < 2 {
+			return nil, fmt.Errorf("point %d: expected at least 2 fields, got %d", i, len(point))
+		}
+
+		var price, cumulative float64
+		if err := json.Unmarshal(point[0], &price); err != nil {
+			return nil, fmt.Errorf("point %d price: %w", i, err)
+		}
+		if err := json.Unmarshal(point[1], &cumulative); err != nil {
+			return nil, fmt.Errorf("point %d quantity: %w", i, err)
+		}
+
+		quantity := int64(cumulative) - prevCumulative
+		prevCumulative = int64(cumulative)
+		if quantity <= 0 {
+			continue
+		}
+
+		levels = append(levels, domain.OrderBookLevel{
+			Price:    int64(math.Round(price * 100)),
+			Quantity: quantity,
+		})
+	}
+
+	return levels, nil
+}
+
+// parseOrderCount - "1,234" / 1234 / null → 1234 (отсутствие = 0)
+func parseOrderCount(raw json.RawMessage) (int64, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return 0, nil
+	}
+
+	var s string
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return 0, err
+		}
+	} else {
+		s = string(raw)
+	}
+	if s == "" {
+		return 0, nil
+	}
+	return parseVolume(s)
+}
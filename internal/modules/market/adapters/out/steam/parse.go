@@ -0,0 +1,99 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// steamHistoryLayout - формат даты в pricehistory без хвоста " +0"
+// Steam отдаёт "Jul 02 2014 01: +0": час без минут и смещение UTC в нестандартном виде
+const steamHistoryLayout = "Jan 02 2006 15:"
+
+// parsePrice - разбирает отформатированную цену Steam в сотые доли валюты
+//
+// Примеры:
+//
+//	"$1,234.56"     → 123456
+//	"1 234,56 pуб." → 123456
+//	"12,--€"        → 1200
+//	"¥ 1,234"       → 123400
+//	"CHF 1'234.50"  → 123450
+//
+// Алгоритм: берём подстроку от первой до последней цифры (отбрасываем символы валюты,
+// в том числе точку в "pуб."), убираем разделители тысяч. Последний '.' или ',' считаем
+// десятичным разделителем, если после него 1-2 цифры, иначе - разделителем тысяч.
+func parsePrice(s string) (int64, error) {
+	first := strings.IndexFunc(s, unicode.IsDigit)
+	last := strings.LastIndexFunc(s, unicode.IsDigit)
+	if first < 0 {
+		return 0, fmt.Errorf("no digits in price %q", s)
+	}
+	number := s[first : last+1]
+
+	// Пробелы (в т.ч. неразрывные) и апострофы - всегда разделители тысяч
+	number = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\'' {
+			return -1
+		}
+		return r
+	}, number)
+
+	intPart, fracPart := number, ""
+	if sep := strings.LastIndexAny(number, ".,"); sep >= 0 {
+		if tail := number[sep+1:]; len(tail) == 1 || len(tail) == 2 {
+			intPart, fracPart = number[:sep], tail
+		}
+	}
+	intPart = strings.NewReplacer(".", "", ",", "").Replace(intPart)
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	// Дополняем дробную часть до двух знаков: "5" → "50"
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse price %q: %w", s, err)
+	}
+	cents, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse price %q: %w", s, err)
+	}
+
+	return units*100 + cents, nil
+}
+
+// parseVolume - "1,234" → 1234
+func parseVolume(s string) (int64, error) {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+	if digits == "" {
+		return 0, errors.New("no digits in volume " + strconv.Quote(s))
+	}
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+// parseHistoryTime - "Jul 02 2014 01: +0" → time.Time (UTC)
+func parseHistoryTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if idx := strings.LastIndex(s, " "); idx > 0 && strings.HasPrefix(s[idx+1:], "+") {
+		s = s[:idx]
+	}
+
+	t, err := time.ParseInLocation(steamHistoryLayout, s, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse history time %q: %w", s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,85 @@
+package steam
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"$1.23", 123},
+		{"$1,234.56", 123456},
+		{"$0.03", 3},
+		{"1,23€", 123},
+		{"1.234,56€", 123456},
+		{"12,--€", 1200},
+		{"12,5€", 1250},
+		{"95 pуб.", 9500},
+		{"1 234,56 pуб.", 123456},
+		{"1\u00a0234,56 pуб.", 123456}, // Неразрывный пробел
+		{"¥ 1,234", 123400},
+		{"CHF 1'234.50", 123450},
+		{"R$ 10,99", 1099},
+		{"1.234₴", 123400},
+		{"₹ 1,23,456.78", 12345678},
+		{"Rp 1 234 567", 123456700},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePrice(tt.in)
+		if err != nil {
+			t.Errorf("parsePrice(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePrice(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParsePriceInvalid(t *testing.T) {
+	for _, in := range []string{"", "$", "n/a", "99999999999999999999 pуб."} {
+		if got, err := parsePrice(in); err == nil {
+			t.Errorf("parsePrice(%q) = %d, want an error", in, got)
+		}
+	}
+}
+
+func TestParseVolume(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"40", 40},
+		{"1,234", 1234},
+		{"1 234 567", 1234567},
+	}
+
+	for _, tt := range tests {
+		got, err := parseVolume(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseVolume(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	if _, err := parseVolume(""); err == nil {
+		t.Error("parseVolume(\"\"): want an error")
+	}
+}
+
+func TestParseHistoryTime(t *testing.T) {
+	got, err := parseHistoryTime("Jul 02 2014 01: +0")
+	if err != nil {
+		t.Fatalf("parseHistoryTime: %v", err)
+	}
+	if want := time.Date(2014, 7, 2, 1, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("parseHistoryTime = %s, want %s", got, want)
+	}
+
+	if _, err := parseHistoryTime("2014-07-02T01:00:00Z"); err == nil {
+		t.Error("parseHistoryTime of an RFC 3339 date: want an error")
+	}
+}
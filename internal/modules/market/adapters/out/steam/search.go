@@ -0,0 +1,72 @@
+package steam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+// maxSearchCount - Steam отдаёт не больше 100 результатов за запрос
+const maxSearchCount = 100
+
+// searchResponse - ответ /market/search/render/?norender=1
+type searchResponse struct {
+	Success bool `json:"success"`
+	Results []struct {
+		Name             string `json:"name"`
+		HashName         string `json:"hash_name"`
+		AssetDescription struct {
+			AppID   int    `json:"appid"`
+			IconURL string `json:"icon_url"`
+			Type    string `json:"type"` // "Classified Rifle", "★ Covert Knife"
+		} `json:"asset_description"`
+	} `json:"results"`
+}
+
+// SearchItems - поиск предметов по тексту, популярные первыми
+func (c *marketClient) SearchItems(ctx context.Context, appID int, query string, count int) ([]domain.CatalogItem, error) {
+	count = min(max(count, 1), maxSearchCount)
+
+	params := url.Values{}
+	params.Set("appid", strconv.Itoa(appID))
+	params.Set("query", query)
+	params.Set("start", "0")
+	params.Set("count", strconv.Itoa(count))
+	params.Set("search_descriptions", "0")
+	params.Set("sort_column", "popular")
+	params.Set("sort_dir", "desc")
+	params.Set("norender", "1")
+
+	body, err := c.get(ctx, "/market/search/render/", params)
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", query, err)
+	}
+
+	var resp searchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode search: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("search %q: %w", query, out_ports.ErrSteamNoData)
+	}
+
+	items := make([]domain.CatalogItem, 0, len(resp.Results))
+	for _, res := range resp.Results {
+		if res.HashName == "" {
+			continue
+		}
+		item := domain.NewCatalogItem(appID, res.HashName, res.Name)
+		if res.AssetDescription.IconURL != "" {
+			item.IconURL = economyImageURL + res.AssetDescription.IconURL
+		}
+		item.Rarity = domain.RarityFromSteamType(res.AssetDescription.Type)
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
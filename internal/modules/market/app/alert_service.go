@@ -0,0 +1,328 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// Лимиты истории срабатываний в одном ответе
+const (
+	defaultFiredAlertsLimit = 50
+	maxFiredAlertsLimit     = 500
+)
+
+// FiredAlertObserver - подписчик на срабатывания алертов
+// Вызывается синхронно после сохранения срабатывания, поэтому не должен надолго блокироваться
+type FiredAlertObserver interface {
+	OnFiredAlert(ctx context.Context, fired *domain.FiredAlert)
+}
+
+// AlertService - CRUD правил + оценка правил на каждом новом снимке цены
+// Реализует SnapshotObserver: подписывается на PricePoller в DI
+type AlertService interface {
+	in_ports.AlertService
+	SnapshotObserver
+
+	// AddObserver - подписывает observer на срабатывания
+	// Вызывать до запуска PricePoller: список подписчиков не защищён мьютексом
+	AddObserver(observer FiredAlertObserver)
+}
+
+type alertServiceImpl struct {
+	alertRepo out_ports.AlertRepository
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	currency  CurrencyConverter
+	notifier  out_ports.AlertNotifier
+	logger    logger.Logger
+	observers []FiredAlertObserver
+}
+
+func NewAlertService(
+	alertRepo out_ports.AlertRepository,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	currency CurrencyConverter,
+	notifier out_ports.AlertNotifier,
+	log logger.Logger,
+) AlertService {
+	return &alertServiceImpl{
+		alertRepo: alertRepo,
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		currency:  currency,
+		notifier:  notifier,
+		logger:    log,
+	}
+}
+
+func (s *alertServiceImpl) AddObserver(observer FiredAlertObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+func (s *alertServiceImpl) ListRules(ctx context.Context, userID string) ([]domain.AlertRule, error) {
+	rules, err := s.alertRepo.ListRulesByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *alertServiceImpl) CreateRule(ctx context.Context, userID string, input in_ports.CreateAlertRuleInput) (*domain.AlertRule, error) {
+	// Правило можно повесить только на свой предмет
+	if _, err := s.itemRepo.FindByID(ctx, userID, input.TrackedItemID); err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	cooldown := int64(-1) // → domain.DefaultAlertCooldown
+	if input.CooldownSeconds != nil {
+		cooldown = *input.CooldownSeconds
+	}
+
+	// Порог по умолчанию - в валюте, в которой пользователь видит цены
+	var currency domain.Currency
+	if input.Currency != nil {
+		currency = *input.Currency
+	} else {
+		var err error
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	rule := domain.NewAlertRule(userID, input.TrackedItemID, input.Type, input.Threshold, currency, input.WindowSeconds, input.Mode, cooldown)
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertRepo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("create alert rule: %w", err)
+	}
+
+	s.logger.Infof("alert rule created, id=%s, user_id=%s, type=%s", rule.ID, userID, rule.Type)
+
+	return rule, nil
+}
+
+func (s *alertServiceImpl) UpdateRule(ctx context.Context, userID, ruleID string, input in_ports.UpdateAlertRuleInput) (*domain.AlertRule, error) {
+	rule, err := s.alertRepo.FindRuleByID(ctx, userID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("find alert rule: %w", err)
+	}
+
+	if input.Threshold != nil {
+		rule.Threshold = *input.Threshold
+	}
+	if input.Currency != nil {
+		rule.Currency = *input.Currency
+	}
+	if input.WindowSeconds != nil {
+		rule.WindowSeconds = *input.WindowSeconds
+	}
+	if input.Mode != nil {
+		rule.Mode = *input.Mode
+	}
+	if input.CooldownSeconds != nil {
+		rule.CooldownSeconds = *input.CooldownSeconds
+	}
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("update alert rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (s *alertServiceImpl) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	if err := s.alertRepo.DeleteRule(ctx, userID, ruleID); err != nil {
+		return fmt.Errorf("delete alert rule: %w", err)
+	}
+	return nil
+}
+
+func (s *alertServiceImpl) ListFired(ctx context.Context, userID string, limit int) ([]domain.FiredAlert, error) {
+	if limit <= 0 {
+		limit = defaultFiredAlertsLimit
+	}
+	limit = min(limit, maxFiredAlertsLimit)
+
+	alerts, err := s.alertRepo.ListFiredByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list fired alerts: %w", err)
+	}
+
+	// Срабатывания хранятся в валюте правила - отдаём в валюте отображения
+	display, err := s.currency.DisplayCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates *domain.ExchangeRates
+	for i := range alerts {
+		if alerts[i].Currency == display {
+			continue
+		}
+		if rates == nil {
+			if rates, err = s.currency.Rates(); err != nil {
+				return nil, err
+			}
+		}
+		if alerts[i], err = rates.ConvertFiredAlert(alerts[i], display); err != nil {
+			return nil, fmt.Errorf("convert fired alert to %s: %w", display.Code(), err)
+		}
+	}
+
+	return alerts, nil
+}
+
+// OnPriceSnapshot - оценивает все включённые правила предмета на новом снимке
+// Ошибка по одному правилу не мешает остальным
+func (s *alertServiceImpl) OnPriceSnapshot(ctx context.Context, snapshot *domain.PriceSnapshot) {
+	rules, err := s.alertRepo.ListEnabledRulesByItem(ctx, snapshot.Key())
+	if err != nil {
+		s.logger.Errorf("list alert rules for %q: %v", snapshot.MarketHashName, err)
+		return
+	}
+
+	// История за окно нужна нескольким правилам - грузим один раз на максимальное окно
+	var history []domain.PriceSnapshot
+	var maxWindow int64
+	for i := range rules {
+		if rules[i].NeedsHistory() {
+			maxWindow = max(maxWindow, rules[i].WindowSeconds)
+		}
+	}
+	if maxWindow > 0 {
+		from := snapshot.ObservedAt.Add(-time.Duration(maxWindow) * time.Second)
+		history, err = s.snapshots.ListRange(ctx, snapshot.Key(), from, snapshot.ObservedAt)
+		if err != nil {
+			s.logger.Errorf("load price history for %q: %v", snapshot.MarketHashName, err)
+			return
+		}
+	}
+
+	// Правила оцениваются в своей валюте: снимок и историю переводим
+	// по одному разу на каждую встретившуюся валюту
+	converted := map[domain.Currency]*pricesInCurrency{}
+
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.CanFire(snapshot.ObservedAt) {
+			continue
+		}
+
+		prices, ok := converted[rule.Currency]
+		if !ok {
+			prices, err = s.convertPrices(snapshot, history, rule.Currency)
+			if err != nil {
+				s.logger.Errorf("convert prices of %q to %s: %v", snapshot.MarketHashName, rule.Currency.Code(), err)
+				continue
+			}
+			converted[rule.Currency] = prices
+		}
+
+		window := historyWindow(prices.history, prices.current, rule)
+		fired := domain.EvaluateAlert(rule, prices.current, window)
+		if fired == nil {
+			continue
+		}
+
+		s.fire(ctx, rule, fired)
+	}
+}
+
+// fire - сохраняет срабатывание и обновляет состояние правила
+func (s *alertServiceImpl) fire(ctx context.Context, rule *domain.AlertRule, fired *domain.FiredAlert) {
+	rule.MarkFired(fired.FiredAt)
+
+	// Сначала правило: если не удалось записать last_fired_at, лучше пропустить
+	// срабатывание, чем слать его на каждом снимке в обход cooldown
+	if err := s.alertRepo.MarkFired(ctx, rule.ID, fired.FiredAt, !rule.Enabled); err != nil {
+		if errors.Is(err, out_ports.ErrNotFound) {
+			// Правило удалили, выключили или уже отметили срабатывание за время раунда
+			return
+		}
+		s.logger.Errorf("mark alert rule %s fired: %v", rule.ID, err)
+		return
+	}
+
+	if err := s.alertRepo.SaveFired(ctx, fired); err != nil {
+		s.logger.Errorf("save fired alert for rule %s: %v", rule.ID, err)
+		return
+	}
+
+	s.logger.Infof("alert fired, rule_id=%s, user_id=%s: %s", rule.ID, rule.UserID, fired.Message)
+
+	for _, observer := range s.observers {
+		observer.OnFiredAlert(ctx, fired)
+	}
+
+	// Срабатывание уже в истории: сбой постановки в доставку не откатывает его
+	if err := s.notifier.NotifyAlert(ctx, fired); err != nil {
+		s.logger.Errorf("notify user about alert %s: %v", fired.ID, err)
+	}
+}
+
+// pricesInCurrency - снимок и история, переведённые в валюту правила
+type pricesInCurrency struct {
+	current *domain.PriceSnapshot
+	history []domain.PriceSnapshot
+}
+
+// convertPrices - копии снимка и истории в валюте to (исходные не меняются - они общие для всех правил)
+func (s *alertServiceImpl) convertPrices(snapshot *domain.PriceSnapshot, history []domain.PriceSnapshot, to domain.Currency) (*pricesInCurrency, error) {
+	if snapshot.Currency == to {
+		sameCurrency := true
+		for i := range history {
+			if history[i].Currency != to {
+				sameCurrency = false
+				break
+			}
+		}
+		if sameCurrency {
+			return &pricesInCurrency{current: snapshot, history: history}, nil
+		}
+	}
+
+	current := []domain.PriceSnapshot{*snapshot}
+	if err := convertSnapshots(s.currency, current, to); err != nil {
+		return nil, err
+	}
+
+	convertedHistory := append([]domain.PriceSnapshot(nil), history...)
+	if err := convertSnapshots(s.currency, convertedHistory, to); err != nil {
+		return nil, err
+	}
+
+	return &pricesInCurrency{current: &current[0], history: convertedHistory}, nil
+}
+
+// historyWindow - часть общей истории, попадающая в окно конкретного правила
+// Снимки отсортированы по времени, поэтому достаточно найти первый подходящий
+func historyWindow(history []domain.PriceSnapshot, current *domain.PriceSnapshot, rule *domain.AlertRule) []domain.PriceSnapshot {
+	if !rule.NeedsHistory() {
+		return nil
+	}
+
+	from := current.ObservedAt.Add(-rule.Window())
+	for i := range history {
+		if !history[i].ObservedAt.Before(from) {
+			return history[i:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// Лимиты списка аномалий в одном ответе
+const (
+	defaultAnomaliesLimit = 50
+	maxAnomaliesLimit     = 500
+)
+
+// AnomalyService - поиск аномалий в истории цен и выдача их пользователю
+// Реализует SnapshotObserver: подписывается на PricePoller в DI
+type AnomalyService interface {
+	in_ports.AnomalyService
+	SnapshotObserver
+}
+
+type anomalyServiceImpl struct {
+	cfg         config.AnomalyConfig
+	params      domain.AnomalyParams
+	anomalyRepo out_ports.AnomalyRepository
+	itemRepo    out_ports.TrackedItemRepository
+	snapshots   out_ports.PriceSnapshotRepository
+	currency    CurrencyConverter
+	logger      logger.Logger
+}
+
+// NewAnomalyService - детекторы без фиксированных порогов цены: каждый предмет
+// сравнивается со своей же историей за cfg.Window (z-score, MAD, всплеск объёма)
+func NewAnomalyService(
+	cfg config.AnomalyConfig,
+	anomalyRepo out_ports.AnomalyRepository,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	currency CurrencyConverter,
+	log logger.Logger,
+) AnomalyService {
+	return &anomalyServiceImpl{
+		cfg: cfg,
+		params: domain.AnomalyParams{
+			Window:       cfg.Window,
+			MinPoints:    max(cfg.MinPoints, 2),
+			ZThreshold:   cfg.ZThreshold,
+			MADThreshold: cfg.MADThreshold,
+			VolumeFactor: cfg.VolumeFactor,
+			MinVolume:    int64(cfg.MinVolume),
+		},
+		anomalyRepo: anomalyRepo,
+		itemRepo:    itemRepo,
+		snapshots:   snapshots,
+		currency:    currency,
+		logger:      log,
+	}
+}
+
+func (s *anomalyServiceImpl) ListAnomalies(ctx context.Context, userID string, query in_ports.AnomalyQuery) ([]domain.Anomaly, error) {
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+
+	return s.list(ctx, userID, items, query)
+}
+
+func (s *anomalyServiceImpl) ListItemAnomalies(ctx context.Context, userID, itemID string, query in_ports.AnomalyQuery) ([]domain.Anomaly, error) {
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	return s.list(ctx, userID, []domain.TrackedItem{*item}, query)
+}
+
+// list - аномалии предметов пользователя в его валюте, с tracked_item_id
+func (s *anomalyServiceImpl) list(ctx context.Context, userID string, items []domain.TrackedItem, query in_ports.AnomalyQuery) ([]domain.Anomaly, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrValidation)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultAnomaliesLimit
+	}
+	limit = min(limit, maxAnomaliesLimit)
+
+	filter := out_ports.AnomalyFilter{From: query.From, To: query.To, Limit: limit}
+	if query.Severity != "" {
+		filter.Severities = query.Severity.AtLeast()
+	}
+
+	itemIDs := make(map[domain.ItemKey]string, len(items))
+	keys := make([]domain.ItemKey, len(items))
+	for i := range items {
+		keys[i] = items[i].Key()
+		itemIDs[keys[i]] = items[i].ID
+	}
+
+	anomalies, err := s.anomalyRepo.ListByKeys(ctx, keys, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list price anomalies: %w", err)
+	}
+
+	currency := query.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := convertAnomalies(s.currency, anomalies, currency); err != nil {
+		return nil, fmt.Errorf("convert anomalies to %s: %w", currency.Code(), err)
+	}
+
+	for i := range anomalies {
+		anomalies[i].TrackedItemID = itemIDs[anomalies[i].Key()]
+	}
+
+	return anomalies, nil
+}
+
+func (s *anomalyServiceImpl) ScanItem(ctx context.Context, userID, itemID string) (*domain.AnomalyScan, error) {
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-s.cfg.ScanRange)
+
+	history, err := s.snapshots.ListRange(ctx, item.Key(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list price snapshots: %w", err)
+	}
+	// Статистику можно считать только по ценам в одной валюте
+	if err := convertSnapshots(s.currency, history, s.currency.Canonical()); err != nil {
+		return nil, fmt.Errorf("convert prices to %s: %w", s.currency.Canonical().Code(), err)
+	}
+
+	anomalies := domain.ScanAnomalies(history, s.params)
+	inserted, err := s.anomalyRepo.Save(ctx, anomalies)
+	if err != nil {
+		return nil, fmt.Errorf("save price anomalies: %w", err)
+	}
+
+	s.logger.Infof("anomaly scan finished, item=%q, snapshots=%d, found=%d, new=%d",
+		item.MarketHashName, len(history), len(anomalies), inserted)
+
+	return &domain.AnomalyScan{
+		TrackedItemID: item.ID,
+		From:          from,
+		To:            to,
+		Snapshots:     len(history),
+		Found:         len(anomalies),
+		New:           inserted,
+	}, nil
+}
+
+// OnPriceSnapshot - проверяет новый снимок на фоне истории предмета за окно
+// Снимок уже в канонической валюте (так его сохраняет poller)
+func (s *anomalyServiceImpl) OnPriceSnapshot(ctx context.Context, snapshot *domain.PriceSnapshot) {
+	from := snapshot.ObservedAt.Add(-s.params.Window)
+	window, err := s.snapshots.ListRange(ctx, snapshot.Key(), from, snapshot.ObservedAt)
+	if err != nil {
+		s.logger.Errorf("load price history for %q: %v", snapshot.MarketHashName, err)
+		return
+	}
+	if err := convertSnapshots(s.currency, window, snapshot.Currency); err != nil {
+		s.logger.Errorf("convert price history of %q to %s: %v", snapshot.MarketHashName, snapshot.Currency.Code(), err)
+		return
+	}
+
+	anomalies := domain.DetectAnomalies(snapshot, window, s.params)
+	if len(anomalies) == 0 {
+		return
+	}
+
+	if _, err := s.anomalyRepo.Save(ctx, anomalies); err != nil {
+		s.logger.Errorf("save price anomalies for %q: %v", snapshot.MarketHashName, err)
+		return
+	}
+
+	for i := range anomalies {
+		s.logger.Infof("price anomaly, item=%q, detector=%s, severity=%s, direction=%s, score=%.2f",
+			snapshot.MarketHashName, anomalies[i].Detector, anomalies[i].Severity, anomalies[i].Direction, anomalies[i].Score)
+	}
+}
@@ -0,0 +1,324 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// Лимиты выдачи арбитража
+const (
+	defaultArbitrageLimit = 50
+	maxArbitrageLimit     = 500
+)
+
+type ArbitrageService interface {
+	in_ports.ArbitrageService
+}
+
+type arbitrageServiceImpl struct {
+	itemRepo out_ports.TrackedItemRepository
+	sources  []out_ports.PriceSource
+	currency CurrencyConverter
+	logger   logger.Logger
+}
+
+// NewArbitrageService - сравнение цен Steam со сторонними площадками
+//
+// Steam берётся из последних снимков poller'а, а не живым запросом:
+// priceoverview - один запрос на предмет, и на списке предметов упрёмся в 429.
+// sources - остальные площадки, их прайс-листы запрашиваются при каждом сравнении.
+func NewArbitrageService(
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	sources []out_ports.PriceSource,
+	currency CurrencyConverter,
+	log logger.Logger,
+) ArbitrageService {
+	all := append([]out_ports.PriceSource{&snapshotPriceSource{snapshots: snapshots}}, sources...)
+
+	return &arbitrageServiceImpl{
+		itemRepo: itemRepo,
+		sources:  all,
+		currency: currency,
+		logger:   log,
+	}
+}
+
+func (s *arbitrageServiceImpl) ListVenues() []string {
+	names := make([]string, len(s.sources))
+	for i, source := range s.sources {
+		names[i] = source.Name()
+	}
+	return names
+}
+
+func (s *arbitrageServiceImpl) ComparePrices(ctx context.Context, userID, itemID string, currency domain.Currency) (*domain.PriceComparison, error) {
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	quotes, statuses, err := s.collectQuotes(ctx, []domain.ItemKey{item.Key()}, s.sources, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	itemQuotes := quotes[item.Key()]
+	sort.Slice(itemQuotes, func(i, j int) bool { return itemQuotes[i].LowestPrice < itemQuotes[j].LowestPrice })
+
+	opportunities := s.evaluatePairs(itemQuotes)
+	for i := range opportunities {
+		opportunities[i].TrackedItemID = item.ID
+	}
+	sortOpportunities(opportunities)
+
+	return &domain.PriceComparison{
+		TrackedItemID:  item.ID,
+		AppID:          item.AppID,
+		MarketHashName: item.MarketHashName,
+		Currency:       currency,
+		Quotes:         append([]domain.VenueQuote{}, itemQuotes...),
+		Opportunities:  opportunities,
+		Venues:         statuses,
+	}, nil
+}
+
+func (s *arbitrageServiceImpl) FindArbitrage(ctx context.Context, userID string, query in_ports.ArbitrageQuery) (*domain.ArbitrageReport, error) {
+	minPercent := domain.DefaultArbitrageMinProfitPercent
+	if query.MinProfitPercent != nil {
+		minPercent = *query.MinProfitPercent
+	}
+	if err := domain.ValidateArbitrageThreshold(minPercent); err != nil {
+		return nil, err
+	}
+	if query.MinProfit < 0 {
+		return nil, fmt.Errorf("%w: min_profit must not be negative", domain.ErrValidation)
+	}
+
+	sources, err := s.selectSources(query.Venues)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultArbitrageLimit
+	}
+	query.Limit = min(query.Limit, maxArbitrageLimit)
+
+	currency := query.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+
+	keys := make([]domain.ItemKey, 0, len(items))
+	trackedIDs := make(map[domain.ItemKey]string, len(items))
+	for i := range items {
+		keys = append(keys, items[i].Key())
+		trackedIDs[items[i].Key()] = items[i].ID
+	}
+
+	report := &domain.ArbitrageReport{
+		Currency:         currency,
+		MinProfitPercent: minPercent,
+		MinProfit:        query.MinProfit,
+		Opportunities:    []domain.ArbitrageOpportunity{},
+		CalculatedAt:     time.Now().UTC(),
+	}
+	if len(keys) == 0 {
+		return report, nil
+	}
+
+	quotes, statuses, err := s.collectQuotes(ctx, keys, sources, currency)
+	if err != nil {
+		return nil, err
+	}
+	report.Venues = statuses
+
+	for key, itemQuotes := range quotes {
+		for _, opp := range s.evaluatePairs(itemQuotes) {
+			if opp.ProfitPercent < minPercent || opp.Profit < query.MinProfit {
+				continue
+			}
+			opp.TrackedItemID = trackedIDs[key]
+			report.Opportunities = append(report.Opportunities, opp)
+		}
+	}
+
+	sortOpportunities(report.Opportunities)
+	if len(report.Opportunities) > query.Limit {
+		report.Opportunities = report.Opportunities[:query.Limit]
+	}
+
+	return report, nil
+}
+
+// selectSources - площадки по именам; пустой список - все
+func (s *arbitrageServiceImpl) selectSources(names []string) ([]out_ports.PriceSource, error) {
+	if len(names) == 0 {
+		return s.sources, nil
+	}
+
+	selected := make([]out_ports.PriceSource, 0, len(names))
+	for _, source := range s.sources {
+		if slices.Contains(names, source.Name()) {
+			selected = append(selected, source)
+		}
+	}
+	if len(selected) != len(names) {
+		return nil, fmt.Errorf("%w: unknown venue in %v, available: %v", domain.ErrValidation, names, s.ListVenues())
+	}
+	if len(selected) < 2 {
+		return nil, fmt.Errorf("%w: at least two venues are required", domain.ErrValidation)
+	}
+	return selected, nil
+}
+
+// collectQuotes - котировки всех площадок параллельно, в валюте currency
+// Недоступная площадка не валит сравнение: ошибка попадает в её VenueStatus
+func (s *arbitrageServiceImpl) collectQuotes(
+	ctx context.Context,
+	keys []domain.ItemKey,
+	sources []out_ports.PriceSource,
+	currency domain.Currency,
+) (map[domain.ItemKey][]domain.VenueQuote, []domain.VenueStatus, error) {
+	results := make([]map[domain.ItemKey]domain.VenueQuote, len(sources))
+	statuses := make([]domain.VenueStatus, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i].Venue = source.Name()
+
+			quotes, err := source.FetchQuotes(ctx, keys)
+			if err != nil {
+				s.logger.Warnf("fetch %s quotes: %v", source.Name(), err)
+				statuses[i].Error = "venue is unavailable"
+				return
+			}
+			results[i] = quotes
+			statuses[i].Quotes = len(quotes)
+		}()
+	}
+	wg.Wait()
+
+	var rates *domain.ExchangeRates
+	byKey := make(map[domain.ItemKey][]domain.VenueQuote, len(keys))
+	for _, quotes := range results {
+		for key, quote := range quotes {
+			if quote.Currency != currency {
+				if rates == nil {
+					var err error
+					if rates, err = s.currency.Rates(); err != nil {
+						return nil, nil, err
+					}
+				}
+				converted, err := rates.ConvertQuote(quote, currency)
+				if err != nil {
+					return nil, nil, fmt.Errorf("convert %s quote to %s: %w", quote.Venue, currency.Code(), err)
+				}
+				quote = converted
+			}
+			byKey[key] = append(byKey[key], quote)
+		}
+	}
+
+	return byKey, statuses, nil
+}
+
+// evaluatePairs - все пары "купить здесь - продать там" по котировкам одного предмета
+// Комиссия площадки продажи применяется к цене уже в валюте сравнения -
+// для процентных комиссий это точно, для минимальной комиссии Steam - с точностью до копеек
+func (s *arbitrageServiceImpl) evaluatePairs(quotes []domain.VenueQuote) []domain.ArbitrageOpportunity {
+	opportunities := []domain.ArbitrageOpportunity{}
+	for _, buy := range quotes {
+		for _, sell := range quotes {
+			if buy.Venue == sell.Venue {
+				continue
+			}
+			source := s.sourceByName(sell.Venue)
+			if source == nil {
+				continue
+			}
+
+			opp, ok := domain.EvaluateArbitrage(buy, sell, func(gross int64) int64 {
+				return source.SellerReceives(sell.AppID, gross)
+			})
+			if ok {
+				opportunities = append(opportunities, opp)
+			}
+		}
+	}
+	return opportunities
+}
+
+func (s *arbitrageServiceImpl) sourceByName(name string) out_ports.PriceSource {
+	for _, source := range s.sources {
+		if source.Name() == name {
+			return source
+		}
+	}
+	return nil
+}
+
+// sortOpportunities - выгодные первыми; при равной выгоде - по имени для стабильного ответа
+func sortOpportunities(opportunities []domain.ArbitrageOpportunity) {
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		if opportunities[i].ProfitPercent != opportunities[j].ProfitPercent {
+			return opportunities[i].ProfitPercent > opportunities[j].ProfitPercent
+		}
+		return opportunities[i].MarketHashName < opportunities[j].MarketHashName
+	})
+}
+
+// snapshotPriceSource - Steam по последним сохранённым снимкам цен
+type snapshotPriceSource struct {
+	snapshots out_ports.PriceSnapshotRepository
+}
+
+func (s *snapshotPriceSource) Name() string {
+	return domain.VenueSteam
+}
+
+func (s *snapshotPriceSource) SellerReceives(appID int, gross int64) int64 {
+	return domain.FeeScheduleFor(appID).SellerReceivesAt(gross)
+}
+
+func (s *snapshotPriceSource) FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error) {
+	latest, err := s.snapshots.LatestByKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("latest price snapshots: %w", err)
+	}
+
+	quotes := make(map[domain.ItemKey]domain.VenueQuote, len(latest))
+	for key, snapshot := range latest {
+		if snapshot.LowestPrice <= 0 {
+			continue
+		}
+		quotes[key] = domain.NewVenueQuote(&snapshot)
+	}
+	return quotes, nil
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// BackfillRunner - загрузка истории по одной задаче
+type BackfillRunner interface {
+	// Run - запрашивает историю у Steam и сохраняет точки новее курсора порциями
+	// При ошибке задача остаётся с курсором последней сохранённой порции
+	Run(ctx context.Context, job *domain.BackfillJob) error
+}
+
+type BackfillService interface {
+	in_ports.BackfillService
+	BackfillRunner
+}
+
+type backfillServiceImpl struct {
+	cfg      config.BackfillConfig
+	itemRepo out_ports.TrackedItemRepository
+	jobRepo  out_ports.BackfillJobRepository
+	steam    out_ports.SteamMarketClient
+	currency CurrencyConverter
+	logger   logger.Logger
+}
+
+func NewBackfillService(
+	cfg config.BackfillConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	jobRepo out_ports.BackfillJobRepository,
+	steam out_ports.SteamMarketClient,
+	currency CurrencyConverter,
+	log logger.Logger,
+) BackfillService {
+	return &backfillServiceImpl{
+		cfg:      cfg,
+		itemRepo: itemRepo,
+		jobRepo:  jobRepo,
+		steam:    steam,
+		currency: currency,
+		logger:   log,
+	}
+}
+
+func (s *backfillServiceImpl) GetItemBackfill(ctx context.Context, userID, itemID string) (*domain.BackfillJob, error) {
+	// Проверяем что предмет принадлежит пользователю (чужой → ErrNotFound)
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	job, err := s.jobRepo.FindLatest(ctx, item.Key())
+	if err != nil {
+		return nil, fmt.Errorf("find backfill job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (s *backfillServiceImpl) ListJobs(ctx context.Context, filter domain.BackfillJobFilter) ([]domain.BackfillJob, error) {
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultBackfillJobsLimit
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxBackfillJobsLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrValidation, domain.MaxBackfillJobsLimit)
+	}
+
+	jobs, err := s.jobRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list backfill jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *backfillServiceImpl) TriggerBackfill(ctx context.Context, adminID string, key domain.ItemKey) (*domain.BackfillJob, error) {
+	key.MarketHashName = strings.TrimSpace(key.MarketHashName)
+	if key.AppID <= 0 || key.MarketHashName == "" {
+		return nil, fmt.Errorf("%w: app_id and market_hash_name are required", domain.ErrValidation)
+	}
+
+	job := domain.NewBackfillJob(key, domain.BackfillByAdmin, adminID)
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("create backfill job: %w", err)
+	}
+
+	s.logger.Infof("backfill job queued, id=%d, item=%s, admin_id=%s", job.ID, key.MarketHashName, adminID)
+
+	return job, nil
+}
+
+// Run - pricehistory отдаёт всю историю одним ответом, поэтому при продолжении
+// она запрашивается заново, а сохраняются только точки новее курсора
+func (s *backfillServiceImpl) Run(ctx context.Context, job *domain.BackfillJob) error {
+	currency := s.currency.Canonical()
+
+	history, err := s.steam.GetPriceHistory(ctx, job.AppID, job.MarketHashName, currency)
+	if err != nil {
+		return err
+	}
+	job.TotalPoints = len(history)
+
+	for chunk := range slices.Chunk(job.Remaining(history), max(s.cfg.ChunkSize, 1)) {
+		// Курсор двигаем только после успешной записи порции
+		next := *job
+		next.Advance(chunk, time.Now().UTC())
+		if err := s.jobRepo.SaveChunk(ctx, &next, currency, chunk); err != nil {
+			return err
+		}
+		*job = next
+	}
+
+	job.Complete(time.Now().UTC())
+	return s.jobRepo.Update(ctx, job)
+}
+
+// listPriceSeries - снимки предмета за [from, to), перед первым из которых добавлена
+// загруженная история Steam: без неё график начинается с момента отслеживания
+func listPriceSeries(
+	ctx context.Context,
+	snapshots out_ports.PriceSnapshotRepository,
+	history out_ports.PriceHistoryRepository,
+	key domain.ItemKey,
+	from, to time.Time,
+) ([]domain.PriceSnapshot, error) {
+	series, err := snapshots.ListRange(ctx, key, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list price snapshots: %w", err)
+	}
+
+	before := to
+	if len(series) > 0 {
+		before = series[0].ObservedAt
+	}
+	if !before.After(from) {
+		return series, nil
+	}
+
+	// Сутки запаса: объём точки истории - продажи за 24ч до неё
+	points, err := history.ListRange(ctx, key, from.Add(-24*time.Hour), before)
+	if err != nil {
+		return nil, fmt.Errorf("list price history: %w", err)
+	}
+
+	return append(domain.HistorySnapshots(points, from, before), series...), nil
+}
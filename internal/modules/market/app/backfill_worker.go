@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// BackfillWorker - фоновый воркер очереди загрузки истории цен
+//
+// Каждый раунд ставит в очередь предметы, по которым ещё не было задач (только что
+// отслеженные), и выполняет все задачи с наступившим временем попытки. Задачи,
+// прерванные остановкой или падением процесса, при старте возвращаются в очередь
+// и продолжаются с сохранённого курсора. 429 откладывает задачу и прерывает раунд.
+type BackfillWorker struct {
+	cfg      config.BackfillConfig
+	itemRepo out_ports.TrackedItemRepository
+	jobRepo  out_ports.BackfillJobRepository
+	runner   BackfillRunner
+	logger   logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBackfillWorker - создаёт воркер загрузки истории (запуск - через Start)
+func NewBackfillWorker(
+	cfg config.BackfillConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	jobRepo out_ports.BackfillJobRepository,
+	runner BackfillRunner,
+	log logger.Logger,
+) *BackfillWorker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+
+	return &BackfillWorker{
+		cfg:      cfg,
+		itemRepo: itemRepo,
+		jobRepo:  jobRepo,
+		runner:   runner,
+		logger:   log,
+	}
+}
+
+// Start - запускает фоновую горутину; первый раунд сразу, следующие - через cfg.Interval
+func (w *BackfillWorker) Start() {
+	// Фоновые запросы уступают бюджет Steam запросам пользователей
+	ctx, cancel := context.WithCancel(httpclient.WithPriority(context.Background(), httpclient.PriorityBackground))
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(ctx)
+
+	w.logger.Infof("backfill worker started, interval=%s, chunk_size=%d", w.cfg.Interval, w.cfg.ChunkSize)
+}
+
+// Stop - останавливает воркер; текущая задача возвращается в очередь с сохранённым курсором
+// Безопасно вызывать, даже если Start не вызывался
+func (w *BackfillWorker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+
+	w.cancel()
+	<-w.done
+
+	w.logger.Info("backfill worker stopped")
+}
+
+// run - возврат прерванных задач, затем раунд → пауза → раунд ...
+func (w *BackfillWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	// Другого воркера нет, поэтому все running задачи - прерванные прошлым процессом
+	if n, err := w.jobRepo.ResetRunning(ctx); err != nil {
+		w.logger.Errorf("reset interrupted backfill jobs: %v", err)
+	} else if n > 0 {
+		w.logger.Infof("resuming %d interrupted backfill jobs", n)
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		w.pollOnce(ctx)
+		timer.Reset(w.cfg.Interval)
+	}
+}
+
+// pollOnce - постановка новых предметов в очередь и выполнение готовых задач
+func (w *BackfillWorker) pollOnce(ctx context.Context) {
+	started := time.Now()
+
+	keys, err := w.itemRepo.ListDistinctKeys(ctx)
+	if err != nil {
+		w.logger.Errorf("list tracked item keys: %v", err)
+		return
+	}
+	queued, err := w.jobRepo.EnqueueMissing(ctx, keys)
+	if err != nil {
+		w.logger.Errorf("enqueue backfill jobs: %v", err)
+		return
+	}
+
+	var done, failed int
+	for {
+		job, err := w.jobRepo.ClaimNext(ctx, time.Now().UTC())
+		if errors.Is(err, out_ports.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Errorf("claim backfill job: %v", err)
+			}
+			return
+		}
+
+		if w.cfg.Jitter > 0 {
+			select {
+			case <-time.After(rand.N(w.cfg.Jitter)):
+			case <-ctx.Done():
+				w.release(job)
+				return
+			}
+		}
+
+		err = w.runner.Run(ctx, job)
+		now := time.Now().UTC()
+		switch {
+		case err == nil:
+			done++
+			w.logger.Infof("backfill job %d done, item=%s, points=%d", job.ID, job.MarketHashName, job.Points)
+			continue
+
+		case ctx.Err() != nil:
+			// Остановка, а не сбой - попытку не засчитываем
+			w.release(job)
+			return
+
+		case errors.Is(err, out_ports.ErrSteamRateLimited):
+			job.Defer(now.Add(w.cfg.RetryBaseDelay), now)
+			w.save(job)
+			w.logger.Warn("steam rate limit hit, skipping rest of the backfill round")
+			return
+		}
+
+		failed++
+		job.RecordFailure(err, w.cfg.MaxAttempts,
+			domain.BackfillBackoff(job.Attempts+1, w.cfg.RetryBaseDelay, w.cfg.RetryMaxDelay), now)
+		w.save(job)
+		w.logger.Warnf("backfill job %d for %q attempt %d failed: %v", job.ID, job.MarketHashName, job.Attempts, err)
+	}
+
+	if queued > 0 || done > 0 || failed > 0 {
+		w.logger.Infof("backfill round finished, queued=%d, done=%d, failed=%d, duration=%s",
+			queued, done, failed, time.Since(started).Round(time.Millisecond))
+	}
+}
+
+// release - возвращает задачу в очередь без попытки
+func (w *BackfillWorker) release(job *domain.BackfillJob) {
+	now := time.Now().UTC()
+	job.Defer(now, now)
+	w.save(job)
+}
+
+// save - сохраняет статус задачи
+// Используем независимый контекст: статус нужно записать даже во время остановки
+func (w *BackfillWorker) save(job *domain.BackfillJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := w.jobRepo.Update(ctx, job); err != nil {
+		w.logger.Errorf("save backfill job %d status: %v", job.ID, err)
+	}
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// Лимиты выдачи каталога
+const (
+	defaultCatalogLimit = 100
+	maxCatalogLimit     = 1000
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+)
+
+// CatalogRegistry - то, что нужно другим сервисам модуля от каталога
+type CatalogRegistry interface {
+	// Canonicalize - нормализованное имя; если в каталоге уже есть
+	// то же имя в другом регистре, возвращается каталожное
+	Canonicalize(ctx context.Context, appID int, marketHashName string) (string, error)
+
+	// Register - добавляет или обновляет карточку предмета
+	Register(ctx context.Context, item *domain.CatalogItem) error
+}
+
+type CatalogService interface {
+	in_ports.CatalogService
+	CatalogRegistry
+
+	// Backfill - заводит карточки для отслеживаемых предметов, добавленных до появления каталога
+	Backfill(ctx context.Context) error
+}
+
+type catalogServiceImpl struct {
+	catalogRepo out_ports.CatalogRepository
+	itemRepo    out_ports.TrackedItemRepository
+	steamMarket out_ports.SteamMarketClient
+	logger      logger.Logger
+}
+
+func NewCatalogService(
+	catalogRepo out_ports.CatalogRepository,
+	itemRepo out_ports.TrackedItemRepository,
+	steamMarket out_ports.SteamMarketClient,
+	log logger.Logger,
+) CatalogService {
+	return &catalogServiceImpl{
+		catalogRepo: catalogRepo,
+		itemRepo:    itemRepo,
+		steamMarket: steamMarket,
+		logger:      log,
+	}
+}
+
+func (s *catalogServiceImpl) ListCatalog(ctx context.Context, filter domain.CatalogFilter) ([]domain.CatalogItem, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultCatalogLimit
+	}
+	filter.Limit = min(filter.Limit, maxCatalogLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	items, err := s.catalogRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list catalog: %w", err)
+	}
+	return items, nil
+}
+
+func (s *catalogServiceImpl) Search(ctx context.Context, userID string, query domain.CatalogSearchQuery) ([]domain.CatalogSearchResult, error) {
+	query.Query = domain.NormalizeMarketHashName(query.Query)
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.Filter.Limit <= 0 {
+		query.Filter.Limit = defaultSearchLimit
+	}
+	query.Filter.Limit = min(query.Filter.Limit, maxSearchLimit)
+	query.Filter.Offset = max(query.Filter.Offset, 0)
+
+	results, err := s.catalogRepo.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("search catalog: %w", err)
+	}
+
+	// Каталог знает только то, что уже кто-то отслеживал или импортировал.
+	// Поиск Steam дополняет его, после чего повторяем поиск, чтобы ранжирование было единым
+	if query.Remote && query.Filter.Offset == 0 && len(results) < query.Filter.Limit {
+		if added := s.importFromSteam(ctx, query); added > 0 {
+			if results, err = s.catalogRepo.Search(ctx, query); err != nil {
+				return nil, fmt.Errorf("search catalog: %w", err)
+			}
+		}
+	}
+
+	tracked, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+	trackedIDs := make(map[domain.ItemKey]string, len(tracked))
+	for i := range tracked {
+		trackedIDs[tracked[i].Key()] = tracked[i].ID
+	}
+	for i := range results {
+		results[i].TrackedItemID = trackedIDs[results[i].Key()]
+	}
+
+	return results, nil
+}
+
+// importFromSteam - регистрирует в каталоге найденное поиском Steam, возвращает число карточек
+// Ошибки Steam не фатальны: пользователь получит то, что нашлось локально
+func (s *catalogServiceImpl) importFromSteam(ctx context.Context, query domain.CatalogSearchQuery) int {
+	appID := query.Filter.AppID
+	if appID == 0 {
+		appID = domain.AppIDCS2
+	}
+
+	items, err := s.steamMarket.SearchItems(ctx, appID, query.Query, query.Filter.Limit)
+	if err != nil {
+		s.logger.Warnf("steam search %q: %v", query.Query, err)
+		return 0
+	}
+
+	added := 0
+	for i := range items {
+		if err := s.Register(ctx, &items[i]); err != nil {
+			s.logger.Warnf("register %q from steam search: %v", items[i].MarketHashName, err)
+			continue
+		}
+		added++
+	}
+	return added
+}
+
+func (s *catalogServiceImpl) ParseName(name string) domain.ParsedName {
+	return domain.ParseMarketHashName(name)
+}
+
+func (s *catalogServiceImpl) Canonicalize(ctx context.Context, appID int, marketHashName string) (string, error) {
+	// Парсер знает только имена CS2 - остальные игры лишь чистим от лишних пробелов
+	normalized := domain.NormalizeMarketHashName(marketHashName)
+	if appID != domain.AppIDCS2 {
+		return normalized, nil
+	}
+
+	existing, err := s.catalogRepo.FindByNameFold(ctx, appID, normalized)
+	if errors.Is(err, out_ports.ErrNotFound) {
+		return normalized, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("find catalog item: %w", err)
+	}
+	return existing.MarketHashName, nil
+}
+
+func (s *catalogServiceImpl) Register(ctx context.Context, item *domain.CatalogItem) error {
+	if err := s.catalogRepo.Upsert(ctx, item); err != nil {
+		return fmt.Errorf("register catalog item: %w", err)
+	}
+	return nil
+}
+
+func (s *catalogServiceImpl) Backfill(ctx context.Context) error {
+	keys, err := s.catalogRepo.ListMissingTracked(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.Register(ctx, domain.NewCatalogItem(key.AppID, key.MarketHashName, "")); err != nil {
+			return err
+		}
+	}
+
+	if len(keys) > 0 {
+		s.logger.Infof("catalog backfilled with %d tracked items", len(keys))
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+type ContainerService interface {
+	in_ports.ContainerService
+}
+
+type containerServiceImpl struct {
+	gameData out_ports.GameData
+	sources  []out_ports.PriceSource
+	currency CurrencyConverter
+	logger   logger.Logger
+}
+
+// NewContainerService - EV кейсов по справочнику gameData
+//
+// Как и в арбитраже, Steam - последние снимки poller'а: выпадений у кейса сотни,
+// по priceoverview на каждое не хватит никакого лимита. Поэтому цены Steam есть
+// только у предметов, которые кто-то отслеживает; остальные площадки отдают прайс-лист целиком.
+func NewContainerService(
+	gameData out_ports.GameData,
+	snapshots out_ports.PriceSnapshotRepository,
+	sources []out_ports.PriceSource,
+	currency CurrencyConverter,
+	log logger.Logger,
+) ContainerService {
+	all := append([]out_ports.PriceSource{&snapshotPriceSource{snapshots: snapshots}}, sources...)
+
+	return &containerServiceImpl{
+		gameData: gameData,
+		sources:  all,
+		currency: currency,
+		logger:   log,
+	}
+}
+
+func (s *containerServiceImpl) ListContainers(context.Context) []domain.ContainerSummary {
+	containers := s.gameData.Containers()
+
+	summaries := make([]domain.ContainerSummary, 0, len(containers))
+	for i := range containers {
+		c := &containers[i]
+		summary := domain.ContainerSummary{
+			ID:             c.ID,
+			Name:           c.Name,
+			MarketHashName: c.MarketHashName,
+			Collection:     c.Collection,
+			StatTrak:       c.StatTrak,
+			RareItems:      len(c.Rare),
+		}
+		if collection, err := s.gameData.Collection(c.Collection); err == nil {
+			summary.Items = len(collection.Items)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+func (s *containerServiceImpl) ContainerEV(ctx context.Context, userID, containerID string, query in_ports.ContainerEVQuery) (*domain.ContainerEV, error) {
+	source, err := selectPriceSource(s.sources, query.Venue)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := s.gameData.Container(containerID)
+	if err != nil {
+		return nil, fmt.Errorf("find container: %w", err)
+	}
+	collection, err := s.gameData.Collection(container.Collection)
+	if err != nil {
+		return nil, fmt.Errorf("find collection: %w", err)
+	}
+
+	currency := query.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	outcomes := domain.ContainerOutcomes(container, collection)
+
+	caseKey := domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: container.MarketHashName}
+	keys := []domain.ItemKey{caseKey}
+	for i := range outcomes {
+		keys = append(keys, domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: outcomes[i].MarketHashName})
+	}
+
+	prices, err := fetchPrices(ctx, source, s.currency, keys, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPrice := container.KeyPrice
+	if keyPrice > 0 && container.KeyCurrency != currency {
+		rates, err := s.currency.Rates()
+		if err != nil {
+			return nil, err
+		}
+		if keyPrice, err = rates.Convert(keyPrice, container.KeyCurrency, currency); err != nil {
+			return nil, fmt.Errorf("convert key price to %s: %w", currency.Code(), err)
+		}
+	}
+
+	// Комиссия применяется к цене уже в валюте расчёта - как в арбитраже
+	ev := domain.EvaluateContainer(container, outcomes, prices, prices[container.MarketHashName], keyPrice,
+		func(gross int64) int64 { return source.SellerReceives(domain.AppIDCS2, gross) })
+	ev.Venue = source.Name()
+	ev.Currency = currency
+
+	return ev, nil
+}
+
+// selectPriceSource - площадка по имени; пустое имя - Steam
+func selectPriceSource(sources []out_ports.PriceSource, name string) (out_ports.PriceSource, error) {
+	if name == "" {
+		name = domain.VenueSteam
+	}
+	for _, source := range sources {
+		if source.Name() == name {
+			return source, nil
+		}
+	}
+
+	venues := make([]string, len(sources))
+	for i, source := range sources {
+		venues[i] = source.Name()
+	}
+	return nil, fmt.Errorf("%w: unknown venue %q, available: %v", domain.ErrValidation, name, venues)
+}
+
+// fetchPrices - самые дешёвые лоты площадки по market_hash_name в валюте currency
+// Предметов без цены в ответе нет
+func fetchPrices(
+	ctx context.Context,
+	source out_ports.PriceSource,
+	converter CurrencyConverter,
+	keys []domain.ItemKey,
+	currency domain.Currency,
+) (map[string]int64, error) {
+	quotes, err := source.FetchQuotes(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s quotes: %w", source.Name(), err)
+	}
+
+	var rates *domain.ExchangeRates
+	prices := make(map[string]int64, len(quotes))
+	for key, quote := range quotes {
+		if quote.Currency != currency {
+			// Курсы нужны, только если есть что конвертировать
+			if rates == nil {
+				if rates, err = converter.Rates(); err != nil {
+					return nil, err
+				}
+			}
+			if quote, err = rates.ConvertQuote(quote, currency); err != nil {
+				return nil, fmt.Errorf("convert %s quote to %s: %w", source.Name(), currency.Code(), err)
+			}
+		}
+		prices[key.MarketHashName] = quote.LowestPrice
+	}
+	return prices, nil
+}
@@ -0,0 +1,300 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// CurrencyConverter - то, что нужно остальным сервисам модуля для работы с валютами
+type CurrencyConverter interface {
+	// Canonical - валюта, в которой хранятся снимки цен
+	Canonical() domain.Currency
+
+	// Rates - текущие курсы; ошибка с domain.ErrNoRate, если они ещё ни разу не загружались
+	Rates() (*domain.ExchangeRates, error)
+
+	// DisplayCurrency - валюта, в которой пользователю отдаются цены
+	DisplayCurrency(ctx context.Context, userID string) (domain.Currency, error)
+}
+
+// CurrencyService - курсы валют + настройки пользователя
+//
+// Курсы держатся в памяти и периодически обновляются из RateSource.
+// Последние полученные курсы сохраняются в БД: после рестарта конвертация
+// работает сразу, даже если источник временно недоступен.
+type CurrencyService interface {
+	in_ports.CurrencyService
+	CurrencyConverter
+	Start()
+	Stop()
+}
+
+type currencyServiceImpl struct {
+	canonical domain.Currency
+	source    out_ports.RateSource
+	ratesRepo out_ports.ExchangeRateRepository
+	prefsRepo out_ports.UserPreferencesRepository
+	interval  time.Duration
+	logger    logger.Logger
+
+	mu    sync.RWMutex
+	rates *domain.ExchangeRates
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewCurrencyService(
+	canonical domain.Currency,
+	source out_ports.RateSource,
+	ratesRepo out_ports.ExchangeRateRepository,
+	prefsRepo out_ports.UserPreferencesRepository,
+	refreshInterval time.Duration,
+	log logger.Logger,
+) CurrencyService {
+	if refreshInterval <= 0 {
+		refreshInterval = time.Hour
+	}
+
+	return &currencyServiceImpl{
+		canonical: canonical,
+		source:    source,
+		ratesRepo: ratesRepo,
+		prefsRepo: prefsRepo,
+		interval:  refreshInterval,
+		logger:    log,
+	}
+}
+
+// Start - поднимает сохранённые курсы из БД и запускает периодическое обновление
+// Загрузка из БД синхронная: к моменту старта poller'а курсы уже должны быть в памяти
+func (s *currencyServiceImpl) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	loadCtx, cancelLoad := context.WithTimeout(ctx, 5*time.Second)
+	stored, err := s.ratesRepo.Load(loadCtx)
+	cancelLoad()
+	switch {
+	case err == nil:
+		if err := s.setRates(stored); err != nil {
+			s.logger.Warnf("stored exchange rates are unusable: %v", err)
+		}
+	case !errors.Is(err, out_ports.ErrNotFound):
+		s.logger.Errorf("load stored exchange rates: %v", err)
+	}
+
+	go s.run(ctx)
+
+	s.logger.Infof("exchange rates refresher started, source=%s, canonical=%s, interval=%s",
+		s.source.Name(), s.canonical.Code(), s.interval)
+}
+
+// Stop - останавливает обновление курсов
+// Безопасно вызывать, даже если Start не вызывался
+func (s *currencyServiceImpl) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+
+	s.logger.Info("exchange rates refresher stopped")
+}
+
+// run - первое обновление сразу, дальше - раз в interval
+func (s *currencyServiceImpl) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh - получает курсы из источника, приводит к канонической валюте и сохраняет
+// При ошибке остаются прежние курсы
+func (s *currencyServiceImpl) refresh(ctx context.Context) {
+	fetched, err := s.source.FetchRates(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Errorf("fetch exchange rates from %s: %v", s.source.Name(), err)
+		}
+		return
+	}
+
+	if err := s.setRates(fetched); err != nil {
+		s.logger.Errorf("exchange rates from %s: %v", s.source.Name(), err)
+		return
+	}
+
+	rates, _ := s.Rates()
+	if err := s.ratesRepo.Save(ctx, rates); err != nil {
+		s.logger.Errorf("save exchange rates: %v", err)
+	}
+}
+
+// setRates - приводит курсы к канонической валюте и подменяет текущие
+func (s *currencyServiceImpl) setRates(rates *domain.ExchangeRates) error {
+	rebased, err := rates.Rebase(s.canonical)
+	if err != nil {
+		return fmt.Errorf("rebase to %s: %w", s.canonical.Code(), err)
+	}
+
+	s.mu.Lock()
+	s.rates = rebased
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *currencyServiceImpl) Canonical() domain.Currency {
+	return s.canonical
+}
+
+func (s *currencyServiceImpl) Rates() (*domain.ExchangeRates, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.rates == nil {
+		return nil, fmt.Errorf("%w: exchange rates are not loaded yet", domain.ErrNoRate)
+	}
+	return s.rates, nil
+}
+
+func (s *currencyServiceImpl) DisplayCurrency(ctx context.Context, userID string) (domain.Currency, error) {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return prefs.DisplayCurrency, nil
+}
+
+func (s *currencyServiceImpl) GetPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	prefs, err := s.prefsRepo.Get(ctx, userID)
+	if errors.Is(err, out_ports.ErrNotFound) {
+		return &domain.UserPreferences{UserID: userID, DisplayCurrency: s.canonical}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+func (s *currencyServiceImpl) UpdatePreferences(ctx context.Context, userID string, input in_ports.UpdatePreferencesInput) (*domain.UserPreferences, error) {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DisplayCurrency != nil {
+		prefs.DisplayCurrency = *input.DisplayCurrency
+	}
+	if input.SteamID != nil {
+		prefs.SteamID = strings.TrimSpace(*input.SteamID)
+	}
+
+	if err := prefs.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("save user preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (s *currencyServiceImpl) GetRates(context.Context) (*domain.ExchangeRates, error) {
+	return s.Rates()
+}
+
+// convertSnapshots - переводит снимки в валюту to на месте
+// Курсы запрашиваются только если есть что конвертировать: пока курсов нет,
+// снимки в нужной валюте всё равно отдаются
+func convertSnapshots(converter CurrencyConverter, snapshots []domain.PriceSnapshot, to domain.Currency) error {
+	var rates *domain.ExchangeRates
+	for i := range snapshots {
+		if snapshots[i].Currency == to {
+			continue
+		}
+		if rates == nil {
+			var err error
+			if rates, err = converter.Rates(); err != nil {
+				return err
+			}
+		}
+
+		converted, err := rates.ConvertSnapshot(snapshots[i], to)
+		if err != nil {
+			return err
+		}
+		snapshots[i] = converted
+	}
+	return nil
+}
+
+// convertLots - переводит сделки в валюту to на месте (курсы - только если нужно)
+func convertLots(converter CurrencyConverter, lots []domain.Lot, to domain.Currency) error {
+	var rates *domain.ExchangeRates
+	for i := range lots {
+		if lots[i].Currency == to {
+			continue
+		}
+		if rates == nil {
+			var err error
+			if rates, err = converter.Rates(); err != nil {
+				return err
+			}
+		}
+
+		converted, err := rates.ConvertLot(lots[i], to)
+		if err != nil {
+			return err
+		}
+		lots[i] = converted
+	}
+	return nil
+}
+
+// convertAnomalies - переводит аномалии в валюту to на месте (курсы - только если нужно)
+func convertAnomalies(converter CurrencyConverter, anomalies []domain.Anomaly, to domain.Currency) error {
+	var rates *domain.ExchangeRates
+	for i := range anomalies {
+		if anomalies[i].Currency == to {
+			continue
+		}
+		if rates == nil {
+			var err error
+			if rates, err = converter.Rates(); err != nil {
+				return err
+			}
+		}
+
+		converted, err := rates.ConvertAnomaly(anomalies[i], to)
+		if err != nil {
+			return err
+		}
+		anomalies[i] = converted
+	}
+	return nil
+}
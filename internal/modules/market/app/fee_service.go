@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+)
+
+type FeeService interface {
+	in_ports.FeeService
+}
+
+// feeServiceImpl - тонкая обёртка над domain.FeeSchedule: вся математика в домене
+type feeServiceImpl struct{}
+
+func NewFeeService() FeeService {
+	return feeServiceImpl{}
+}
+
+func (feeServiceImpl) CalculateFees(_ context.Context, query in_ports.FeeQuery) (*domain.FeeQuote, error) {
+	appID := query.AppID
+	if appID == 0 {
+		appID = domain.AppIDCS2
+	}
+	if appID < 0 {
+		return nil, domain.ErrInvalidAppID
+	}
+
+	if (query.BuyerPays > 0) == (query.SellerReceives > 0) {
+		return nil, fmt.Errorf("%w: exactly one of buyer_pays and seller_receives must be positive", domain.ErrValidation)
+	}
+
+	schedule := domain.FeeScheduleFor(appID)
+	quote := &domain.FeeQuote{AppID: appID, Schedule: schedule}
+
+	var (
+		breakdown domain.FeeBreakdown
+		err       error
+	)
+	if query.SellerReceives > 0 {
+		breakdown, err = schedule.FromSellerReceives(query.SellerReceives)
+	} else {
+		breakdown, err = schedule.FromBuyerPays(query.BuyerPays)
+	}
+	if err != nil {
+		return nil, err
+	}
+	quote.FeeBreakdown = breakdown
+
+	return quote, nil
+}
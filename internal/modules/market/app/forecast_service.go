@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+type ForecastService interface {
+	in_ports.ForecastService
+}
+
+type forecastServiceImpl struct {
+	models    []domain.ForecastModel
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	history   out_ports.PriceHistoryRepository
+	currency  CurrencyConverter
+}
+
+// NewForecastService - models - доступные модели в порядке вывода (domain.DefaultForecastModels)
+func NewForecastService(
+	models []domain.ForecastModel,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	history out_ports.PriceHistoryRepository,
+	currency CurrencyConverter,
+) ForecastService {
+	return &forecastServiceImpl{
+		models:    models,
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		history:   history,
+		currency:  currency,
+	}
+}
+
+func (s *forecastServiceImpl) Models() []string {
+	names := make([]string, len(s.models))
+	for i, model := range s.models {
+		names[i] = model.Name()
+	}
+	return names
+}
+
+func (s *forecastServiceImpl) ForecastItem(ctx context.Context, userID, itemID string, query in_ports.ForecastQuery) (*domain.ItemForecast, error) {
+	days := query.Days
+	if days == 0 {
+		days = domain.DefaultForecastDays
+	}
+	historyDays := query.HistoryDays
+	if historyDays == 0 {
+		historyDays = domain.DefaultForecastHistory
+	}
+	if err := domain.ValidateForecastRange(days, historyDays); err != nil {
+		return nil, err
+	}
+
+	models, err := s.selectModels(query.Models)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -historyDays)
+	snapshots, err := listPriceSeries(ctx, s.snapshots, s.history, item.Key(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := query.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	// Как и для свечей: вся история в одной валюте по текущему курсу
+	if err := convertSnapshots(s.currency, snapshots, currency); err != nil {
+		return nil, fmt.Errorf("convert prices to %s: %w", currency.Code(), err)
+	}
+
+	values, lastDate := domain.DailySeries(snapshots)
+
+	forecast := &domain.ItemForecast{
+		TrackedItemID:  item.ID,
+		AppID:          item.AppID,
+		MarketHashName: item.MarketHashName,
+		Currency:       currency,
+		Days:           days,
+		Confidence:     domain.ForecastConfidence,
+		HistoryPoints:  len(values),
+		LastDate:       lastDate,
+		Models:         make([]domain.ModelForecast, len(models)),
+	}
+	if len(values) > 0 {
+		forecast.LastPrice = int64(values[len(values)-1])
+	}
+
+	for i, model := range models {
+		forecast.Models[i] = domain.RunForecast(model, values, days, lastDate)
+	}
+	forecast.Best = domain.BestForecast(forecast.Models)
+
+	return forecast, nil
+}
+
+// selectModels - модели по именам из запроса; пустой список - все
+func (s *forecastServiceImpl) selectModels(names []string) ([]domain.ForecastModel, error) {
+	if len(names) == 0 {
+		return s.models, nil
+	}
+
+	selected := make([]domain.ForecastModel, 0, len(names))
+	for _, name := range names {
+		idx := slices.IndexFunc(s.models, func(m domain.ForecastModel) bool { return m.Name() == name })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: unknown model %q (use %s)", domain.ErrValidation, name, strings.Join(s.Models(), ", "))
+		}
+		if !slices.Contains(selected, s.models[idx]) {
+			selected = append(selected, s.models[idx])
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+type InventoryService interface {
+	in_ports.InventoryService
+}
+
+type inventoryServiceImpl struct {
+	inventory out_ports.SteamInventoryClient
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	currency  CurrencyService
+	catalog   CatalogRegistry
+	logger    logger.Logger
+}
+
+func NewInventoryService(
+	inventory out_ports.SteamInventoryClient,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	currency CurrencyService,
+	catalog CatalogRegistry,
+	log logger.Logger,
+) InventoryService {
+	return &inventoryServiceImpl{
+		inventory: inventory,
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		currency:  currency,
+		catalog:   catalog,
+		logger:    log,
+	}
+}
+
+// ImportInventory - инвентарь → позиции → (опционально) отслеживание → оценка
+//
+// Оценка - по последним снимкам poller'а. Предметы, которые только что добавлены
+// в отслеживаемые, получат цену после ближайшего раунда опроса.
+func (s *inventoryServiceImpl) ImportInventory(ctx context.Context, userID string, input in_ports.ImportInventoryInput) (*domain.InventoryValuation, error) {
+	prefs, err := s.currency.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	steamID := strings.TrimSpace(input.SteamID)
+	if steamID == "" {
+		steamID = prefs.SteamID
+	}
+	if steamID == "" {
+		return nil, fmt.Errorf("%w: steam_id is required (or link a Steam account via /market/preferences)", domain.ErrValidation)
+	}
+	if err := domain.ValidateSteamID(steamID); err != nil {
+		return nil, err
+	}
+
+	appID := input.AppID
+	if appID == 0 {
+		appID = domain.AppIDCS2
+	}
+	if appID < 0 {
+		return nil, domain.ErrInvalidAppID
+	}
+	contextID := input.ContextID
+	if contextID == "" {
+		contextID = domain.ContextIDCS2
+	}
+
+	assets, err := s.inventory.GetInventory(ctx, steamID, appID, contextID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch steam inventory: %w", err)
+	}
+
+	positions := domain.GroupInventory(assets)
+	if input.MarketableOnly {
+		marketable := positions[:0]
+		for _, p := range positions {
+			if p.Marketable {
+				marketable = append(marketable, p)
+			}
+		}
+		positions = marketable
+	}
+
+	valuation := &domain.InventoryValuation{
+		SteamID:    steamID,
+		AppID:      appID,
+		ContextID:  contextID,
+		Currency:   prefs.DisplayCurrency,
+		Positions:  positions,
+		ImportedAt: time.Now().UTC(),
+	}
+
+	// Инвентарь - единственный источник иконок и редкости для каталога
+	for i := range positions {
+		if err := s.catalog.Register(ctx, positions[i].CatalogItem()); err != nil {
+			s.logger.Warnf("register %q in catalog: %v", positions[i].MarketHashName, err)
+		}
+	}
+
+	if err := s.linkTracked(ctx, userID, valuation, input.Track); err != nil {
+		return nil, err
+	}
+	if err := s.price(ctx, valuation); err != nil {
+		return nil, err
+	}
+	valuation.Summarize()
+
+	s.logger.Infof("inventory imported, user_id=%s, steam_id=%s, positions=%d, newly_tracked=%d",
+		userID, steamID, len(valuation.Positions), valuation.NewlyTracked)
+
+	return valuation, nil
+}
+
+// linkTracked - проставляет TrackedItemID уже отслеживаемым позициям
+// и при track=true начинает отслеживать остальные продаваемые
+func (s *inventoryServiceImpl) linkTracked(ctx context.Context, userID string, valuation *domain.InventoryValuation, track bool) error {
+	tracked, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list tracked items: %w", err)
+	}
+
+	trackedIDs := make(map[domain.ItemKey]string, len(tracked))
+	for i := range tracked {
+		trackedIDs[tracked[i].Key()] = tracked[i].ID
+	}
+
+	for i := range valuation.Positions {
+		p := &valuation.Positions[i]
+		if id, ok := trackedIDs[p.Key()]; ok {
+			p.TrackedItemID = id
+			continue
+		}
+		if !track || !p.Marketable {
+			continue
+		}
+
+		item := domain.NewTrackedItem(userID, p.AppID, p.MarketHashName, p.Name, "")
+		if err := s.itemRepo.Create(ctx, item); err != nil {
+			if errors.Is(err, out_ports.ErrAlreadyExists) {
+				continue // добавили параллельным запросом
+			}
+			return fmt.Errorf("track %q: %w", p.MarketHashName, err)
+		}
+
+		p.TrackedItemID = item.ID
+		valuation.NewlyTracked++
+	}
+
+	return nil
+}
+
+// price - оценивает позиции по последним снимкам в валюте valuation.Currency
+func (s *inventoryServiceImpl) price(ctx context.Context, valuation *domain.InventoryValuation) error {
+	keys := make([]domain.ItemKey, 0, len(valuation.Positions))
+	for i := range valuation.Positions {
+		keys = append(keys, valuation.Positions[i].Key())
+	}
+
+	latest, err := s.snapshots.LatestByKeys(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("load latest prices: %w", err)
+	}
+
+	for i := range valuation.Positions {
+		p := &valuation.Positions[i]
+		snapshot, ok := latest[p.Key()]
+		if !ok {
+			continue
+		}
+
+		converted := []domain.PriceSnapshot{snapshot}
+		if err := convertSnapshots(s.currency, converted, valuation.Currency); err != nil {
+			return fmt.Errorf("convert prices to %s: %w", valuation.Currency.Code(), err)
+		}
+		p.Price(&converted[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// LiquidityPoller - фоновый воркер, который пересчитывает ликвидность отслеживаемых предметов
+//
+// Раунды частые, но пересчитываются только метрики старше cfg.MaxAge: новый предмет
+// получает метрики в пределах одного интервала, а pricehistory каждого предмета
+// запрашивается не чаще раза в MaxAge. 429 прерывает раунд, остаток переносится на следующий.
+type LiquidityPoller struct {
+	cfg           config.LiquidityConfig
+	itemRepo      out_ports.TrackedItemRepository
+	liquidityRepo out_ports.LiquidityRepository
+	refresher     LiquidityRefresher
+	logger        logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLiquidityPoller - создаёт воркер ликвидности (запуск - через Start)
+func NewLiquidityPoller(
+	cfg config.LiquidityConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	liquidityRepo out_ports.LiquidityRepository,
+	refresher LiquidityRefresher,
+	log logger.Logger,
+) *LiquidityPoller {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+
+	return &LiquidityPoller{
+		cfg:           cfg,
+		itemRepo:      itemRepo,
+		liquidityRepo: liquidityRepo,
+		refresher:     refresher,
+		logger:        log,
+	}
+}
+
+// Start - запускает фоновую горутину; первый раунд сразу, следующие - через cfg.Interval
+func (p *LiquidityPoller) Start() {
+	// Фоновые запросы уступают бюджет Steam запросам пользователей
+	ctx, cancel := context.WithCancel(httpclient.WithPriority(context.Background(), httpclient.PriorityBackground))
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go p.run(ctx)
+
+	p.logger.Infof("liquidity poller started, interval=%s, max_age=%s", p.cfg.Interval, p.cfg.MaxAge)
+}
+
+// Stop - останавливает пересчёт и ждёт завершения текущего запроса
+// Безопасно вызывать, даже если Start не вызывался
+func (p *LiquidityPoller) Stop() {
+	if p.cancel == nil {
+		return
+	}
+
+	p.cancel()
+	<-p.done
+
+	p.logger.Info("liquidity poller stopped")
+}
+
+// run - раунд → пауза → раунд ... (пауза отсчитывается от конца раунда)
+func (p *LiquidityPoller) run(ctx context.Context) {
+	defer close(p.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		p.pollOnce(ctx)
+		timer.Reset(p.cfg.Interval)
+	}
+}
+
+// pollOnce - пересчёт устаревших и ещё не посчитанных метрик
+func (p *LiquidityPoller) pollOnce(ctx context.Context) {
+	started := time.Now()
+
+	keys, err := p.itemRepo.ListDistinctKeys(ctx)
+	if err != nil {
+		p.logger.Errorf("list tracked item keys: %v", err)
+		return
+	}
+
+	stale, err := p.staleKeys(ctx, keys)
+	if err != nil {
+		p.logger.Errorf("list item liquidity: %v", err)
+		return
+	}
+
+	var refreshed, failed int
+	for _, key := range stale {
+		if p.cfg.Jitter > 0 {
+			select {
+			case <-time.After(rand.N(p.cfg.Jitter)):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if _, err := p.refresher.Refresh(ctx, key); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failed++
+			if errors.Is(err, out_ports.ErrSteamRateLimited) {
+				p.logger.Warn("steam rate limit hit, skipping rest of the liquidity round")
+				break
+			}
+			p.logger.Warnf("refresh liquidity for %q: %v", key.MarketHashName, err)
+			continue
+		}
+		refreshed++
+	}
+
+	if len(stale) > 0 {
+		p.logger.Infof("liquidity round finished, items=%d, stale=%d, refreshed=%d, failed=%d, duration=%s",
+			len(keys), len(stale), refreshed, failed, time.Since(started).Round(time.Millisecond))
+	}
+}
+
+// staleKeys - предметы без метрик или с метриками старше cfg.MaxAge
+func (p *LiquidityPoller) staleKeys(ctx context.Context, keys []domain.ItemKey) ([]domain.ItemKey, error) {
+	existing, err := p.liquidityRepo.ListByKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	computed := make(map[domain.ItemKey]time.Time, len(existing))
+	for i := range existing {
+		computed[existing[i].Key()] = existing[i].ComputedAt
+	}
+
+	threshold := time.Now().Add(-p.cfg.MaxAge)
+	stale := make([]domain.ItemKey, 0, len(keys))
+	for _, key := range keys {
+		if at, ok := computed[key]; !ok || at.Before(threshold) {
+			stale = append(stale, key)
+		}
+	}
+	return stale, nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// LiquidityRefresher - пересчёт метрик ликвидности предмета с сохранением
+type LiquidityRefresher interface {
+	Refresh(ctx context.Context, key domain.ItemKey) (*domain.ItemLiquidity, error)
+}
+
+type LiquidityService interface {
+	in_ports.LiquidityService
+	LiquidityRefresher
+}
+
+type liquidityServiceImpl struct {
+	cfg           config.LiquidityConfig
+	itemRepo      out_ports.TrackedItemRepository
+	liquidityRepo out_ports.LiquidityRepository
+	snapshots     out_ports.PriceSnapshotRepository
+	steam         out_ports.SteamMarketClient
+	currency      CurrencyConverter
+	logger        logger.Logger
+}
+
+func NewLiquidityService(
+	cfg config.LiquidityConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	liquidityRepo out_ports.LiquidityRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	steam out_ports.SteamMarketClient,
+	currency CurrencyConverter,
+	log logger.Logger,
+) LiquidityService {
+	return &liquidityServiceImpl{
+		cfg:           cfg,
+		itemRepo:      itemRepo,
+		liquidityRepo: liquidityRepo,
+		snapshots:     snapshots,
+		steam:         steam,
+		currency:      currency,
+		logger:        log,
+	}
+}
+
+func (s *liquidityServiceImpl) GetItemLiquidity(ctx context.Context, userID, itemID string) (*domain.ItemLiquidity, error) {
+	// Проверяем что предмет принадлежит пользователю (чужой → ErrNotFound)
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	liquidity, err := s.liquidityRepo.Find(ctx, item.Key())
+	if errors.Is(err, out_ports.ErrNotFound) {
+		// Предмет добавлен недавно и воркер до него ещё не дошёл
+		if liquidity, err = s.Refresh(ctx, item.Key()); err != nil {
+			return nil, fmt.Errorf("compute liquidity: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("find item liquidity: %w", err)
+	}
+
+	return liquidity, nil
+}
+
+// Refresh - считает метрики по истории продаж Steam и сохраняет
+//
+// Без cookie steamLoginSecure pricehistory недоступна: тогда, как и при пустом ответе
+// Steam, метрики считаются по объёмам из собственных снимков цен.
+func (s *liquidityServiceImpl) Refresh(ctx context.Context, key domain.ItemKey) (*domain.ItemLiquidity, error) {
+	now := time.Now()
+
+	var liquidity *domain.ItemLiquidity
+	if s.cfg.UsePriceHistory {
+		history, err := s.steam.GetPriceHistory(ctx, key.AppID, key.MarketHashName, s.currency.Canonical())
+		switch {
+		case err == nil:
+			daily := domain.DailySalesFromHistory(history, now)
+			liquidity = domain.ComputeLiquidity(key, domain.LiquidityPriceHistory, daily, now)
+		case errors.Is(err, out_ports.ErrSteamNoData):
+			// Cookie устарела или предмет не продавался - считаем по снимкам ниже
+		default:
+			return nil, err
+		}
+	}
+
+	if liquidity == nil {
+		from := now.AddDate(0, 0, -domain.LiquidityWindowDays-1)
+		snapshots, err := s.snapshots.ListRange(ctx, key, from, now)
+		if err != nil {
+			return nil, fmt.Errorf("list price snapshots: %w", err)
+		}
+		daily := domain.DailySalesFromSnapshots(snapshots, now)
+		liquidity = domain.ComputeLiquidity(key, domain.LiquiditySnapshots, daily, now)
+	}
+
+	if err := s.liquidityRepo.Save(ctx, liquidity); err != nil {
+		return nil, err
+	}
+
+	return liquidity, nil
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// OrderBookPoller - фоновый воркер, который периодически снимает стаканы отслеживаемых предметов
+//
+// В отличие от PricePoller предметы обходятся по одному: itemordershistogram
+// Steam ограничивает жёстче, а для нового предмета нужен ещё и запрос страницы лота.
+// 429 прерывает раунд, остаток переносится на следующий.
+type OrderBookPoller struct {
+	cfg      config.OrderBookConfig
+	itemRepo out_ports.TrackedItemRepository
+	capturer OrderBookCapturer
+	logger   logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewOrderBookPoller - создаёт poller стаканов (запуск - через Start)
+func NewOrderBookPoller(
+	cfg config.OrderBookConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	capturer OrderBookCapturer,
+	log logger.Logger,
+) *OrderBookPoller {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+
+	return &OrderBookPoller{
+		cfg:      cfg,
+		itemRepo: itemRepo,
+		capturer: capturer,
+		logger:   log,
+	}
+}
+
+// Start - запускает фоновую горутину; первый раунд сразу, следующие - через cfg.Interval
+func (p *OrderBookPoller) Start() {
+	// Фоновые запросы уступают бюджет Steam запросам пользователей
+	ctx, cancel := context.WithCancel(httpclient.WithPriority(context.Background(), httpclient.PriorityBackground))
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go p.run(ctx)
+
+	p.logger.Infof("order book poller started, interval=%s", p.cfg.Interval)
+}
+
+// Stop - останавливает опрос и ждёт завершения текущего запроса
+// Безопасно вызывать, даже если Start не вызывался
+func (p *OrderBookPoller) Stop() {
+	if p.cancel == nil {
+		return
+	}
+
+	p.cancel()
+	<-p.done
+
+	p.logger.Info("order book poller stopped")
+}
+
+// run - раунд → пауза → раунд ... (пауза отсчитывается от конца раунда)
+func (p *OrderBookPoller) run(ctx context.Context) {
+	defer close(p.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		p.pollOnce(ctx)
+		timer.Reset(p.cfg.Interval)
+	}
+}
+
+// pollOnce - один раунд по всем отслеживаемым предметам
+func (p *OrderBookPoller) pollOnce(ctx context.Context) {
+	started := time.Now()
+
+	keys, err := p.itemRepo.ListDistinctKeys(ctx)
+	if err != nil {
+		p.logger.Errorf("list tracked item keys: %v", err)
+		return
+	}
+
+	var saved, failed int
+	for _, key := range keys {
+		if p.cfg.Jitter > 0 {
+			select {
+			case <-time.After(rand.N(p.cfg.Jitter)):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if _, err := p.capturer.Capture(ctx, key); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failed++
+			if errors.Is(err, out_ports.ErrSteamRateLimited) {
+				p.logger.Warn("steam rate limit hit, skipping rest of the order book round")
+				break
+			}
+			p.logger.Warnf("capture order book for %q: %v", key.MarketHashName, err)
+			continue
+		}
+		saved++
+	}
+
+	if len(keys) > 0 {
+		p.logger.Infof("order book round finished, items=%d, saved=%d, failed=%d, duration=%s",
+			len(keys), saved, failed, time.Since(started).Round(time.Millisecond))
+	}
+}
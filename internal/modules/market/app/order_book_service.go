@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// Окно истории метрик стакана
+const (
+	defaultOrderBookHistoryRange = 24 * time.Hour
+	maxOrderBookHistoryRange     = 90 * 24 * time.Hour
+)
+
+// OrderBookCapturer - снятие стакана предмета из Steam с сохранением снимка
+type OrderBookCapturer interface {
+	Capture(ctx context.Context, key domain.ItemKey) (*domain.OrderBook, error)
+}
+
+type OrderBookService interface {
+	in_ports.OrderBookService
+	OrderBookCapturer
+}
+
+type orderBookServiceImpl struct {
+	itemRepo out_ports.TrackedItemRepository
+	books    out_ports.OrderBookRepository
+	steam    out_ports.SteamMarketClient
+	currency CurrencyConverter
+	logger   logger.Logger
+}
+
+func NewOrderBookService(
+	itemRepo out_ports.TrackedItemRepository,
+	books out_ports.OrderBookRepository,
+	steam out_ports.SteamMarketClient,
+	currency CurrencyConverter,
+	log logger.Logger,
+) OrderBookService {
+	return &orderBookServiceImpl{
+		itemRepo: itemRepo,
+		books:    books,
+		steam:    steam,
+		currency: currency,
+		logger:   log,
+	}
+}
+
+func (s *orderBookServiceImpl) GetOrderBookMetrics(ctx context.Context, userID, itemID string, query in_ports.OrderBookQuery) (*domain.OrderBookMetrics, error) {
+	if query.Percent == 0 {
+		query.Percent = domain.DefaultDepthPercent
+	}
+	if err := domain.ValidateDepthPercent(query.Percent); err != nil {
+		return nil, err
+	}
+
+	book, err := s.latestForUser(ctx, userID, itemID, query.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := book.Metrics(query.Percent)
+	return &metrics, nil
+}
+
+func (s *orderBookServiceImpl) GetDepthChart(ctx context.Context, userID, itemID string, query in_ports.OrderBookQuery) (*domain.DepthChart, error) {
+	if query.Percent == 0 {
+		query.Percent = domain.DefaultDepthChartPercent
+	}
+	if err := domain.ValidateDepthPercent(query.Percent); err != nil {
+		return nil, err
+	}
+
+	book, err := s.latestForUser(ctx, userID, itemID, query.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	chart := book.DepthChart(query.Percent)
+	return &chart, nil
+}
+
+func (s *orderBookServiceImpl) GetOrderBookHistory(ctx context.Context, userID, itemID string, query in_ports.OrderBookHistoryQuery) ([]domain.OrderBookMetrics, error) {
+	if query.DepthPercent == 0 {
+		query.DepthPercent = domain.DefaultDepthPercent
+	}
+	if err := domain.ValidateDepthPercent(query.DepthPercent); err != nil {
+		return nil, err
+	}
+
+	to := query.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.Add(-defaultOrderBookHistoryRange)
+	}
+	if !from.Before(to) || to.Sub(from) > maxOrderBookHistoryRange {
+		return nil, fmt.Errorf("%w: from must be before to and the range must not exceed %s",
+			domain.ErrValidation, maxOrderBookHistoryRange)
+	}
+
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	books, err := s.books.ListRange(ctx, item.Key(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list order books: %w", err)
+	}
+
+	currency, err := s.resolveCurrency(ctx, userID, query.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates *domain.ExchangeRates
+	history := make([]domain.OrderBookMetrics, 0, len(books))
+	for i := range books {
+		book := books[i]
+		if book.Currency != currency {
+			if rates == nil {
+				if rates, err = s.currency.Rates(); err != nil {
+					return nil, err
+				}
+			}
+			if book, err = rates.ConvertOrderBook(book, currency); err != nil {
+				return nil, fmt.Errorf("convert order book to %s: %w", currency.Code(), err)
+			}
+		}
+		history = append(history, book.Metrics(query.DepthPercent))
+	}
+
+	return history, nil
+}
+
+// Capture - снимает стакан из Steam в канонической валюте и сохраняет снимок
+// item_nameid берётся со страницы лота при первом обращении и дальше читается из БД
+func (s *orderBookServiceImpl) Capture(ctx context.Context, key domain.ItemKey) (*domain.OrderBook, error) {
+	itemNameID, err := s.books.FindItemNameID(ctx, key)
+	if errors.Is(err, out_ports.ErrNotFound) {
+		if itemNameID, err = s.steam.GetItemNameID(ctx, key.AppID, key.MarketHashName); err != nil {
+			return nil, err
+		}
+		if err := s.books.SaveItemNameID(ctx, key, itemNameID); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	book, err := s.steam.GetOrderBook(ctx, itemNameID, s.currency.Canonical())
+	if err != nil {
+		return nil, err
+	}
+	book.AppID = key.AppID
+	book.MarketHashName = key.MarketHashName
+	book.ObservedAt = time.Now().UTC()
+
+	if err := s.books.Save(ctx, book); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// latestForUser - последний снимок стакана предмета пользователя в нужной валюте
+func (s *orderBookServiceImpl) latestForUser(ctx context.Context, userID, itemID string, currency domain.Currency) (*domain.OrderBook, error) {
+	// Проверяем что предмет принадлежит пользователю (чужой → ErrNotFound)
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	book, err := s.books.Latest(ctx, item.Key())
+	if errors.Is(err, out_ports.ErrNotFound) {
+		// Предмет добавлен недавно и poller до него ещё не дошёл
+		if book, err = s.Capture(ctx, item.Key()); err != nil {
+			return nil, fmt.Errorf("capture order book: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("latest order book: %w", err)
+	}
+
+	if currency, err = s.resolveCurrency(ctx, userID, currency); err != nil {
+		return nil, err
+	}
+	if book.Currency == currency {
+		return book, nil
+	}
+
+	rates, err := s.currency.Rates()
+	if err != nil {
+		return nil, err
+	}
+	converted, err := rates.ConvertOrderBook(*book, currency)
+	if err != nil {
+		return nil, fmt.Errorf("convert order book to %s: %w", currency.Code(), err)
+	}
+	return &converted, nil
+}
+
+// resolveCurrency - явная валюта запроса или валюта отображения пользователя
+func (s *orderBookServiceImpl) resolveCurrency(ctx context.Context, userID string, currency domain.Currency) (domain.Currency, error) {
+	if currency != 0 {
+		return currency, nil
+	}
+	return s.currency.DisplayCurrency(ctx, userID)
+}
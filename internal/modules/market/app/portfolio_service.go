@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+type PortfolioService interface {
+	in_ports.PortfolioService
+}
+
+type portfolioServiceImpl struct {
+	lotRepo   out_ports.LotRepository
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	currency  CurrencyConverter
+	logger    logger.Logger
+}
+
+func NewPortfolioService(
+	lotRepo out_ports.LotRepository,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	currency CurrencyConverter,
+	log logger.Logger,
+) PortfolioService {
+	return &portfolioServiceImpl{
+		lotRepo:   lotRepo,
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		currency:  currency,
+		logger:    log,
+	}
+}
+
+func (s *portfolioServiceImpl) ListLots(ctx context.Context, userID, trackedItemID string) ([]domain.Lot, error) {
+	var (
+		lots []domain.Lot
+		err  error
+	)
+	if trackedItemID == "" {
+		lots, err = s.lotRepo.ListByUser(ctx, userID)
+	} else {
+		lots, err = s.lotRepo.ListByItem(ctx, userID, trackedItemID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list lots: %w", err)
+	}
+	return lots, nil
+}
+
+func (s *portfolioServiceImpl) CreateLot(ctx context.Context, userID string, input in_ports.CreateLotInput) (*domain.Lot, error) {
+	// Сделку можно записать только на свой предмет
+	if _, err := s.itemRepo.FindByID(ctx, userID, input.TrackedItemID); err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	var currency domain.Currency
+	if input.Currency != nil {
+		currency = *input.Currency
+	} else {
+		var err error
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var executedAt time.Time
+	if input.ExecutedAt != nil {
+		executedAt = *input.ExecutedAt
+	}
+
+	lot := domain.NewLot(userID, input.TrackedItemID, input.Side, input.Quantity, input.UnitPrice, input.Fee, currency, executedAt, input.Notes)
+	if err := lot.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Продажа не может превысить позицию ни в какой момент времени
+	if lot.Side == domain.LotSell {
+		existing, err := s.lotRepo.ListByItem(ctx, userID, lot.TrackedItemID)
+		if err != nil {
+			return nil, fmt.Errorf("list lots: %w", err)
+		}
+		if err := domain.ValidateLotSequence(append(existing, *lot)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.lotRepo.Create(ctx, lot); err != nil {
+		return nil, fmt.Errorf("create lot: %w", err)
+	}
+
+	s.logger.Infof("lot created, id=%s, user_id=%s, side=%s, quantity=%d", lot.ID, userID, lot.Side, lot.Quantity)
+
+	return lot, nil
+}
+
+func (s *portfolioServiceImpl) DeleteLot(ctx context.Context, userID, lotID string) error {
+	lot, err := s.lotRepo.FindByID(ctx, userID, lotID)
+	if err != nil {
+		return fmt.Errorf("find lot: %w", err)
+	}
+
+	// Удаление покупки может оставить более поздние продажи без покрытия
+	if lot.Side == domain.LotBuy {
+		existing, err := s.lotRepo.ListByItem(ctx, userID, lot.TrackedItemID)
+		if err != nil {
+			return fmt.Errorf("list lots: %w", err)
+		}
+		remaining := existing[:0]
+		for _, l := range existing {
+			if l.ID != lot.ID {
+				remaining = append(remaining, l)
+			}
+		}
+		if err := domain.ValidateLotSequence(remaining); err != nil {
+			return fmt.Errorf("%w: delete the matching sells first", err)
+		}
+	}
+
+	if err := s.lotRepo.Delete(ctx, userID, lotID); err != nil {
+		return fmt.Errorf("delete lot: %w", err)
+	}
+
+	return nil
+}
+
+// GetPortfolio - сделки → FIFO по каждому предмету → оценка по последним снимкам
+// Сделки и цены переводятся в валюту отображения по текущему курсу
+func (s *portfolioServiceImpl) GetPortfolio(ctx context.Context, userID string) (*domain.Portfolio, error) {
+	currency, err := s.currency.DisplayCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lots, err := s.lotRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list lots: %w", err)
+	}
+	if err := convertLots(s.currency, lots, currency); err != nil {
+		return nil, fmt.Errorf("convert lots to %s: %w", currency.Code(), err)
+	}
+
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+	itemsByID := make(map[string]*domain.TrackedItem, len(items))
+	for i := range items {
+		itemsByID[items[i].ID] = &items[i]
+	}
+
+	lotsByItem := map[string][]domain.Lot{}
+	keys := []domain.ItemKey{}
+	for _, lot := range lots {
+		item, ok := itemsByID[lot.TrackedItemID]
+		if !ok {
+			continue
+		}
+		if _, seen := lotsByItem[lot.TrackedItemID]; !seen {
+			keys = append(keys, item.Key())
+		}
+		lotsByItem[lot.TrackedItemID] = append(lotsByItem[lot.TrackedItemID], lot)
+	}
+
+	prices, err := s.latestPrices(ctx, keys, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := &domain.Portfolio{
+		Currency:     currency,
+		Items:        make([]domain.PositionPnL, 0, len(lotsByItem)),
+		CalculatedAt: time.Now().UTC(),
+	}
+
+	for itemID, itemLots := range lotsByItem {
+		item := itemsByID[itemID]
+
+		pnl, err := domain.ComputePositionPnL(itemLots, currency, prices[item.Key()], domain.FeeScheduleFor(item.AppID))
+		if err != nil {
+			return nil, fmt.Errorf("compute pnl for %q: %w", item.MarketHashName, err)
+		}
+		pnl.TrackedItemID = item.ID
+		pnl.AppID = item.AppID
+		pnl.MarketHashName = item.MarketHashName
+
+		portfolio.Items = append(portfolio.Items, pnl)
+	}
+
+	portfolio.Summarize()
+
+	return portfolio, nil
+}
+
+// latestPrices - последняя известная цена предметов в валюте currency
+func (s *portfolioServiceImpl) latestPrices(ctx context.Context, keys []domain.ItemKey, currency domain.Currency) (map[domain.ItemKey]int64, error) {
+	latest, err := s.snapshots.LatestByKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("load latest prices: %w", err)
+	}
+
+	snapshots := make([]domain.PriceSnapshot, 0, len(latest))
+	for _, snapshot := range latest {
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := convertSnapshots(s.currency, snapshots, currency); err != nil {
+		return nil, fmt.Errorf("convert prices to %s: %w", currency.Code(), err)
+	}
+
+	prices := make(map[domain.ItemKey]int64, len(snapshots))
+	for i := range snapshots {
+		prices[snapshots[i].Key()] = snapshots[i].Price()
+	}
+	return prices, nil
+}
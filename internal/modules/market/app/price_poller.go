@@ -0,0 +1,247 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/httpclient"
+	"steam-observer/internal/shared/logger"
+)
+
+// SnapshotObserver - подписчик на новые снимки цен
+// Вызывается синхронно из воркера poller'а после успешного сохранения снимка,
+// поэтому реализация не должна надолго блокироваться
+type SnapshotObserver interface {
+	OnPriceSnapshot(ctx context.Context, snapshot *domain.PriceSnapshot)
+}
+
+// PricePoller - фоновый воркер, который периодически снимает цены всех отслеживаемых предметов
+//
+// Раунд опроса:
+//  1. Берём уникальные (app_id, market_hash_name) по всем пользователям
+//  2. Раздаём ключи Concurrency воркерам через канал
+//  3. Каждый воркер перед запросом ждёт случайный jitter - не шлём в Steam пачку
+//     запросов в одну миллисекунду
+//  4. Цена берётся из источника (Steam в валюте cfg.Currency) и перед сохранением
+//     переводится в каноническую валюту - в БД все снимки в одной валюте
+//  5. Ошибка по одному предмету логируется и не влияет на остальные
+//
+// Исключение - 429 от Steam: продолжать раунд бессмысленно, остаток переносим на следующий.
+type PricePoller struct {
+	cfg       config.PollerConfig
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	source    out_ports.PriceSource
+	currency  CurrencyConverter
+	logger    logger.Logger
+	observers []SnapshotObserver
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPricePoller - создаёт poller (запуск - через Start)
+func NewPricePoller(
+	cfg config.PollerConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	source out_ports.PriceSource,
+	currency CurrencyConverter,
+	log logger.Logger,
+) *PricePoller {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Minute
+	}
+
+	return &PricePoller{
+		cfg:       cfg,
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		source:    source,
+		currency:  currency,
+		logger:    log,
+	}
+}
+
+// AddObserver - подписывает observer на новые снимки
+// Вызывать до Start: список подписчиков не защищён мьютексом
+func (p *PricePoller) AddObserver(observer SnapshotObserver) {
+	p.observers = append(p.observers, observer)
+}
+
+// Start - запускает фоновую горутину опроса
+// Первый раунд выполняется сразу, следующие - через cfg.Interval
+func (p *PricePoller) Start() {
+	// Фоновые запросы уступают бюджет Steam запросам пользователей
+	ctx, cancel := context.WithCancel(httpclient.WithPriority(context.Background(), httpclient.PriorityBackground))
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go p.run(ctx)
+
+	p.logger.Infof("price poller started, interval=%s, concurrency=%d", p.cfg.Interval, p.cfg.Concurrency)
+}
+
+// Stop - останавливает опрос и ждёт завершения текущих запросов
+// Безопасно вызывать, даже если Start не вызывался
+func (p *PricePoller) Stop() {
+	if p.cancel == nil {
+		return
+	}
+
+	p.cancel()
+	<-p.done
+
+	p.logger.Info("price poller stopped")
+}
+
+// run - основной цикл: раунд → пауза → раунд ...
+func (p *PricePoller) run(ctx context.Context) {
+	defer close(p.done)
+
+	// time.Timer вместо Ticker: следующий раунд отсчитывается от конца предыдущего,
+	// поэтому долгий раунд не приводит к наложению раундов друг на друга
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		p.pollOnce(ctx)
+		timer.Reset(p.cfg.Interval)
+	}
+}
+
+// pollOnce - один раунд опроса всех отслеживаемых предметов
+func (p *PricePoller) pollOnce(ctx context.Context) {
+	started := time.Now()
+
+	keys, err := p.itemRepo.ListDistinctKeys(ctx)
+	if err != nil {
+		p.logger.Errorf("list tracked item keys: %v", err)
+		return
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// Отдельный контекст раунда: отменяем его при 429, чтобы воркеры
+	// не добивали Steam оставшимися запросами
+	roundCtx, cancelRound := context.WithCancel(ctx)
+	defer cancelRound()
+
+	jobs := make(chan domain.ItemKey)
+	var (
+		wg              sync.WaitGroup
+		mu              sync.Mutex
+		saved, failed   int
+		rateLimitedOnce sync.Once
+	)
+
+	for range p.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				err := p.pollItem(roundCtx, key)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					saved++
+				}
+				mu.Unlock()
+
+				if errors.Is(err, out_ports.ErrSteamRateLimited) {
+					rateLimitedOnce.Do(func() {
+						p.logger.Warn("steam rate limit hit, skipping rest of the round")
+						cancelRound()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, key := range keys {
+		select {
+		case jobs <- key:
+		case <-roundCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	p.logger.Infof("price poll round finished, items=%d, saved=%d, failed=%d, duration=%s",
+		len(keys), saved, failed, time.Since(started).Round(time.Millisecond))
+}
+
+// pollItem - снимает и сохраняет цену одного предмета
+// Паника в обработке одного предмета не должна ронять весь процесс
+func (p *PricePoller) pollItem(ctx context.Context, key domain.ItemKey) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			p.logger.Errorf("poll item %q panicked: %v", key.MarketHashName, r)
+		}
+	}()
+
+	if p.cfg.Jitter > 0 {
+		select {
+		case <-time.After(rand.N(p.cfg.Jitter)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	quotes, err := p.source.FetchQuotes(ctx, []domain.ItemKey{key})
+	if err != nil {
+		// Отмена раунда (shutdown или 429 у соседа) - не ошибка предмета
+		if ctx.Err() == nil {
+			p.logger.Warnf("fetch price for %q: %v", key.MarketHashName, err)
+		}
+		return err
+	}
+
+	quote, ok := quotes[key]
+	if !ok {
+		p.logger.Warnf("no %s price for %q", p.source.Name(), key.MarketHashName)
+		return fmt.Errorf("no %s price for %q", p.source.Name(), key.MarketHashName)
+	}
+
+	snapshot := quote.Snapshot()
+
+	canonical := []domain.PriceSnapshot{*snapshot}
+	if err := convertSnapshots(p.currency, canonical, p.currency.Canonical()); err != nil {
+		p.logger.Errorf("convert price of %q to %s: %v", key.MarketHashName, p.currency.Canonical().Code(), err)
+		return err
+	}
+	snapshot = &canonical[0]
+
+	if err := p.snapshots.Save(ctx, snapshot); err != nil {
+		p.logger.Errorf("save price snapshot for %q: %v", key.MarketHashName, err)
+		return err
+	}
+
+	for _, observer := range p.observers {
+		observer.OnPriceSnapshot(ctx, snapshot)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+type PriceService interface {
+	in_ports.PriceService
+}
+
+type priceServiceImpl struct {
+	itemRepo  out_ports.TrackedItemRepository
+	snapshots out_ports.PriceSnapshotRepository
+	history   out_ports.PriceHistoryRepository
+	currency  CurrencyConverter
+}
+
+func NewPriceService(
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	history out_ports.PriceHistoryRepository,
+	currency CurrencyConverter,
+) PriceService {
+	return &priceServiceImpl{
+		itemRepo:  itemRepo,
+		snapshots: snapshots,
+		history:   history,
+		currency:  currency,
+	}
+}
+
+func (s *priceServiceImpl) GetCandles(ctx context.Context, userID, itemID string, query in_ports.CandlesQuery) (*domain.CandleSeries, error) {
+	to := query.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.Add(-query.Interval.DefaultRange())
+	}
+
+	if err := query.Interval.ValidateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	// Проверяем что предмет принадлежит пользователю (чужой → ErrNotFound)
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	snapshots, err := listPriceSeries(ctx, s.snapshots, s.history, item.Key(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := query.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Снимки до смены канонической валюты могут быть в другой валюте -
+	// приводим всё к одной, иначе их нельзя смешивать в свече.
+	// Конвертация по текущему курсу: истории курсов мы не храним
+	if err := convertSnapshots(s.currency, snapshots, currency); err != nil {
+		return nil, fmt.Errorf("convert prices to %s: %w", currency.Code(), err)
+	}
+
+	return &domain.CandleSeries{
+		ItemID:         item.ID,
+		AppID:          item.AppID,
+		MarketHashName: item.MarketHashName,
+		Interval:       query.Interval,
+		Currency:       currency,
+		From:           from.UTC(),
+		To:             to.UTC(),
+		Candles:        domain.BuildCandles(snapshots, query.Interval),
+	}, nil
+}
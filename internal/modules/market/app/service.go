@@ -2,29 +2,177 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"steam-observer/internal/modules/market/domain"
 	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
 )
 
-// Пока без out_ports — вернём заглушечные данные.
 type MarketService interface {
 	in_ports.MarketService
 }
 
-type marketServiceImpl struct{}
+type marketServiceImpl struct {
+	itemRepo      out_ports.TrackedItemRepository
+	watchlistRepo out_ports.WatchlistRepository
+	liquidityRepo out_ports.LiquidityRepository
+	catalog       CatalogRegistry
+	logger        logger.Logger
+}
+
+func NewMarketService(
+	itemRepo out_ports.TrackedItemRepository,
+	watchlistRepo out_ports.WatchlistRepository,
+	liquidityRepo out_ports.LiquidityRepository,
+	catalog CatalogRegistry,
+	log logger.Logger,
+) MarketService {
+	return &marketServiceImpl{
+		itemRepo:      itemRepo,
+		watchlistRepo: watchlistRepo,
+		liquidityRepo: liquidityRepo,
+		catalog:       catalog,
+		logger:        log,
+	}
+}
+
+func (s *marketServiceImpl) ListTrackedItems(ctx context.Context, userID string, filter domain.TrackedItemFilter) ([]domain.TrackedItem, error) {
+	tags, err := domain.NormalizeTags(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	filter.Tags = tags
+
+	// Чужой или удалённый список - 404, а не пустая выдача: клиент должен узнать, что ссылка устарела
+	if filter.WatchlistID != "" {
+		if _, err := s.watchlistRepo.FindByID(ctx, userID, filter.WatchlistID); err != nil {
+			return nil, fmt.Errorf("find watchlist: %w", err)
+		}
+	}
+
+	items, err := s.itemRepo.ListFiltered(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+
+	if err := s.attachLiquidity(ctx, items); err != nil {
+		return nil, err
+	}
+	if filter.Sort != "" {
+		domain.SortTrackedItems(items, filter.Sort, filter.Reverse)
+	}
+
+	return items, nil
+}
+
+func (s *marketServiceImpl) GetTrackedItem(ctx context.Context, userID, itemID string) (*domain.TrackedItem, error) {
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	liquidity, err := s.liquidityRepo.Find(ctx, item.Key())
+	if err != nil && !errors.Is(err, out_ports.ErrNotFound) {
+		return nil, fmt.Errorf("find item liquidity: %w", err)
+	}
+	item.Liquidity = liquidity
+
+	return item, nil
+}
+
+// attachLiquidity - заполняет Liquidity у предметов, для которых метрики уже посчитаны
+func (s *marketServiceImpl) attachLiquidity(ctx context.Context, items []domain.TrackedItem) error {
+	keys := make([]domain.ItemKey, len(items))
+	for i := range items {
+		keys[i] = items[i].Key()
+	}
+
+	list, err := s.liquidityRepo.ListByKeys(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("list item liquidity: %w", err)
+	}
+
+	byKey := make(map[domain.ItemKey]*domain.ItemLiquidity, len(list))
+	for i := range list {
+		byKey[list[i].Key()] = &list[i]
+	}
+	for i := range items {
+		items[i].Liquidity = byKey[items[i].Key()]
+	}
 
-func NewMarketService() MarketService {
-	return &marketServiceImpl{}
+	return nil
 }
 
-func (s *marketServiceImpl) ListTrackedItems(ctx context.Context, userID string) ([]domain.TrackedItem, error) {
-	// Пока просто заглушка: один фейковый элемент с привязкой к userID
-	return []domain.TrackedItem{
-		{
-			ID:     "item-1",
-			Name:   "Stub AK-47 | Redline",
-			UserID: userID,
-		},
-	}, nil
+func (s *marketServiceImpl) CreateTrackedItem(ctx context.Context, userID string, input in_ports.CreateTrackedItemInput) (*domain.TrackedItem, error) {
+	item := domain.NewTrackedItem(userID, input.AppID, input.MarketHashName, input.Name, input.Notes)
+
+	// Валидируем до похода в БД, чтобы отдать клиенту понятную ошибку (400)
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+	if err := item.SetTags(input.Tags); err != nil {
+		return nil, err
+	}
+
+	// "stattrak ak-47|redline" и "StatTrak™ AK-47 | Redline" - один предмет:
+	// без нормализации пользователь мог бы отслеживать его дважды
+	canonical, err := s.catalog.Canonicalize(ctx, item.AppID, item.MarketHashName)
+	if err != nil {
+		return nil, err
+	}
+	if item.Name == item.MarketHashName {
+		item.Name = canonical
+	}
+	item.MarketHashName = canonical
+
+	if err := s.itemRepo.Create(ctx, item); err != nil {
+		return nil, fmt.Errorf("create tracked item: %w", err)
+	}
+
+	// Каталог - справочник: сбой записи карточки не отменяет отслеживание
+	if err := s.catalog.Register(ctx, domain.NewCatalogItem(item.AppID, item.MarketHashName, "")); err != nil {
+		s.logger.Warnf("register %q in catalog: %v", item.MarketHashName, err)
+	}
+
+	s.logger.Infof("tracked item created, id=%s, user_id=%s, item=%s", item.ID, userID, item.MarketHashName)
+
+	return item, nil
+}
+
+func (s *marketServiceImpl) UpdateTrackedItem(ctx context.Context, userID, itemID string, input in_ports.UpdateTrackedItemInput) (*domain.TrackedItem, error) {
+	item, err := s.itemRepo.FindByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("find tracked item: %w", err)
+	}
+
+	if input.Name != nil {
+		item.Rename(*input.Name)
+	}
+	if input.Notes != nil {
+		item.UpdateNotes(*input.Notes)
+	}
+	if input.Tags != nil {
+		if err := item.SetTags(*input.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.itemRepo.Update(ctx, item); err != nil {
+		return nil, fmt.Errorf("update tracked item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *marketServiceImpl) DeleteTrackedItem(ctx context.Context, userID, itemID string) error {
+	if err := s.itemRepo.Delete(ctx, userID, itemID); err != nil {
+		return fmt.Errorf("delete tracked item: %w", err)
+	}
+
+	s.logger.Infof("tracked item deleted, id=%s, user_id=%s", itemID, userID)
+
+	return nil
 }
@@ -0,0 +1,339 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// errStreamStopped - сервер останавливается, новые подписки не принимаются
+var errStreamStopped = errors.New("stream service is stopped")
+
+// StreamService - раздача цен и алертов открытым SSE соединениям
+// Реализует SnapshotObserver и FiredAlertObserver: подписывается на PricePoller и AlertService в DI
+type StreamService interface {
+	in_ports.StreamService
+	SnapshotObserver
+	FiredAlertObserver
+
+	// Close - закрывает все подписки (соединения завершаются, и HTTP сервер может остановиться)
+	Close()
+}
+
+// streamRecord - событие в буфере переподключения
+// Хранится в канонической валюте без получателей: кому и в какой валюте его отдать,
+// решает каждая подписка (так же при повторной отправке после Last-Event-ID)
+type streamRecord struct {
+	id       uint64
+	snapshot *domain.PriceSnapshot // Для StreamPrice
+	fired    *domain.FiredAlert    // Для StreamAlert
+}
+
+type streamServiceImpl struct {
+	cfg      config.StreamConfig
+	itemRepo out_ports.TrackedItemRepository
+	currency CurrencyConverter
+	logger   logger.Logger
+
+	mu          sync.Mutex
+	nextID      uint64
+	history     []streamRecord // Кольцевой буфер последних событий
+	historyHead int            // Индекс самого старого события в history
+	historyLen  int
+	subscribers map[*streamSubscription]struct{}
+	perUser     map[string]int
+	closed      bool
+}
+
+// NewStreamService - создаёт раздачу событий
+//
+// Backpressure: у каждого соединения свой буфер на cfg.ClientBuffer событий.
+// Публикация в него не блокируется; если буфер полон, клиент не успевает читать -
+// подписка закрывается, а клиент переподключается с Last-Event-ID и дочитывает
+// пропущенное из общего буфера на cfg.ReplayBuffer событий. Так медленный клиент
+// не тормозит poller и не теряет события молча.
+func NewStreamService(
+	cfg config.StreamConfig,
+	itemRepo out_ports.TrackedItemRepository,
+	currency CurrencyConverter,
+	log logger.Logger,
+) StreamService {
+	cfg.ClientBuffer = max(cfg.ClientBuffer, 1)
+	cfg.ReplayBuffer = max(cfg.ReplayBuffer, 1)
+	cfg.MaxConnectionsPerUser = max(cfg.MaxConnectionsPerUser, 1)
+	if cfg.ItemsRefresh <= 0 {
+		cfg.ItemsRefresh = time.Minute
+	}
+
+	return &streamServiceImpl{
+		cfg:      cfg,
+		itemRepo: itemRepo,
+		currency: currency,
+		logger:   log,
+		// ID продолжают расти после рестарта: Last-Event-ID от прошлого процесса
+		// заведомо меньше новых ID и распознаётся как разрыв (StreamResync).
+		// Миллион событий в секунду мы не публикуем, так что ID не догонят часы.
+		nextID:      uint64(time.Now().UnixMicro()),
+		history:     make([]streamRecord, cfg.ReplayBuffer),
+		subscribers: make(map[*streamSubscription]struct{}),
+		perUser:     make(map[string]int),
+	}
+}
+
+func (s *streamServiceImpl) Subscribe(ctx context.Context, userID string, opts in_ports.StreamOptions) (in_ports.StreamSubscription, error) {
+	currency := opts.Currency
+	if currency == 0 {
+		var err error
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.loadItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, errStreamStopped
+	}
+	if s.perUser[userID] >= s.cfg.MaxConnectionsPerUser {
+		return nil, domain.ErrTooManyStreams
+	}
+
+	sub := &streamSubscription{
+		service:  s,
+		userID:   userID,
+		currency: currency,
+		items:    items,
+		done:     make(chan struct{}),
+	}
+
+	var replay []domain.StreamEvent
+	if opts.LastEventID != 0 {
+		replay = s.replayLocked(sub, opts.LastEventID)
+	}
+
+	// Пропущенные события не должны сразу переполнить буфер
+	sub.events = make(chan domain.StreamEvent, s.cfg.ClientBuffer+len(replay))
+	for _, event := range replay {
+		sub.events <- event
+	}
+
+	s.subscribers[sub] = struct{}{}
+	s.perUser[userID]++
+
+	go sub.refreshItems(ctx, s.cfg.ItemsRefresh)
+
+	return sub, nil
+}
+
+// OnPriceSnapshot - новая цена: всем подпискам, чей пользователь следит за предметом
+func (s *streamServiceImpl) OnPriceSnapshot(_ context.Context, snapshot *domain.PriceSnapshot) {
+	snapshotCopy := *snapshot
+	s.publish(streamRecord{snapshot: &snapshotCopy})
+}
+
+// OnFiredAlert - срабатывание: подпискам владельца правила
+func (s *streamServiceImpl) OnFiredAlert(_ context.Context, fired *domain.FiredAlert) {
+	firedCopy := *fired
+	s.publish(streamRecord{fired: &firedCopy})
+}
+
+func (s *streamServiceImpl) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		s.dropLocked(sub)
+	}
+}
+
+// publish - запоминает событие для переподключений и раздаёт его подпискам
+func (s *streamServiceImpl) publish(record streamRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	record.id = s.nextID
+	s.nextID++
+	s.rememberLocked(record)
+
+	for sub := range s.subscribers {
+		event, ok := s.eventFor(sub, &record)
+		if !ok {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			s.logger.Warnf("stream client is too slow, closing subscription, user_id=%s", sub.userID)
+			s.dropLocked(sub)
+		}
+	}
+}
+
+// rememberLocked - добавляет событие в кольцевой буфер, вытесняя самое старое
+func (s *streamServiceImpl) rememberLocked(record streamRecord) {
+	size := len(s.history)
+	if s.historyLen < size {
+		s.history[(s.historyHead+s.historyLen)%size] = record
+		s.historyLen++
+		return
+	}
+	s.history[s.historyHead] = record
+	s.historyHead = (s.historyHead + 1) % size
+}
+
+// replayLocked - события подписки после lastEventID
+// Если часть из них уже вытеснена из буфера (или ID из другого процесса) - одно событие StreamResync
+func (s *streamServiceImpl) replayLocked(sub *streamSubscription, lastEventID uint64) []domain.StreamEvent {
+	latest := s.nextID - 1
+	oldest := s.nextID - uint64(s.historyLen)
+	if lastEventID > latest || lastEventID+1 < oldest {
+		return []domain.StreamEvent{{ID: latest, Type: domain.StreamResync, Data: struct{}{}}}
+	}
+
+	var events []domain.StreamEvent
+	size := len(s.history)
+	for i := int(lastEventID + 1 - oldest); i < s.historyLen; i++ {
+		if event, ok := s.eventFor(sub, &s.history[(s.historyHead+i)%size]); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// eventFor - событие для конкретной подписки: фильтр по пользователю и перевод в её валюту
+// Если курса нет, цена уходит в исходной валюте - поле currency в событии это отражает
+func (s *streamServiceImpl) eventFor(sub *streamSubscription, record *streamRecord) (domain.StreamEvent, bool) {
+	switch {
+	case record.snapshot != nil:
+		itemID, ok := sub.items[record.snapshot.Key()]
+		if !ok {
+			return domain.StreamEvent{}, false
+		}
+
+		converted := []domain.PriceSnapshot{*record.snapshot}
+		if err := convertSnapshots(s.currency, converted, sub.currency); err != nil {
+			converted[0] = *record.snapshot
+		}
+		return domain.StreamEvent{
+			ID:   record.id,
+			Type: domain.StreamPrice,
+			Data: domain.NewPriceUpdate(itemID, &converted[0]),
+		}, true
+
+	case record.fired != nil:
+		if record.fired.UserID != sub.userID {
+			return domain.StreamEvent{}, false
+		}
+
+		fired := *record.fired
+		if fired.Currency != sub.currency {
+			if rates, err := s.currency.Rates(); err == nil {
+				if converted, err := rates.ConvertFiredAlert(fired, sub.currency); err == nil {
+					fired = converted
+				}
+			}
+		}
+		return domain.StreamEvent{ID: record.id, Type: domain.StreamAlert, Data: fired}, true
+	}
+
+	return domain.StreamEvent{}, false
+}
+
+// dropLocked - убирает подписку и закрывает её канал (соединение завершится)
+func (s *streamServiceImpl) dropLocked(sub *streamSubscription) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	if s.perUser[sub.userID]--; s.perUser[sub.userID] == 0 {
+		delete(s.perUser, sub.userID)
+	}
+	close(sub.events)
+	close(sub.done)
+}
+
+// loadItems - предметы пользователя по ключу (для фильтра цен)
+func (s *streamServiceImpl) loadItems(ctx context.Context, userID string) (map[domain.ItemKey]string, error) {
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+
+	byKey := make(map[domain.ItemKey]string, len(items))
+	for i := range items {
+		byKey[items[i].Key()] = items[i].ID
+	}
+	return byKey, nil
+}
+
+// streamSubscription - подписка одного соединения
+// items защищены service.mu: их читает publish, а обновляет refreshItems
+type streamSubscription struct {
+	service  *streamServiceImpl
+	userID   string
+	currency domain.Currency
+	items    map[domain.ItemKey]string // Ключ предмета → tracked_item_id
+	events   chan domain.StreamEvent
+	done     chan struct{} // Закрывается вместе с events
+}
+
+func (sub *streamSubscription) Events() <-chan domain.StreamEvent {
+	return sub.events
+}
+
+func (sub *streamSubscription) Close() {
+	sub.service.mu.Lock()
+	defer sub.service.mu.Unlock()
+
+	sub.service.dropLocked(sub)
+}
+
+// refreshItems - периодически перечитывает предметы пользователя,
+// чтобы добавленный во время соединения предмет тоже попадал в поток
+func (sub *streamSubscription) refreshItems(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.done:
+			return
+		case <-ticker.C:
+		}
+
+		items, err := sub.service.loadItems(ctx, sub.userID)
+		if err != nil {
+			if ctx.Err() == nil {
+				sub.service.logger.Warnf("refresh stream items, user_id=%s: %v", sub.userID, err)
+			}
+			continue
+		}
+
+		sub.service.mu.Lock()
+		sub.items = items
+		sub.service.mu.Unlock()
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+)
+
+type TradeUpService interface {
+	in_ports.TradeUpService
+}
+
+type tradeUpServiceImpl struct {
+	gameData out_ports.GameData
+	sources  []out_ports.PriceSource
+	currency CurrencyConverter
+}
+
+// NewTradeUpService - контракты обмена по коллекциям справочника gameData
+// Цены Steam, как и для кейсов, - последние снимки poller'а
+func NewTradeUpService(
+	gameData out_ports.GameData,
+	snapshots out_ports.PriceSnapshotRepository,
+	sources []out_ports.PriceSource,
+	currency CurrencyConverter,
+) TradeUpService {
+	all := append([]out_ports.PriceSource{&snapshotPriceSource{snapshots: snapshots}}, sources...)
+
+	return &tradeUpServiceImpl{
+		gameData: gameData,
+		sources:  all,
+		currency: currency,
+	}
+}
+
+func (s *tradeUpServiceImpl) ListCollections(context.Context) []domain.Collection {
+	return s.gameData.Collections()
+}
+
+func (s *tradeUpServiceImpl) EvaluateTradeUp(ctx context.Context, userID string, input in_ports.EvaluateTradeUpInput) (*domain.TradeUp, error) {
+	source, err := selectPriceSource(s.sources, input.Venue)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.resolveSlots(input.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outcomes, err := domain.TradeUpOutcomes(slots)
+	if err != nil {
+		return nil, err
+	}
+
+	var currency domain.Currency
+	if input.Currency != nil {
+		currency = *input.Currency
+	} else if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.ItemKey, 0, len(slots)+len(outcomes))
+	for i := range slots {
+		keys = append(keys, domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: slots[i].Item.MarketHashName})
+	}
+	for i := range outcomes {
+		keys = append(keys, domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: outcomes[i].MarketHashName})
+	}
+
+	prices, err := fetchPrices(ctx, source, s.currency, keys, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeUp := domain.EvaluateTradeUp(slots, outcomes, prices,
+		func(gross int64) int64 { return source.SellerReceives(domain.AppIDCS2, gross) })
+	tradeUp.Venue = source.Name()
+	tradeUp.Currency = currency
+
+	return tradeUp, nil
+}
+
+// resolveSlots - находит скины входов в справочнике; имена нормализуются
+func (s *tradeUpServiceImpl) resolveSlots(items []domain.TradeUpItem) ([]domain.TradeUpSlot, error) {
+	slots := make([]domain.TradeUpSlot, len(items))
+	for i, item := range items {
+		if item.Price != nil && *item.Price < 0 {
+			return nil, fmt.Errorf("%w: price of %q must not be negative", domain.ErrValidation, item.MarketHashName)
+		}
+
+		parsed := domain.ParseMarketHashName(item.MarketHashName)
+		skin, collection, err := s.gameData.FindSkin(parsed.SkinName())
+		if errors.Is(err, out_ports.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %q is not in any known collection", domain.ErrValidation, item.MarketHashName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find skin: %w", err)
+		}
+
+		item.MarketHashName = parsed.MarketHashName
+		slots[i] = domain.TradeUpSlot{
+			Item:       item,
+			Skin:       skin,
+			Collection: collection,
+			StatTrak:   parsed.StatTrak,
+			Souvenir:   parsed.Souvenir,
+		}
+	}
+	return slots, nil
+}
@@ -0,0 +1,632 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+type TransferService interface {
+	in_ports.TransferService
+}
+
+type transferServiceImpl struct {
+	itemRepo  out_ports.TrackedItemRepository
+	lotRepo   out_ports.LotRepository
+	alertRepo out_ports.AlertRepository
+	catalog   CatalogRegistry
+	currency  CurrencyConverter
+	logger    logger.Logger
+}
+
+// NewTransferService - выгрузка и загрузка предметов, сделок и алертов
+//
+// Импорт не транзакционный: корректные записи сохраняются, ошибочные попадают в отчёт.
+// Поэтому сначала стоит прогнать dry_run и посмотреть отчёт.
+// Существующая запись определяется так:
+//   - предмет - по (app_id, market_hash_name) после нормализации имени;
+//   - сделка и алерт - по id из выгрузки, а без него - по совпадению ключевых полей
+//     (повторная загрузка той же таблицы не задваивает записи).
+func NewTransferService(
+	itemRepo out_ports.TrackedItemRepository,
+	lotRepo out_ports.LotRepository,
+	alertRepo out_ports.AlertRepository,
+	catalog CatalogRegistry,
+	currency CurrencyConverter,
+	log logger.Logger,
+) TransferService {
+	return &transferServiceImpl{
+		itemRepo:  itemRepo,
+		lotRepo:   lotRepo,
+		alertRepo: alertRepo,
+		catalog:   catalog,
+		currency:  currency,
+		logger:    log,
+	}
+}
+
+func (s *transferServiceImpl) Export(ctx context.Context, userID string) (*domain.DataExport, error) {
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+	lots, err := s.lotRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list lots: %w", err)
+	}
+	rules, err := s.alertRepo.ListRulesByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert rules: %w", err)
+	}
+
+	export := &domain.DataExport{
+		Version:    domain.ExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Items:      make([]domain.ItemRecord, 0, len(items)),
+		Lots:       make([]domain.LotRecord, 0, len(lots)),
+		Alerts:     make([]domain.AlertRecord, 0, len(rules)),
+	}
+
+	byID := make(map[string]*domain.TrackedItem, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+		export.Items = append(export.Items, domain.ItemRecord{
+			AppID:          items[i].AppID,
+			MarketHashName: items[i].MarketHashName,
+			Name:           items[i].Name,
+			Notes:          items[i].Notes,
+			Tags:           items[i].Tags,
+		})
+	}
+
+	for _, lot := range lots {
+		item, ok := byID[lot.TrackedItemID]
+		if !ok {
+			continue
+		}
+		export.Lots = append(export.Lots, domain.LotRecord{
+			ID:             lot.ID,
+			AppID:          item.AppID,
+			MarketHashName: item.MarketHashName,
+			Side:           lot.Side,
+			Quantity:       lot.Quantity,
+			UnitPrice:      lot.UnitPrice,
+			Fee:            lot.Fee,
+			Currency:       lot.Currency,
+			ExecutedAt:     lot.ExecutedAt,
+			Notes:          lot.Notes,
+		})
+	}
+
+	for _, rule := range rules {
+		item, ok := byID[rule.TrackedItemID]
+		if !ok {
+			continue
+		}
+		export.Alerts = append(export.Alerts, domain.AlertRecord{
+			ID:              rule.ID,
+			AppID:           item.AppID,
+			MarketHashName:  item.MarketHashName,
+			Type:            rule.Type,
+			Threshold:       rule.Threshold,
+			Currency:        &rule.Currency,
+			WindowSeconds:   rule.WindowSeconds,
+			Mode:            rule.Mode,
+			CooldownSeconds: &rule.CooldownSeconds,
+			Enabled:         &rule.Enabled,
+		})
+	}
+
+	return export, nil
+}
+
+func (s *transferServiceImpl) Import(ctx context.Context, userID string, data domain.DataExport, opts in_ports.ImportOptions) (*domain.ImportReport, error) {
+	if data.Size() > domain.MaxImportRecords {
+		return nil, fmt.Errorf("%w: at most %d records per import", domain.ErrValidation, domain.MaxImportRecords)
+	}
+	if opts.Mode == "" {
+		opts.Mode = domain.ImportSkip
+	}
+
+	items, err := s.itemRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked items: %w", err)
+	}
+	tracked := make(map[domain.ItemKey]*domain.TrackedItem, len(items))
+	for i := range items {
+		tracked[items[i].Key()] = &items[i]
+	}
+
+	report := &domain.ImportReport{DryRun: opts.DryRun, Mode: opts.Mode, Rows: []domain.ImportRowResult{}}
+
+	if err := s.importItems(ctx, userID, data.Items, opts, tracked, report); err != nil {
+		return nil, err
+	}
+	if err := s.importLots(ctx, userID, data.Lots, opts, tracked, report); err != nil {
+		return nil, err
+	}
+	if err := s.importAlerts(ctx, userID, data.Alerts, opts, tracked, report); err != nil {
+		return nil, err
+	}
+
+	if !opts.DryRun {
+		s.logger.Infof("import finished, user_id=%s, mode=%s, created=%d, updated=%d, skipped=%d, failed=%d",
+			userID, opts.Mode, report.Created, report.Updated, report.Skipped, report.Failed)
+	}
+
+	return report, nil
+}
+
+// importItems - предметы; tracked пополняется созданными, чтобы на них могли ссылаться сделки и алерты
+func (s *transferServiceImpl) importItems(
+	ctx context.Context,
+	userID string,
+	records []domain.ItemRecord,
+	opts in_ports.ImportOptions,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	report *domain.ImportReport,
+) error {
+	for i, rec := range records {
+		row := domain.ImportRowResult{Kind: domain.RecordItem, Row: i + 1, MarketHashName: rec.MarketHashName}
+
+		row, err := s.importItem(ctx, userID, rec, opts, tracked, row)
+		if err != nil {
+			if row, err = rowFailed(row, err); err != nil {
+				return err
+			}
+		}
+		report.Add(row)
+	}
+	return nil
+}
+
+func (s *transferServiceImpl) importItem(
+	ctx context.Context,
+	userID string,
+	rec domain.ItemRecord,
+	opts in_ports.ImportOptions,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	row domain.ImportRowResult,
+) (domain.ImportRowResult, error) {
+	key, err := s.resolveKey(ctx, rec.AppID, rec.MarketHashName)
+	if err != nil {
+		return row, err
+	}
+	row.MarketHashName = key.MarketHashName
+
+	tags, err := domain.NormalizeTags(rec.Tags)
+	if err != nil {
+		return row, err
+	}
+
+	existing, ok := tracked[key]
+	if !ok {
+		item := domain.NewTrackedItem(userID, key.AppID, key.MarketHashName, rec.Name, rec.Notes)
+		item.Tags = tags
+		if err := item.Validate(); err != nil {
+			return row, err
+		}
+
+		if !opts.DryRun {
+			if err := s.itemRepo.Create(ctx, item); err != nil {
+				return row, fmt.Errorf("create tracked item: %w", err)
+			}
+			if err := s.catalog.Register(ctx, domain.NewCatalogItem(item.AppID, item.MarketHashName, "")); err != nil {
+				s.logger.Warnf("register %q in catalog: %v", item.MarketHashName, err)
+			}
+		}
+
+		tracked[key] = item
+		row.Action, row.ID = domain.ImportActionCreate, item.ID
+		return row, nil
+	}
+
+	row.ID = existing.ID
+	switch opts.Mode {
+	case domain.ImportSkip:
+		row.Action = domain.ImportActionSkip
+		return row, nil
+	case domain.ImportOverwrite:
+		existing.Rename(rec.Name)
+		existing.UpdateNotes(rec.Notes)
+		existing.Tags = tags
+	case domain.ImportMerge:
+		merged, err := domain.NormalizeTags(domain.MergeTags(existing.Tags, tags))
+		if err != nil {
+			return row, err
+		}
+		if strings.TrimSpace(rec.Name) != "" {
+			existing.Rename(rec.Name)
+		}
+		if strings.TrimSpace(rec.Notes) != "" {
+			existing.UpdateNotes(rec.Notes)
+		}
+		existing.Tags = merged
+	}
+
+	if !opts.DryRun {
+		if err := s.itemRepo.Update(ctx, existing); err != nil {
+			return row, fmt.Errorf("update tracked item: %w", err)
+		}
+	}
+
+	row.Action = domain.ImportActionUpdate
+	return row, nil
+}
+
+// plannedLot - сделка, которую импорт создаст или обновит после проверки позиции
+type plannedLot struct {
+	index int
+	lot   domain.Lot
+}
+
+// importLots - сделки
+// Продажи не должны превышать позицию: проверяется итоговый набор сделок каждого предмета
+// (существующие + импортируемые), и если он некорректен, ни одна сделка предмета не сохраняется
+func (s *transferServiceImpl) importLots(
+	ctx context.Context,
+	userID string,
+	records []domain.LotRecord,
+	opts in_ports.ImportOptions,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	report *domain.ImportReport,
+) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	existing, err := s.lotRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list lots: %w", err)
+	}
+	byItem := make(map[string][]domain.Lot)
+	itemOfLot := make(map[string]string, len(existing))
+	for _, lot := range existing {
+		byItem[lot.TrackedItemID] = append(byItem[lot.TrackedItemID], lot)
+		itemOfLot[lot.ID] = lot.TrackedItemID
+	}
+
+	rows := make([]domain.ImportRowResult, len(records))
+	planned := make(map[string][]plannedLot)
+	var itemOrder []string
+
+	for i, rec := range records {
+		row := domain.ImportRowResult{Kind: domain.RecordLot, Row: i + 1, MarketHashName: rec.MarketHashName}
+
+		lot, match, err := s.planLot(ctx, userID, rec, tracked, byItem, itemOfLot)
+		if err != nil {
+			if rows[i], err = rowFailed(row, err); err != nil {
+				return err
+			}
+			continue
+		}
+		current := byItem[lot.TrackedItemID]
+
+		if match < 0 {
+			row.Action, row.ID = domain.ImportActionCreate, lot.ID
+			current = append(current, *lot)
+		} else {
+			row.ID = current[match].ID
+			switch opts.Mode {
+			case domain.ImportSkip:
+				row.Action = domain.ImportActionSkip
+				rows[i] = row
+				continue
+			case domain.ImportOverwrite:
+				lot.ID, lot.CreatedAt = current[match].ID, current[match].CreatedAt
+			case domain.ImportMerge:
+				// Обязательные поля сделки берутся из импорта, необязательные - только заполненные
+				lot.ID, lot.CreatedAt = current[match].ID, current[match].CreatedAt
+				if rec.Fee == 0 {
+					lot.Fee = current[match].Fee
+				}
+				if strings.TrimSpace(rec.Notes) == "" {
+					lot.Notes = current[match].Notes
+				}
+			}
+			row.Action = domain.ImportActionUpdate
+			current[match] = *lot
+		}
+
+		rows[i] = row
+		byItem[lot.TrackedItemID] = current
+		if _, ok := planned[lot.TrackedItemID]; !ok {
+			itemOrder = append(itemOrder, lot.TrackedItemID)
+		}
+		planned[lot.TrackedItemID] = append(planned[lot.TrackedItemID], plannedLot{index: i, lot: *lot})
+	}
+
+	for _, itemID := range itemOrder {
+		if err := domain.ValidateLotSequence(byItem[itemID]); err != nil {
+			for _, p := range planned[itemID] {
+				rows[p.index].Action = domain.ImportActionError
+				rows[p.index].Error = err.Error()
+			}
+			continue
+		}
+
+		if opts.DryRun {
+			continue
+		}
+		for _, p := range planned[itemID] {
+			lot := p.lot
+			if rows[p.index].Action == domain.ImportActionCreate {
+				err = s.lotRepo.Create(ctx, &lot)
+			} else {
+				err = s.lotRepo.Update(ctx, &lot)
+			}
+			if err != nil {
+				return fmt.Errorf("save lot: %w", err)
+			}
+		}
+	}
+
+	for _, row := range rows {
+		report.Add(row)
+	}
+	return nil
+}
+
+// planLot - сделка из записи и индекс совпавшей существующей сделки предмета (-1 - новая)
+func (s *transferServiceImpl) planLot(
+	ctx context.Context,
+	userID string,
+	rec domain.LotRecord,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	byItem map[string][]domain.Lot,
+	itemOfLot map[string]string,
+) (*domain.Lot, int, error) {
+	item, err := s.findTracked(ctx, rec.AppID, rec.MarketHashName, tracked)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	currency := rec.Currency
+	if currency == 0 {
+		if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	lot := domain.NewLot(userID, item.ID, rec.Side, rec.Quantity, rec.UnitPrice, rec.Fee, currency, rec.ExecutedAt, rec.Notes)
+	if err := lot.Validate(); err != nil {
+		return nil, -1, err
+	}
+
+	// Предмет сделки не меняется: сделка из выгрузки не может переехать на другой предмет
+	if rec.ID != "" {
+		if itemID, ok := itemOfLot[rec.ID]; ok && itemID != item.ID {
+			return nil, -1, fmt.Errorf("%w: lot %s belongs to another item", domain.ErrValidation, rec.ID)
+		}
+	}
+
+	current := byItem[item.ID]
+	for i := range current {
+		if rec.ID != "" && current[i].ID == rec.ID {
+			return lot, i, nil
+		}
+	}
+	for i := range current {
+		c := &current[i]
+		if c.Side == lot.Side && c.Quantity == lot.Quantity && c.UnitPrice == lot.UnitPrice &&
+			c.Currency == lot.Currency && c.ExecutedAt.Equal(lot.ExecutedAt) {
+			return lot, i, nil
+		}
+	}
+	return lot, -1, nil
+}
+
+// importAlerts - правила алертов
+func (s *transferServiceImpl) importAlerts(
+	ctx context.Context,
+	userID string,
+	records []domain.AlertRecord,
+	opts in_ports.ImportOptions,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	report *domain.ImportReport,
+) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	existing, err := s.alertRepo.ListRulesByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list alert rules: %w", err)
+	}
+	rules := make([]*domain.AlertRule, len(existing))
+	for i := range existing {
+		rules[i] = &existing[i]
+	}
+
+	for i, rec := range records {
+		row := domain.ImportRowResult{Kind: domain.RecordAlert, Row: i + 1, MarketHashName: rec.MarketHashName}
+
+		row, created, err := s.importAlert(ctx, userID, rec, opts, tracked, rules, row)
+		if err != nil {
+			if row, err = rowFailed(row, err); err != nil {
+				return err
+			}
+		}
+		if created != nil {
+			rules = append(rules, created)
+		}
+		report.Add(row)
+	}
+	return nil
+}
+
+// importAlert - одно правило; created - новое правило (для поиска совпадений следующими записями)
+func (s *transferServiceImpl) importAlert(
+	ctx context.Context,
+	userID string,
+	rec domain.AlertRecord,
+	opts in_ports.ImportOptions,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+	rules []*domain.AlertRule,
+	row domain.ImportRowResult,
+) (domain.ImportRowResult, *domain.AlertRule, error) {
+	item, err := s.findTracked(ctx, rec.AppID, rec.MarketHashName, tracked)
+	if err != nil {
+		return row, nil, err
+	}
+	row.MarketHashName = item.MarketHashName
+
+	var currency domain.Currency
+	if rec.Currency != nil {
+		currency = *rec.Currency
+	} else if currency, err = s.currency.DisplayCurrency(ctx, userID); err != nil {
+		return row, nil, err
+	}
+	cooldown := int64(-1) // → domain.DefaultAlertCooldown
+	if rec.CooldownSeconds != nil {
+		cooldown = *rec.CooldownSeconds
+	}
+
+	rule := domain.NewAlertRule(userID, item.ID, rec.Type, rec.Threshold, currency, rec.WindowSeconds, rec.Mode, cooldown)
+	if rec.Enabled != nil {
+		rule.Enabled = *rec.Enabled
+	}
+	if err := rule.Validate(); err != nil {
+		return row, nil, err
+	}
+
+	match, err := findAlertRule(rules, rec.ID, rule)
+	if err != nil {
+		return row, nil, err
+	}
+
+	if match == nil {
+		if !opts.DryRun {
+			if err := s.alertRepo.CreateRule(ctx, rule); err != nil {
+				return row, nil, fmt.Errorf("create alert rule: %w", err)
+			}
+		}
+		row.Action, row.ID = domain.ImportActionCreate, rule.ID
+		return row, rule, nil
+	}
+
+	row.ID = match.ID
+	updated := *match
+	switch opts.Mode {
+	case domain.ImportSkip:
+		row.Action = domain.ImportActionSkip
+		return row, nil, nil
+	case domain.ImportOverwrite:
+		updated.Threshold = rule.Threshold
+		updated.Currency = rule.Currency
+		updated.WindowSeconds = rule.WindowSeconds
+		updated.Mode = rule.Mode
+		updated.CooldownSeconds = rule.CooldownSeconds
+		updated.Enabled = rule.Enabled
+	case domain.ImportMerge:
+		if rec.Threshold != 0 {
+			updated.Threshold = rec.Threshold
+		}
+		if rec.Currency != nil {
+			updated.Currency = *rec.Currency
+		}
+		if rec.WindowSeconds != 0 {
+			updated.WindowSeconds = rec.WindowSeconds
+		}
+		if rec.Mode != "" {
+			updated.Mode = rec.Mode
+		}
+		if rec.CooldownSeconds != nil {
+			updated.CooldownSeconds = *rec.CooldownSeconds
+		}
+		if rec.Enabled != nil {
+			updated.Enabled = *rec.Enabled
+		}
+	}
+	if err := updated.Validate(); err != nil {
+		return row, nil, err
+	}
+
+	if !opts.DryRun {
+		if err := s.alertRepo.UpdateRule(ctx, &updated); err != nil {
+			return row, nil, fmt.Errorf("update alert rule: %w", err)
+		}
+	}
+	*match = updated
+
+	row.Action = domain.ImportActionUpdate
+	return row, nil, nil
+}
+
+// findAlertRule - существующее правило для записи: по id, а без него - по предмету, типу и порогу
+func findAlertRule(rules []*domain.AlertRule, id string, rule *domain.AlertRule) (*domain.AlertRule, error) {
+	if id != "" {
+		for _, r := range rules {
+			if r.ID != id {
+				continue
+			}
+			// Тип и предмет правила не меняются - это уже другое правило
+			if r.TrackedItemID != rule.TrackedItemID || r.Type != rule.Type {
+				return nil, fmt.Errorf("%w: alert %s has a different item or type", domain.ErrValidation, id)
+			}
+			return r, nil
+		}
+	}
+
+	for _, r := range rules {
+		if r.TrackedItemID == rule.TrackedItemID && r.Type == rule.Type && r.Threshold == rule.Threshold &&
+			r.Currency == rule.Currency && r.WindowSeconds == rule.WindowSeconds {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveKey - ключ предмета из записи: app_id по умолчанию CS2, имя - как при добавлении вручную
+func (s *transferServiceImpl) resolveKey(ctx context.Context, appID int, marketHashName string) (domain.ItemKey, error) {
+	if appID == 0 {
+		appID = domain.AppIDCS2
+	}
+	if appID < 0 {
+		return domain.ItemKey{}, domain.ErrInvalidAppID
+	}
+	if strings.TrimSpace(marketHashName) == "" {
+		return domain.ItemKey{}, domain.ErrEmptyMarketHashName
+	}
+
+	canonical, err := s.catalog.Canonicalize(ctx, appID, marketHashName)
+	if err != nil {
+		return domain.ItemKey{}, err
+	}
+	return domain.ItemKey{AppID: appID, MarketHashName: canonical}, nil
+}
+
+// findTracked - отслеживаемый предмет, на который ссылается запись сделки или алерта
+func (s *transferServiceImpl) findTracked(
+	ctx context.Context,
+	appID int,
+	marketHashName string,
+	tracked map[domain.ItemKey]*domain.TrackedItem,
+) (*domain.TrackedItem, error) {
+	key, err := s.resolveKey(ctx, appID, marketHashName)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := tracked[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not tracked, import it as an item first", domain.ErrValidation, key.MarketHashName)
+	}
+	return item, nil
+}
+
+// rowFailed - ошибка валидации записи уходит в отчёт; прочие (БД) прерывают импорт
+func rowFailed(row domain.ImportRowResult, err error) (domain.ImportRowResult, error) {
+	if !errors.Is(err, domain.ErrValidation) {
+		return row, err
+	}
+	row.Action = domain.ImportActionError
+	row.Error = err.Error()
+	return row, nil
+}
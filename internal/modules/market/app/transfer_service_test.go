@@ -0,0 +1,226 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+// fakeLotRepo - сделки в памяти; сохранённые сделки копятся в created и updated
+type fakeLotRepo struct {
+	out_ports.LotRepository
+	lots    []domain.Lot
+	created []domain.Lot
+	updated []domain.Lot
+}
+
+func (r *fakeLotRepo) ListByUser(context.Context, string) ([]domain.Lot, error) {
+	return append([]domain.Lot(nil), r.lots...), nil
+}
+
+func (r *fakeLotRepo) Create(_ context.Context, lot *domain.Lot) error {
+	r.created = append(r.created, *lot)
+	return nil
+}
+
+func (r *fakeLotRepo) Update(_ context.Context, lot *domain.Lot) error {
+	r.updated = append(r.updated, *lot)
+	return nil
+}
+
+// fakeCatalog - имена уже канонические
+type fakeCatalog struct{}
+
+func (fakeCatalog) Canonicalize(_ context.Context, _ int, name string) (string, error) {
+	return strings.TrimSpace(name), nil
+}
+
+func (fakeCatalog) Register(context.Context, *domain.CatalogItem) error { return nil }
+
+// fakeCurrency - валюта пользователя USD
+type fakeCurrency struct {
+	CurrencyConverter
+}
+
+func (fakeCurrency) DisplayCurrency(context.Context, string) (domain.Currency, error) {
+	return domain.CurrencyUSD, nil
+}
+
+const transferUser = "user-1"
+
+func newTransferTest(lots ...domain.Lot) (*transferServiceImpl, *fakeLotRepo, map[domain.ItemKey]*domain.TrackedItem) {
+	repo := &fakeLotRepo{lots: lots}
+	service := &transferServiceImpl{
+		lotRepo:  repo,
+		catalog:  fakeCatalog{},
+		currency: fakeCurrency{},
+		logger:   logger.NewNopLogger(),
+	}
+
+	tracked := make(map[domain.ItemKey]*domain.TrackedItem)
+	for _, name := range []string{"AK-47 | Redline (Field-Tested)", "Recoil Case"} {
+		item := domain.NewTrackedItem(transferUser, domain.AppIDCS2, name, "", "")
+		tracked[item.Key()] = item
+	}
+	return service, repo, tracked
+}
+
+func trackedID(tracked map[domain.ItemKey]*domain.TrackedItem, name string) string {
+	return tracked[domain.ItemKey{AppID: domain.AppIDCS2, MarketHashName: name}].ID
+}
+
+var lotDay = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func lotRecord(name string, side domain.LotSide, quantity int64, days int) domain.LotRecord {
+	return domain.LotRecord{
+		MarketHashName: name,
+		Side:           side,
+		Quantity:       quantity,
+		UnitPrice:      100,
+		Currency:       domain.CurrencyUSD,
+		ExecutedAt:     lotDay.AddDate(0, 0, days),
+	}
+}
+
+func TestImportLotsRejectsOversoldItem(t *testing.T) {
+	service, repo, tracked := newTransferTest()
+
+	records := []domain.LotRecord{
+		lotRecord("AK-47 | Redline (Field-Tested)", domain.LotBuy, 2, 0),
+		lotRecord("Recoil Case", domain.LotBuy, 1, 0),
+		lotRecord("AK-47 | Redline (Field-Tested)", domain.LotSell, 3, 1), // Продаём больше, чем купили
+	}
+
+	report := &domain.ImportReport{}
+	if err := service.importLots(context.Background(), transferUser, records, in_ports.ImportOptions{Mode: domain.ImportSkip}, tracked, report); err != nil {
+		t.Fatalf("importLots: %v", err)
+	}
+
+	wantActions := []string{domain.ImportActionError, domain.ImportActionCreate, domain.ImportActionError}
+	for i, row := range report.Rows {
+		if row.Action != wantActions[i] {
+			t.Errorf("row %d: action %q, want %q (%s)", row.Row, row.Action, wantActions[i], row.Error)
+		}
+	}
+	if report.Created != 1 || report.Failed != 2 {
+		t.Errorf("created %d, failed %d; want 1, 2", report.Created, report.Failed)
+	}
+
+	// Сохранена только сделка по кейсу: у AK-47 не сохраняется ни одна
+	if len(repo.created) != 1 || repo.created[0].TrackedItemID != trackedID(tracked, "Recoil Case") {
+		t.Errorf("created lots = %+v, want only the case purchase", repo.created)
+	}
+}
+
+func TestImportLotsExistingLot(t *testing.T) {
+	existingCreated := lotDay.Add(-time.Hour)
+
+	tests := []struct {
+		mode      domain.ImportMode
+		wantFee   int64
+		wantNotes string
+	}{
+		{domain.ImportOverwrite, 0, ""},
+		{domain.ImportMerge, 7, "bought on a sale"},
+	}
+
+	for _, tt := range tests {
+		service, repo, tracked := newTransferTest()
+		existing := domain.NewLot(transferUser, trackedID(tracked, "Recoil Case"), domain.LotBuy, 5, 100, 7,
+			domain.CurrencyUSD, lotDay, "bought on a sale")
+		existing.CreatedAt = existingCreated
+		repo.lots = []domain.Lot{*existing}
+
+		// Та же сделка, но без id, комиссии и заметки - совпадает по ключевым полям
+		rec := lotRecord("Recoil Case", domain.LotBuy, 5, 0)
+
+		report := &domain.ImportReport{}
+		if err := service.importLots(context.Background(), transferUser, []domain.LotRecord{rec}, in_ports.ImportOptions{Mode: tt.mode}, tracked, report); err != nil {
+			t.Fatalf("%s: importLots: %v", tt.mode, err)
+		}
+
+		if report.Updated != 1 || len(repo.updated) != 1 || len(repo.created) != 0 {
+			t.Fatalf("%s: report %+v, created %d, updated %d", tt.mode, report, len(repo.created), len(repo.updated))
+		}
+		got := repo.updated[0]
+		if got.ID != existing.ID || !got.CreatedAt.Equal(existingCreated) {
+			t.Errorf("%s: id %s, created at %v; want the existing %s, %v", tt.mode, got.ID, got.CreatedAt, existing.ID, existingCreated)
+		}
+		if got.Fee != tt.wantFee || got.Notes != tt.wantNotes {
+			t.Errorf("%s: fee %d, notes %q; want %d, %q", tt.mode, got.Fee, got.Notes, tt.wantFee, tt.wantNotes)
+		}
+	}
+}
+
+func TestImportLotsSkipAndDryRun(t *testing.T) {
+	service, repo, tracked := newTransferTest()
+	existing := domain.NewLot(transferUser, trackedID(tracked, "Recoil Case"), domain.LotBuy, 5, 100, 0,
+		domain.CurrencyUSD, lotDay, "")
+	repo.lots = []domain.Lot{*existing}
+
+	records := []domain.LotRecord{
+		{ID: existing.ID, MarketHashName: "Recoil Case", Side: domain.LotBuy, Quantity: 6, UnitPrice: 90, Currency: domain.CurrencyUSD, ExecutedAt: lotDay},
+		lotRecord("Recoil Case", domain.LotSell, 5, 1),
+		lotRecord("M4A4 | Howl (Field-Tested)", domain.LotBuy, 1, 0), // Не отслеживается
+	}
+
+	report := &domain.ImportReport{}
+	opts := in_ports.ImportOptions{Mode: domain.ImportSkip, DryRun: true}
+	if err := service.importLots(context.Background(), transferUser, records, opts, tracked, report); err != nil {
+		t.Fatalf("importLots: %v", err)
+	}
+
+	if report.Skipped != 1 || report.Created != 1 || report.Failed != 1 {
+		t.Errorf("report = %+v, want 1 skipped, 1 created, 1 failed", report)
+	}
+	if report.Rows[0].ID != existing.ID {
+		t.Errorf("skipped row id %s, want %s", report.Rows[0].ID, existing.ID)
+	}
+	if len(repo.created)+len(repo.updated) != 0 {
+		t.Error("dry run saved lots")
+	}
+}
+
+func TestFindAlertRule(t *testing.T) {
+	rule := func(itemID string, alertType domain.AlertType, threshold float64) *domain.AlertRule {
+		return domain.NewAlertRule(transferUser, itemID, alertType, threshold, domain.CurrencyUSD, 0, domain.AlertModeRepeat, -1)
+	}
+	below := rule("item-1", domain.AlertPriceBelow, 100)
+	above := rule("item-1", domain.AlertPriceAbove, 200)
+	rules := []*domain.AlertRule{below, above}
+
+	tests := []struct {
+		name    string
+		id      string
+		rule    *domain.AlertRule
+		want    *domain.AlertRule
+		wantErr bool
+	}{
+		{"by id", below.ID, rule("item-1", domain.AlertPriceBelow, 50), below, false},
+		{"by fields", "", rule("item-1", domain.AlertPriceAbove, 200), above, false},
+		{"unknown id falls back to fields", "missing", rule("item-1", domain.AlertPriceAbove, 200), above, false},
+		{"no match", "", rule("item-1", domain.AlertPriceAbove, 300), nil, false},
+		{"id with another type", below.ID, rule("item-1", domain.AlertPriceAbove, 100), nil, true},
+		{"id with another item", below.ID, rule("item-2", domain.AlertPriceBelow, 100), nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := findAlertRule(rules, tt.id, tt.rule)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("%s: error = %v, want ErrValidation", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %v, %v; want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"steam-observer/internal/modules/market/domain"
+	"steam-observer/internal/modules/market/ports/in_ports"
+	"steam-observer/internal/modules/market/ports/out_ports"
+	"steam-observer/internal/shared/logger"
+)
+
+type WatchlistService interface {
+	in_ports.WatchlistService
+}
+
+type watchlistServiceImpl struct {
+	watchlistRepo out_ports.WatchlistRepository
+	itemRepo      out_ports.TrackedItemRepository
+	snapshots     out_ports.PriceSnapshotRepository
+	currency      CurrencyConverter
+	logger        logger.Logger
+}
+
+// NewWatchlistService - списки предметов и их публикация по ссылке
+func NewWatchlistService(
+	watchlistRepo out_ports.WatchlistRepository,
+	itemRepo out_ports.TrackedItemRepository,
+	snapshots out_ports.PriceSnapshotRepository,
+	currency CurrencyConverter,
+	log logger.Logger,
+) WatchlistService {
+	return &watchlistServiceImpl{
+		watchlistRepo: watchlistRepo,
+		itemRepo:      itemRepo,
+		snapshots:     snapshots,
+		currency:      currency,
+		logger:        log,
+	}
+}
+
+func (s *watchlistServiceImpl) ListWatchlists(ctx context.Context, userID string) ([]domain.Watchlist, error) {
+	watchlists, err := s.watchlistRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list watchlists: %w", err)
+	}
+	return watchlists, nil
+}
+
+func (s *watchlistServiceImpl) CreateWatchlist(ctx context.Context, userID string, input in_ports.CreateWatchlistInput) (*domain.Watchlist, error) {
+	watchlist := domain.NewWatchlist(userID, input.Name, input.Description)
+	if err := watchlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.watchlistRepo.Create(ctx, watchlist); err != nil {
+		return nil, fmt.Errorf("create watchlist: %w", err)
+	}
+
+	s.logger.Infof("watchlist created, id=%s, user_id=%s", watchlist.ID, userID)
+
+	return watchlist, nil
+}
+
+func (s *watchlistServiceImpl) UpdateWatchlist(ctx context.Context, userID, watchlistID string, input in_ports.UpdateWatchlistInput) (*domain.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.FindByID(ctx, userID, watchlistID)
+	if err != nil {
+		return nil, fmt.Errorf("find watchlist: %w", err)
+	}
+
+	if input.Name != nil {
+		watchlist.Rename(*input.Name)
+	}
+	if input.Description != nil {
+		watchlist.Describe(*input.Description)
+	}
+	if err := watchlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.watchlistRepo.Update(ctx, watchlist); err != nil {
+		return nil, fmt.Errorf("update watchlist: %w", err)
+	}
+
+	return watchlist, nil
+}
+
+func (s *watchlistServiceImpl) DeleteWatchlist(ctx context.Context, userID, watchlistID string) error {
+	if err := s.watchlistRepo.Delete(ctx, userID, watchlistID); err != nil {
+		return fmt.Errorf("delete watchlist: %w", err)
+	}
+
+	s.logger.Infof("watchlist deleted, id=%s, user_id=%s", watchlistID, userID)
+
+	return nil
+}
+
+func (s *watchlistServiceImpl) AddItem(ctx context.Context, userID, watchlistID, itemID string) error {
+	// Таблица связей не знает о владельцах: обе стороны проверяем здесь
+	if _, err := s.watchlistRepo.FindByID(ctx, userID, watchlistID); err != nil {
+		return fmt.Errorf("find watchlist: %w", err)
+	}
+	if _, err := s.itemRepo.FindByID(ctx, userID, itemID); err != nil {
+		return fmt.Errorf("find tracked item: %w", err)
+	}
+
+	if err := s.watchlistRepo.AddItem(ctx, watchlistID, itemID); err != nil {
+		return fmt.Errorf("add watchlist item: %w", err)
+	}
+	return nil
+}
+
+func (s *watchlistServiceImpl) RemoveItem(ctx context.Context, userID, watchlistID, itemID string) error {
+	if _, err := s.watchlistRepo.FindByID(ctx, userID, watchlistID); err != nil {
+		return fmt.Errorf("find watchlist: %w", err)
+	}
+
+	if err := s.watchlistRepo.RemoveItem(ctx, watchlistID, itemID); err != nil {
+		return fmt.Errorf("remove watchlist item: %w", err)
+	}
+	return nil
+}
+
+func (s *watchlistServiceImpl) Share(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.FindByID(ctx, userID, watchlistID)
+	if err != nil {
+		return nil, fmt.Errorf("find watchlist: %w", err)
+	}
+
+	if err := watchlist.Share(); err != nil {
+		return nil, err
+	}
+	if err := s.watchlistRepo.Update(ctx, watchlist); err != nil {
+		return nil, fmt.Errorf("update watchlist: %w", err)
+	}
+
+	s.logger.Infof("watchlist shared, id=%s, user_id=%s", watchlist.ID, userID)
+
+	return watchlist, nil
+}
+
+func (s *watchlistServiceImpl) Unshare(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.FindByID(ctx, userID, watchlistID)
+	if err != nil {
+		return nil, fmt.Errorf("find watchlist: %w", err)
+	}
+
+	watchlist.Unshare()
+	if err := s.watchlistRepo.Update(ctx, watchlist); err != nil {
+		return nil, fmt.Errorf("update watchlist: %w", err)
+	}
+
+	return watchlist, nil
+}
+
+func (s *watchlistServiceImpl) GetShared(ctx context.Context, token string, currency domain.Currency) (*domain.PublicWatchlist, error) {
+	watchlist, err := s.watchlistRepo.FindByShareToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("find shared watchlist: %w", err)
+	}
+
+	items, err := s.itemRepo.ListFiltered(ctx, watchlist.UserID, domain.TrackedItemFilter{WatchlistID: watchlist.ID})
+	if err != nil {
+		return nil, fmt.Errorf("list watchlist items: %w", err)
+	}
+
+	keys := make([]domain.ItemKey, len(items))
+	for i := range items {
+		keys[i] = items[i].Key()
+	}
+	latest, err := s.snapshots.LatestByKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("latest price snapshots: %w", err)
+	}
+
+	if currency == 0 {
+		currency = s.currency.Canonical()
+	}
+	prices := make([]domain.PriceSnapshot, 0, len(latest))
+	for _, snapshot := range latest {
+		prices = append(prices, snapshot)
+	}
+	if err := convertSnapshots(s.currency, prices, currency); err != nil {
+		return nil, err
+	}
+	byKey := make(map[domain.ItemKey]*domain.PriceSnapshot, len(prices))
+	for i := range prices {
+		byKey[prices[i].Key()] = &prices[i]
+	}
+
+	result := &domain.PublicWatchlist{
+		Name:        watchlist.Name,
+		Description: watchlist.Description,
+		Currency:    currency,
+		Items:       make([]domain.PublicWatchlistItem, 0, len(items)),
+		UpdatedAt:   watchlist.UpdatedAt,
+	}
+	for i := range items {
+		item := domain.PublicWatchlistItem{
+			AppID:          items[i].AppID,
+			MarketHashName: items[i].MarketHashName,
+			Name:           items[i].Name,
+		}
+		if snapshot, ok := byKey[items[i].Key()]; ok {
+			item.LowestPrice = &snapshot.LowestPrice
+			item.MedianPrice = &snapshot.MedianPrice
+			item.Volume = &snapshot.Volume
+			item.ObservedAt = &snapshot.ObservedAt
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertType - вид условия алерта
+type AlertType string
+
+const (
+	// AlertPriceBelow - цена опустилась до Threshold или ниже (Threshold в сотых долях валюты)
+	AlertPriceBelow AlertType = "price_below"
+	// AlertPriceAbove - цена поднялась до Threshold или выше (Threshold в сотых долях валюты)
+	AlertPriceAbove AlertType = "price_above"
+	// AlertPercentChange - цена изменилась на Threshold процентов за окно
+	// Знак задаёт направление: +15 = рост на 15%+, -10 = падение на 10%+
+	AlertPercentChange AlertType = "percent_change"
+	// AlertVolumeSpike - объём продаж в Threshold раз выше среднего за окно
+	AlertVolumeSpike AlertType = "volume_spike"
+)
+
+// AlertMode - что делать с правилом после срабатывания
+type AlertMode string
+
+const (
+	AlertModeOnce   AlertMode = "once"   // Сработать один раз и выключиться
+	AlertModeRepeat AlertMode = "repeat" // Срабатывать снова после cooldown
+)
+
+// Ограничения на окно для percent_change / volume_spike
+const (
+	MinAlertWindow = 5 * time.Minute
+	MaxAlertWindow = 30 * 24 * time.Hour
+)
+
+// DefaultAlertCooldown - пауза между срабатываниями repeat правила по умолчанию
+const DefaultAlertCooldown = time.Hour
+
+// AlertRule - правило алерта на отслеживаемый предмет пользователя
+type AlertRule struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	TrackedItemID   string     `json:"tracked_item_id"`
+	Type            AlertType  `json:"type"`
+	Threshold       float64    `json:"threshold"`      // Смысл зависит от Type (см. константы)
+	Currency        Currency   `json:"currency"`       // Валюта ценового Threshold; в ней же оцениваются снимки
+	WindowSeconds   int64      `json:"window_seconds"` // Окно для percent_change / volume_spike
+	Mode            AlertMode  `json:"mode"`
+	CooldownSeconds int64      `json:"cooldown_seconds"` // Минимальная пауза между срабатываниями
+	Enabled         bool       `json:"enabled"`
+	LastFiredAt     *time.Time `json:"last_fired_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// NewAlertRule - фабричный метод нового правила
+// Пустой mode → once, отрицательный cooldown → DefaultAlertCooldown
+func NewAlertRule(userID, trackedItemID string, alertType AlertType, threshold float64, currency Currency, windowSeconds int64, mode AlertMode, cooldownSeconds int64) *AlertRule {
+	now := time.Now()
+
+	if mode == "" {
+		mode = AlertModeOnce
+	}
+	if cooldownSeconds < 0 {
+		cooldownSeconds = int64(DefaultAlertCooldown / time.Second)
+	}
+
+	return &AlertRule{
+		ID:              uuid.New().String(),
+		UserID:          userID,
+		TrackedItemID:   trackedItemID,
+		Type:            alertType,
+		Threshold:       threshold,
+		Currency:        currency,
+		WindowSeconds:   windowSeconds,
+		Mode:            mode,
+		CooldownSeconds: cooldownSeconds,
+		Enabled:         true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Window - окно сравнения
+func (r *AlertRule) Window() time.Duration {
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// Cooldown - пауза между срабатываниями
+func (r *AlertRule) Cooldown() time.Duration {
+	return time.Duration(r.CooldownSeconds) * time.Second
+}
+
+// NeedsHistory - нужна ли правилу история цен за окно
+func (r *AlertRule) NeedsHistory() bool {
+	return r.Type == AlertPercentChange || r.Type == AlertVolumeSpike
+}
+
+// Validate - проверка инвариантов правила
+func (r *AlertRule) Validate() error {
+	if r.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if r.TrackedItemID == "" {
+		return fmt.Errorf("%w: tracked_item_id is required", ErrValidation)
+	}
+
+	if !r.Currency.IsKnown() {
+		return fmt.Errorf("%w: unsupported currency %d", ErrValidation, int(r.Currency))
+	}
+
+	switch r.Type {
+	case AlertPriceBelow, AlertPriceAbove:
+		if r.Threshold <= 0 {
+			return fmt.Errorf("%w: price threshold must be positive", ErrValidation)
+		}
+	case AlertPercentChange:
+		if r.Threshold == 0 || math.Abs(r.Threshold) > 1000 {
+			return fmt.Errorf("%w: percent threshold must be non-zero and within ±1000", ErrValidation)
+		}
+	case AlertVolumeSpike:
+		if r.Threshold <= 1 {
+			return fmt.Errorf("%w: volume spike multiplier must be greater than 1", ErrValidation)
+		}
+	default:
+		return fmt.Errorf("%w: unknown alert type %q", ErrValidation, r.Type)
+	}
+
+	if r.NeedsHistory() && (r.Window() < MinAlertWindow || r.Window() > MaxAlertWindow) {
+		return fmt.Errorf("%w: window_seconds must be between %d and %d",
+			ErrValidation, int64(MinAlertWindow/time.Second), int64(MaxAlertWindow/time.Second))
+	}
+
+	if r.Mode != AlertModeOnce && r.Mode != AlertModeRepeat {
+		return fmt.Errorf("%w: unknown alert mode %q", ErrValidation, r.Mode)
+	}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("%w: cooldown_seconds must not be negative", ErrValidation)
+	}
+
+	return nil
+}
+
+// CanFire - можно ли правилу сработать сейчас (включено и cooldown истёк)
+func (r *AlertRule) CanFire(now time.Time) bool {
+	if !r.Enabled {
+		return false
+	}
+	return r.LastFiredAt == nil || now.Sub(*r.LastFiredAt) >= r.Cooldown()
+}
+
+// MarkFired - фиксирует срабатывание: запоминает время, once-правило выключается
+func (r *AlertRule) MarkFired(at time.Time) {
+	r.LastFiredAt = &at
+	if r.Mode == AlertModeOnce {
+		r.Enabled = false
+	}
+	r.UpdatedAt = at
+}
+
+// FiredAlert - запись истории срабатываний
+type FiredAlert struct {
+	ID             string    `json:"id"`
+	RuleID         string    `json:"rule_id"`
+	UserID         string    `json:"user_id"`
+	TrackedItemID  string    `json:"tracked_item_id"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Type           AlertType `json:"type"`
+	Threshold      float64   `json:"threshold"`
+	Currency       Currency  `json:"currency"`
+	Price          int64     `json:"price"`     // Цена в момент срабатывания
+	Reference      float64   `json:"reference"` // База сравнения: цена в начале окна / средний объём
+	Observed       float64   `json:"observed"`  // Фактическое значение: цена / изменение в % / объём
+	Message        string    `json:"message"`
+	FiredAt        time.Time `json:"fired_at"`
+}
+
+// EvaluateAlert - проверяет правило на новом снимке цены
+//
+// history - снимки того же предмета за окно правила (по возрастанию времени, без current);
+// нужен только для percent_change и volume_spike.
+// current и history должны быть в валюте правила (rule.Currency) - конвертация на вызывающем.
+// Возвращает nil если условие не выполнено или данных недостаточно.
+// Cooldown и Enabled здесь не проверяются - это делает CanFire.
+func EvaluateAlert(rule *AlertRule, current *PriceSnapshot, history []PriceSnapshot) *FiredAlert {
+	price := current.Price()
+
+	fired := &FiredAlert{
+		ID:             uuid.New().String(),
+		RuleID:         rule.ID,
+		UserID:         rule.UserID,
+		TrackedItemID:  rule.TrackedItemID,
+		AppID:          current.AppID,
+		MarketHashName: current.MarketHashName,
+		Type:           rule.Type,
+		Threshold:      rule.Threshold,
+		Currency:       current.Currency,
+		Price:          price,
+		FiredAt:        current.ObservedAt,
+	}
+
+	switch rule.Type {
+	case AlertPriceBelow:
+		if price <= 0 || float64(price) > rule.Threshold {
+			return nil
+		}
+		fired.Reference = rule.Threshold
+		fired.Observed = float64(price)
+		fired.Message = fmt.Sprintf("%s: price %s is at or below %s",
+			current.MarketHashName, FormatPrice(price, current.Currency), FormatPrice(int64(rule.Threshold), current.Currency))
+
+	case AlertPriceAbove:
+		if price <= 0 || float64(price) < rule.Threshold {
+			return nil
+		}
+		fired.Reference = rule.Threshold
+		fired.Observed = float64(price)
+		fired.Message = fmt.Sprintf("%s: price %s is at or above %s",
+			current.MarketHashName, FormatPrice(price, current.Currency), FormatPrice(int64(rule.Threshold), current.Currency))
+
+	case AlertPercentChange:
+		base := firstPrice(history)
+		if price <= 0 || base <= 0 {
+			return nil
+		}
+		change := (float64(price) - float64(base)) / float64(base) * 100
+		if (rule.Threshold > 0 && change < rule.Threshold) || (rule.Threshold < 0 && change > rule.Threshold) {
+			return nil
+		}
+		fired.Reference = float64(base)
+		fired.Observed = change
+		fired.Message = fmt.Sprintf("%s: price moved %+.1f%% over %s (%s → %s)",
+			current.MarketHashName, change, rule.Window(), FormatPrice(base, current.Currency), FormatPrice(price, current.Currency))
+
+	case AlertVolumeSpike:
+		avg := averageVolume(history)
+		if avg <= 0 || float64(current.Volume) < avg*rule.Threshold {
+			return nil
+		}
+		fired.Reference = avg
+		fired.Observed = float64(current.Volume)
+		fired.Message = fmt.Sprintf("%s: volume %d is %.1fx the %s average (%.0f)",
+			current.MarketHashName, current.Volume, float64(current.Volume)/avg, rule.Window(), avg)
+
+	default:
+		return nil
+	}
+
+	return fired
+}
+
+// firstPrice - самая ранняя известная цена в окне
+func firstPrice(history []PriceSnapshot) int64 {
+	for i := range history {
+		if p := history[i].Price(); p > 0 {
+			return p
+		}
+	}
+	return 0
+}
+
+// averageVolume - средний объём продаж по снимкам окна
+func averageVolume(history []PriceSnapshot) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	var sum int64
+	for i := range history {
+		sum += history[i].Volume
+	}
+	return float64(sum) / float64(len(history))
+}
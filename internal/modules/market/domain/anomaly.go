@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// AnomalyDetector - каким методом найдена аномалия
+type AnomalyDetector string
+
+const (
+	// AnomalyZScore - отклонение цены от среднего за окно в стандартных отклонениях
+	AnomalyZScore AnomalyDetector = "zscore"
+	// AnomalyMAD - робастный z-score по медиане и медианному абсолютному отклонению:
+	// одиночные выбросы в окне не размывают базу, как у обычного z-score
+	AnomalyMAD AnomalyDetector = "mad"
+	// AnomalyVolume - объём продаж во много раз выше медианного за окно
+	AnomalyVolume AnomalyDetector = "volume"
+)
+
+// AnomalySeverity - насколько сильно значение вышло за порог
+type AnomalySeverity string
+
+const (
+	SeverityLow    AnomalySeverity = "low"    // Порог превышен
+	SeverityMedium AnomalySeverity = "medium" // В 1.5 раза сильнее порога
+	SeverityHigh   AnomalySeverity = "high"   // В 2 раза сильнее порога
+)
+
+// ParseAnomalySeverity - уровень из строки
+func ParseAnomalySeverity(s string) (AnomalySeverity, error) {
+	switch severity := AnomalySeverity(s); severity {
+	case SeverityLow, SeverityMedium, SeverityHigh:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("%w: severity must be low, medium or high", ErrValidation)
+	}
+}
+
+// AtLeast - уровни не ниже s (для фильтра ?severity=medium → medium и high)
+func (s AnomalySeverity) AtLeast() []AnomalySeverity {
+	switch s {
+	case SeverityHigh:
+		return []AnomalySeverity{SeverityHigh}
+	case SeverityMedium:
+		return []AnomalySeverity{SeverityMedium, SeverityHigh}
+	default:
+		return []AnomalySeverity{SeverityLow, SeverityMedium, SeverityHigh}
+	}
+}
+
+// Направление аномалии цены; у объёма всегда up
+const (
+	AnomalyUp   = "up"
+	AnomalyDown = "down"
+)
+
+// Anomaly - снимок цены, выбивающийся из истории предмета
+//
+// Аномалии общие для всех пользователей (цены у всех одни), TrackedItemID заполняется
+// только в ответах API. Value и Baseline у ценовых детекторов - цены в сотых долях
+// Currency, у volume - штуки.
+type Anomaly struct {
+	ID             int64           `json:"id"`
+	TrackedItemID  string          `json:"tracked_item_id,omitempty"`
+	AppID          int             `json:"app_id"`
+	MarketHashName string          `json:"market_hash_name"`
+	SnapshotID     int64           `json:"snapshot_id"`
+	Detector       AnomalyDetector `json:"detector"`
+	Severity       AnomalySeverity `json:"severity"`
+	Direction      string          `json:"direction"`
+	Score          float64         `json:"score"`    // z-score / робастный z-score / во сколько раз объём выше медианы
+	Value          float64         `json:"value"`    // Значение в снимке
+	Baseline       float64         `json:"baseline"` // Среднее / медиана за окно
+	Currency       Currency        `json:"currency"`
+	ObservedAt     time.Time       `json:"observed_at"`
+	DetectedAt     time.Time       `json:"detected_at"`
+}
+
+// Key - ключ предмета аномалии
+func (a *Anomaly) Key() ItemKey {
+	return ItemKey{AppID: a.AppID, MarketHashName: a.MarketHashName}
+}
+
+// IsPrice - Value и Baseline - цены (их надо переводить в валюту пользователя)
+func (a *Anomaly) IsPrice() bool {
+	return a.Detector != AnomalyVolume
+}
+
+// AnomalyParams - пороги детекторов
+type AnomalyParams struct {
+	Window       time.Duration // Сколько истории до снимка служит базой
+	MinPoints    int           // Меньше снимков в окне - статистика ненадёжна, не проверяем
+	ZThreshold   float64       // |z| с которого цена аномальна (обычно 3)
+	MADThreshold float64       // |робастный z| с которого цена аномальна (обычно 3.5)
+	VolumeFactor float64       // Во сколько раз объём выше медианы, чтобы считаться всплеском
+	MinVolume    int64         // Всплески ниже этого объёма не интересны (3 продажи вместо 0 - не всплеск)
+}
+
+// madScale - приводит MAD к масштабу стандартного отклонения нормального распределения
+// (робастный z-score Иглевича-Хоглина)
+const madScale = 0.6745
+
+// DetectAnomalies - проверяет снимок current на фоне предыдущих снимков window
+//
+// window - снимки того же предмета за params.Window до current, по возрастанию времени,
+// в той же валюте, что и current. Снимки без цены в ценовой статистике не участвуют.
+// Один снимок может дать аномалии нескольких детекторов.
+func DetectAnomalies(current *PriceSnapshot, window []PriceSnapshot, params AnomalyParams) []Anomaly {
+	var anomalies []Anomaly
+
+	prices := make([]float64, 0, len(window))
+	volumes := make([]float64, 0, len(window))
+	for i := range window {
+		if price := window[i].Price(); price > 0 {
+			prices = append(prices, float64(price))
+		}
+		volumes = append(volumes, float64(window[i].Volume))
+	}
+
+	newAnomaly := func(detector AnomalyDetector, score, threshold, value, baseline float64) Anomaly {
+		direction := AnomalyUp
+		if value < baseline {
+			direction = AnomalyDown
+		}
+		return Anomaly{
+			AppID:          current.AppID,
+			MarketHashName: current.MarketHashName,
+			SnapshotID:     current.ID,
+			Detector:       detector,
+			Severity:       anomalySeverity(math.Abs(score), threshold),
+			Direction:      direction,
+			Score:          roundTo(score, 2),
+			Value:          value,
+			Baseline:       roundTo(baseline, 2),
+			Currency:       current.Currency,
+			ObservedAt:     current.ObservedAt,
+		}
+	}
+
+	if price := float64(current.Price()); price > 0 && len(prices) >= params.MinPoints && len(prices) > 1 {
+		mean, std := meanStd(prices)
+		if std > 0 {
+			if z := (price - mean) / std; math.Abs(z) >= params.ZThreshold {
+				anomalies = append(anomalies, newAnomaly(AnomalyZScore, z, params.ZThreshold, price, mean))
+			}
+		}
+
+		// MAD = 0 - больше половины окна с одной ценой; робастный z не определён
+		median := medianOf(prices)
+		if mad := medianAbsDeviation(prices, median); mad > 0 {
+			if z := madScale * (price - median) / mad; math.Abs(z) >= params.MADThreshold {
+				anomalies = append(anomalies, newAnomaly(AnomalyMAD, z, params.MADThreshold, price, median))
+			}
+		}
+	}
+
+	if len(volumes) >= params.MinPoints && len(volumes) > 0 && current.Volume >= params.MinVolume {
+		// Медиана 0 (продаж обычно нет) - сравниваем с одной продажей
+		baseline := max(medianOf(volumes), 1)
+		if ratio := float64(current.Volume) / baseline; ratio >= params.VolumeFactor {
+			anomalies = append(anomalies, newAnomaly(AnomalyVolume, ratio, params.VolumeFactor, float64(current.Volume), baseline))
+		}
+	}
+
+	return anomalies
+}
+
+// ScanAnomalies - прогон DetectAnomalies по всей истории (по возрастанию времени)
+// Каждый снимок сравнивается с предыдущими в пределах params.Window
+func ScanAnomalies(history []PriceSnapshot, params AnomalyParams) []Anomaly {
+	var anomalies []Anomaly
+
+	start := 0
+	for i := range history {
+		from := history[i].ObservedAt.Add(-params.Window)
+		for start < i && history[start].ObservedAt.Before(from) {
+			start++
+		}
+		anomalies = append(anomalies, DetectAnomalies(&history[i], history[start:i], params)...)
+	}
+
+	return anomalies
+}
+
+// anomalySeverity - уровень по тому, во сколько раз превышен порог
+func anomalySeverity(score, threshold float64) AnomalySeverity {
+	switch {
+	case score >= 2*threshold:
+		return SeverityHigh
+	case score >= 1.5*threshold:
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+func meanStd(values []float64) (mean, std float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var sumSq float64
+	for _, v := range values {
+		sumSq += (v - mean) * (v - mean)
+	}
+	// Выборочное отклонение: окно - выборка из истории цены
+	return mean, math.Sqrt(sumSq / float64(len(values)-1))
+}
+
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func medianAbsDeviation(values []float64, median float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	return medianOf(deviations)
+}
+
+func roundTo(v float64, digits int) float64 {
+	pow := math.Pow10(digits)
+	return math.Round(v*pow) / pow
+}
+
+// AnomalyScan - итог пересчёта аномалий по истории предмета
+type AnomalyScan struct {
+	TrackedItemID string    `json:"tracked_item_id"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Snapshots     int       `json:"snapshots"` // Сколько снимков проверено
+	Found         int       `json:"found"`     // Сколько аномалий в истории
+	New           int       `json:"new"`       // Из них раньше не найденных
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+var anomalyParams = AnomalyParams{
+	Window:       6 * time.Hour,
+	MinPoints:    5,
+	ZThreshold:   3,
+	MADThreshold: 3.5,
+	VolumeFactor: 5,
+	MinVolume:    5,
+}
+
+var anomalyStart = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// anomalySeries - снимки раз в час с ценами prices и объёмом volume
+func anomalySeries(volume int64, prices ...int64) []PriceSnapshot {
+	snapshots := make([]PriceSnapshot, len(prices))
+	for i, price := range prices {
+		snapshots[i] = PriceSnapshot{
+			ID:          int64(i),
+			AppID:       AppIDCS2,
+			Currency:    CurrencyUSD,
+			LowestPrice: price,
+			Volume:      volume,
+			ObservedAt:  anomalyStart.Add(time.Duration(i) * time.Hour),
+		}
+	}
+	return snapshots
+}
+
+// anomalyWant - ожидаемая аномалия: детектор, направление, уровень, score и база
+type anomalyWant struct {
+	detector  AnomalyDetector
+	direction string
+	severity  AnomalySeverity
+	score     float64
+	baseline  float64
+}
+
+func checkAnomalies(t *testing.T, name string, got []Anomaly, want []anomalyWant) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d anomalies %+v, want %d", name, len(got), got, len(want))
+		return
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Detector != w.detector || g.Direction != w.direction || g.Severity != w.severity ||
+			g.Score != w.score || g.Baseline != w.baseline {
+			t.Errorf("%s: anomaly %d = %s %s %s score %v baseline %v, want %s %s %s score %v baseline %v",
+				name, i, g.Detector, g.Direction, g.Severity, g.Score, g.Baseline,
+				w.detector, w.direction, w.severity, w.score, w.baseline)
+		}
+	}
+}
+
+func TestDetectAnomaliesPrice(t *testing.T) {
+	// Окно 100/102: среднее 101, выборочное σ = √(10/9) ≈ 1.054, медиана 101, MAD 1
+	window := anomalySeries(10, 100, 102, 100, 102, 100, 102, 100, 102, 100, 102)
+
+	tests := []struct {
+		name  string
+		price int64
+		want  []anomalyWant
+	}{
+		{"spike up", 110, []anomalyWant{
+			{AnomalyZScore, AnomalyUp, SeverityHigh, 8.54, 101}, // 9 / 1.054 ≥ 2 × 3
+			{AnomalyMAD, AnomalyUp, SeverityMedium, 6.07, 101},  // 0.6745 × 9 ≥ 1.5 × 3.5
+		}},
+		// z по выборочному σ: -3.79 (по генеральному было бы ровно -4)
+		{"dip down", 97, []anomalyWant{{AnomalyZScore, AnomalyDown, SeverityLow, -3.79, 101}}},
+		{"within the band", 98, nil},
+	}
+
+	for _, tt := range tests {
+		current := anomalySeries(10, tt.price)[0]
+		current.ObservedAt = anomalyStart.Add(10 * time.Hour)
+		checkAnomalies(t, tt.name, DetectAnomalies(&current, window, anomalyParams), tt.want)
+	}
+}
+
+func TestDetectAnomaliesDegenerateWindow(t *testing.T) {
+	current := anomalySeries(10, 150)[0]
+
+	// Все цены одинаковые: σ = 0 и MAD = 0 - ни z-score, ни робастный z не определены
+	flat := anomalySeries(10, 100, 100, 100, 100, 100, 100)
+	checkAnomalies(t, "flat window", DetectAnomalies(&current, flat, anomalyParams), nil)
+
+	// Больше половины окна с одной ценой: MAD = 0, но σ > 0 (среднее 102, σ = √40)
+	mostlyFlat := anomalySeries(10, 100, 100, 100, 100, 100, 100, 100, 100, 100, 120)
+	current.LowestPrice = 130
+	checkAnomalies(t, "mostly flat window", DetectAnomalies(&current, mostlyFlat, anomalyParams),
+		[]anomalyWant{{AnomalyZScore, AnomalyUp, SeverityLow, 4.43, 102}})
+
+	// Меньше MinPoints снимков - не проверяем
+	checkAnomalies(t, "short window", DetectAnomalies(&current, flat[:4], anomalyParams), nil)
+
+	// Снимки без цены в ценовую статистику не входят
+	noPrice := anomalySeries(10, 0, 0, 0, 0, 0, 0)
+	checkAnomalies(t, "window without prices", DetectAnomalies(&current, noPrice, anomalyParams), nil)
+}
+
+func TestDetectAnomaliesVolume(t *testing.T) {
+	// Продаж обычно нет: медиана 0, база - одна продажа
+	window := anomalySeries(0, 100, 100, 100, 100, 100, 100)
+
+	tests := []struct {
+		name   string
+		volume int64
+		want   []anomalyWant
+	}{
+		{"below min volume", 4, nil},
+		{"at the threshold", 5, []anomalyWant{{AnomalyVolume, AnomalyUp, SeverityLow, 5, 1}}},
+		{"double the threshold", 10, []anomalyWant{{AnomalyVolume, AnomalyUp, SeverityHigh, 10, 1}}},
+	}
+
+	for _, tt := range tests {
+		current := anomalySeries(tt.volume, 100)[0]
+		checkAnomalies(t, tt.name, DetectAnomalies(&current, window, anomalyParams), tt.want)
+	}
+
+	// Обычный объём 20: всплеск - от 100
+	busy := anomalySeries(20, 100, 100, 100, 100, 100, 100)
+	current := anomalySeries(150, 100)[0]
+	checkAnomalies(t, "busy item", DetectAnomalies(&current, busy, anomalyParams),
+		[]anomalyWant{{AnomalyVolume, AnomalyUp, SeverityMedium, 7.5, 20}})
+}
+
+func TestScanAnomaliesRollingWindow(t *testing.T) {
+	// Старые цены 50 выпадают из 6-часового окна к моменту всплеска в 10:00:
+	// база всплеска - только снимки 04:00-09:00 (среднее 101)
+	history := anomalySeries(10, 50, 50, 50, 50, 100, 102, 100, 102, 100, 102, 130)
+
+	anomalies := ScanAnomalies(history, anomalyParams)
+
+	for _, a := range anomalies {
+		if a.SnapshotID != 10 {
+			t.Errorf("unexpected anomaly at snapshot %d: %+v", a.SnapshotID, a)
+		}
+	}
+	checkAnomalies(t, "spike", anomalies, []anomalyWant{
+		{AnomalyZScore, AnomalyUp, SeverityHigh, 26.47, 101},
+		{AnomalyMAD, AnomalyUp, SeverityHigh, 19.56, 101},
+	})
+}
+
+func TestAnomalySeverityAtLeast(t *testing.T) {
+	if got := SeverityMedium.AtLeast(); len(got) != 2 || got[0] != SeverityMedium || got[1] != SeverityHigh {
+		t.Errorf("medium.AtLeast() = %v", got)
+	}
+	if _, err := ParseAnomalySeverity("critical"); err == nil {
+		t.Error("ParseAnomalySeverity(critical): want an error")
+	}
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BackfillStatus - состояние задачи загрузки истории цен
+type BackfillStatus string
+
+const (
+	BackfillPending BackfillStatus = "pending" // Ждёт (первой или повторной) попытки
+	BackfillRunning BackfillStatus = "running" // Воркер загружает историю
+	BackfillDone    BackfillStatus = "done"    // История сохранена целиком
+	BackfillFailed  BackfillStatus = "failed"  // Попытки исчерпаны
+)
+
+// ParseBackfillStatus - статус из query параметра
+func ParseBackfillStatus(s string) (BackfillStatus, error) {
+	switch status := BackfillStatus(s); status {
+	case BackfillPending, BackfillRunning, BackfillDone, BackfillFailed:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: status must be pending, running, done or failed", ErrValidation)
+	}
+}
+
+// BackfillTrigger - почему создана задача
+type BackfillTrigger string
+
+const (
+	BackfillOnTrack BackfillTrigger = "tracked" // Предмет впервые начали отслеживать
+	BackfillByAdmin BackfillTrigger = "admin"   // Ручной перезапуск администратором
+)
+
+// BackfillJob - загрузка всей истории продаж предмета из pricehistory Steam
+//
+// История сохраняется порциями, после каждой порции в задаче фиксируется Cursor -
+// время последней сохранённой точки. Если процесс остановился посреди загрузки,
+// после рестарта задача продолжается с точек новее Cursor.
+type BackfillJob struct {
+	ID             int64           `json:"id"`
+	AppID          int             `json:"app_id"`
+	MarketHashName string          `json:"market_hash_name"`
+	Status         BackfillStatus  `json:"status"`
+	Trigger        BackfillTrigger `json:"trigger"`
+	RequestedBy    string          `json:"requested_by,omitempty"`
+	Cursor         *time.Time      `json:"cursor"`       // nil - ещё ничего не сохранено
+	Points         int64           `json:"points"`       // Сохранено точек
+	TotalPoints    int             `json:"total_points"` // Точек в ответе Steam; 0 - история ещё не запрашивалась
+	Attempts       int             `json:"attempts"`     // Неудачных попыток
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	StartedAt      *time.Time      `json:"started_at"`
+	FinishedAt     *time.Time      `json:"finished_at"`
+}
+
+// NewBackfillJob - новая задача в статусе pending
+func NewBackfillJob(key ItemKey, trigger BackfillTrigger, requestedBy string) *BackfillJob {
+	now := time.Now().UTC()
+
+	return &BackfillJob{
+		AppID:          key.AppID,
+		MarketHashName: key.MarketHashName,
+		Status:         BackfillPending,
+		Trigger:        trigger,
+		RequestedBy:    requestedBy,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Key - ключ предмета
+func (j *BackfillJob) Key() ItemKey {
+	return ItemKey{AppID: j.AppID, MarketHashName: j.MarketHashName}
+}
+
+// Remaining - точки истории новее Cursor по возрастанию времени
+func (j *BackfillJob) Remaining(history []PriceHistoryPoint) []PriceHistoryPoint {
+	points := slices.Clone(history)
+	slices.SortFunc(points, func(a, b PriceHistoryPoint) int { return a.Time.Compare(b.Time) })
+
+	if j.Cursor != nil {
+		idx, _ := slices.BinarySearchFunc(points, *j.Cursor, func(p PriceHistoryPoint, t time.Time) int {
+			if p.Time.After(t) {
+				return 1
+			}
+			return -1
+		})
+		points = points[idx:]
+	}
+	return points
+}
+
+// Advance - порция сохранена: сдвигает Cursor на её последнюю точку
+func (j *BackfillJob) Advance(chunk []PriceHistoryPoint, now time.Time) {
+	if len(chunk) == 0 {
+		return
+	}
+	cursor := chunk[len(chunk)-1].Time.UTC()
+	j.Cursor = &cursor
+	j.Points += int64(len(chunk))
+	j.UpdatedAt = now
+}
+
+// Complete - история сохранена целиком
+func (j *BackfillJob) Complete(now time.Time) {
+	j.Status = BackfillDone
+	j.LastError = ""
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+}
+
+// RecordFailure - попытка не удалась; следующая - через backoff, после maxAttempts задача failed
+// Cursor сохраняется: повтор продолжит с последней сохранённой порции
+func (j *BackfillJob) RecordFailure(err error, maxAttempts int, backoff time.Duration, now time.Time) {
+	j.Attempts++
+	j.LastError = err.Error()
+	j.UpdatedAt = now
+	if j.Attempts >= maxAttempts {
+		j.Status = BackfillFailed
+		j.FinishedAt = &now
+		return
+	}
+	j.Status = BackfillPending
+	j.NextAttemptAt = now.Add(backoff)
+}
+
+// Defer - вернуть задачу в очередь без попытки (например, Steam ограничил частоту запросов)
+func (j *BackfillJob) Defer(until, now time.Time) {
+	j.Status = BackfillPending
+	j.NextAttemptAt = until
+	j.UpdatedAt = now
+}
+
+// BackfillBackoff - пауза после неудачной попытки attempt (1-based): base * 2^(attempt-1), не больше maxDelay
+func BackfillBackoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// BackfillJobFilter - фильтр списка задач
+type BackfillJobFilter struct {
+	Status BackfillStatus // "" - любые
+	Limit  int
+}
+
+// DefaultBackfillJobsLimit / MaxBackfillJobsLimit - размер списка задач
+const (
+	DefaultBackfillJobsLimit = 50
+	MaxBackfillJobsLimit     = 500
+)
+
+// HistorySnapshots - точки загруженной истории для графика перед собственными снимками
+//
+// history - снимки из истории Steam по возрастанию времени, Volume - продажи за период
+// точки (час или день). Volume приводится к смыслу снимка poller'а - продажи за 24ч
+// до точки, поэтому историю нужно передавать с запасом в сутки до from.
+// В результат попадают точки из [from, before); before - время первого собственного снимка.
+func HistorySnapshots(history []PriceSnapshot, from, before time.Time) []PriceSnapshot {
+	result := make([]PriceSnapshot, 0, len(history))
+
+	var (
+		start  int
+		window int64
+	)
+	for i := range history {
+		window += history[i].Volume
+		for history[start].ObservedAt.Add(24*time.Hour).Compare(history[i].ObservedAt) <= 0 {
+			window -= history[start].Volume
+			start++
+		}
+
+		t := history[i].ObservedAt
+		if t.Before(from) || !t.Before(before) {
+			continue
+		}
+		snapshot := history[i]
+		snapshot.Volume = window
+		result = append(result, snapshot)
+	}
+
+	return result
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CandleInterval - ширина бакета OHLC свечи
+type CandleInterval string
+
+const (
+	Interval5m CandleInterval = "5m"
+	Interval1h CandleInterval = "1h"
+	Interval1d CandleInterval = "1d"
+	Interval1w CandleInterval = "1w"
+)
+
+// MaxCandles - максимум свечей в одном ответе
+// Защищает от запросов вида "5m за 5 лет" (полмиллиона бакетов)
+const MaxCandles = 2000
+
+// ParseCandleInterval - "1h" → Interval1h
+func ParseCandleInterval(s string) (CandleInterval, error) {
+	switch CandleInterval(s) {
+	case Interval5m, Interval1h, Interval1d, Interval1w:
+		return CandleInterval(s), nil
+	}
+	return "", fmt.Errorf("%w: unsupported interval %q (use 5m, 1h, 1d, 1w)", ErrValidation, s)
+}
+
+// Duration - длительность бакета
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case Interval5m:
+		return 5 * time.Minute
+	case Interval1h:
+		return time.Hour
+	case Interval1d:
+		return 24 * time.Hour
+	case Interval1w:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// DefaultRange - период по умолчанию, если клиент не указал from
+func (i CandleInterval) DefaultRange() time.Duration {
+	switch i {
+	case Interval5m:
+		return 24 * time.Hour
+	case Interval1h:
+		return 7 * 24 * time.Hour
+	case Interval1d:
+		return 180 * 24 * time.Hour
+	case Interval1w:
+		return 2 * 365 * 24 * time.Hour
+	}
+	return 0
+}
+
+// ValidateRange - проверяет что [from, to) корректен и не даёт больше MaxCandles бакетов
+func (i CandleInterval) ValidateRange(from, to time.Time) error {
+	if !from.Before(to) {
+		return fmt.Errorf("%w: from must be before to", ErrValidation)
+	}
+	if to.Sub(from)/i.Duration() > MaxCandles {
+		return fmt.Errorf("%w: range too large for interval %s (max %d candles)", ErrValidation, i, MaxCandles)
+	}
+	return nil
+}
+
+// BucketStart - начало бакета, в который попадает t (UTC)
+// time.Truncate считает от нулевого времени (1 января 1 года, понедельник),
+// поэтому недельные бакеты автоматически начинаются с понедельника 00:00 UTC
+func (i CandleInterval) BucketStart(t time.Time) time.Time {
+	return t.UTC().Truncate(i.Duration())
+}
+
+// Candle - OHLC свеча по снимкам цен за один бакет
+// Цены в сотых долях валюты, как и в PriceSnapshot
+type Candle struct {
+	Time   time.Time `json:"time"` // Начало бакета (UTC)
+	Open   int64     `json:"open"`
+	High   int64     `json:"high"`
+	Low    int64     `json:"low"`
+	Close  int64     `json:"close"`
+	Volume int64     `json:"volume"` // Объём продаж за 24ч на момент закрытия бакета
+	Count  int       `json:"count"`  // Сколько снимков попало в бакет
+}
+
+// CandleSeries - свечи предмета за период (ответ API)
+type CandleSeries struct {
+	ItemID         string         `json:"item_id"`
+	AppID          int            `json:"app_id"`
+	MarketHashName string         `json:"market_hash_name"`
+	Interval       CandleInterval `json:"interval"`
+	Currency       Currency       `json:"currency"`
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	Candles        []Candle       `json:"candles"`
+}
+
+// Price - цена снимка для графика: lowest (текущая цена покупки),
+// если Steam её не вернул - median
+func (s *PriceSnapshot) Price() int64 {
+	if s.LowestPrice > 0 {
+		return s.LowestPrice
+	}
+	return s.MedianPrice
+}
+
+// BuildCandles - агрегирует снимки в OHLC свечи
+//
+// Ожидает снимки, отсортированные по ObservedAt по возрастанию.
+// Снимки без цены пропускаются, пустые бакеты не создаются (на графике - разрыв).
+func BuildCandles(snapshots []PriceSnapshot, interval CandleInterval) []Candle {
+	candles := []Candle{}
+
+	for i := range snapshots {
+		s := &snapshots[i]
+		price := s.Price()
+		if price <= 0 {
+			continue
+		}
+
+		bucket := interval.BucketStart(s.ObservedAt)
+		n := len(candles)
+
+		if n == 0 || !candles[n-1].Time.Equal(bucket) {
+			candles = append(candles, Candle{
+				Time:   bucket,
+				Open:   price,
+				High:   price,
+				Low:    price,
+				Close:  price,
+				Volume: s.Volume,
+				Count:  1,
+			})
+			continue
+		}
+
+		c := &candles[n-1]
+		c.High = max(c.High, price)
+		c.Low = min(c.Low, price)
+		c.Close = price
+		c.Volume = s.Volume
+		c.Count++
+	}
+
+	return candles
+}
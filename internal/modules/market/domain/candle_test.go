@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuildCandles(t *testing.T) {
+	base := time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	snapshots := []PriceSnapshot{
+		{ObservedAt: at(0), LowestPrice: 100, Volume: 10},
+		{ObservedAt: at(15), LowestPrice: 130, Volume: 11},
+		{ObservedAt: at(30), LowestPrice: 90, Volume: 12},
+		{ObservedAt: at(45), MedianPrice: 110, Volume: 13}, // Без lowest - берём median
+		{ObservedAt: at(70), Volume: 14},                   // Без цены - пропускается
+		{ObservedAt: at(190), LowestPrice: 200, Volume: 15},
+	}
+
+	got := BuildCandles(snapshots, Interval1h)
+	want := []Candle{
+		{Time: base, Open: 100, High: 130, Low: 90, Close: 110, Volume: 13, Count: 4},
+		// 11:00 - только снимок без цены: бакета нет
+		{Time: base.Add(3 * time.Hour), Open: 200, High: 200, Low: 200, Close: 200, Volume: 15, Count: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d candles, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("candle %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildCandlesEmpty(t *testing.T) {
+	got := BuildCandles(nil, Interval1d)
+	if got == nil || len(got) != 0 {
+		t.Errorf("BuildCandles(nil) = %#v, want an empty non-nil slice", got)
+	}
+}
+
+func TestCandleBucketStart(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	t0 := time.Date(2024, 1, 10, 1, 37, 12, 0, moscow) // Среда, 2024-01-09 22:37:12 UTC
+
+	tests := []struct {
+		interval CandleInterval
+		want     time.Time
+	}{
+		{Interval5m, time.Date(2024, 1, 9, 22, 35, 0, 0, time.UTC)},
+		{Interval1h, time.Date(2024, 1, 9, 22, 0, 0, 0, time.UTC)},
+		{Interval1d, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)},
+		{Interval1w, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)}, // Понедельник
+	}
+
+	for _, tt := range tests {
+		if got := tt.interval.BucketStart(t0); !got.Equal(tt.want) {
+			t.Errorf("%s bucket of %s = %s, want %s", tt.interval, t0, got, tt.want)
+		}
+	}
+}
+
+func TestCandleIntervalValidateRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := Interval5m.ValidateRange(from, from.Add(MaxCandles*5*time.Minute)); err != nil {
+		t.Errorf("MaxCandles buckets: %v", err)
+	}
+	if err := Interval5m.ValidateRange(from, from.Add((MaxCandles+1)*5*time.Minute)); !errors.Is(err, ErrValidation) {
+		t.Errorf("MaxCandles+1 buckets: error = %v, want ErrValidation", err)
+	}
+	if err := Interval1h.ValidateRange(from, from); !errors.Is(err, ErrValidation) {
+		t.Errorf("empty range: error = %v, want ErrValidation", err)
+	}
+	if _, err := ParseCandleInterval("15m"); !errors.Is(err, ErrValidation) {
+		t.Errorf("ParseCandleInterval(15m): error = %v, want ErrValidation", err)
+	}
+}
@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ItemType - категория предмета CS2
+type ItemType string
+
+const (
+	ItemTypeWeapon    ItemType = "weapon"
+	ItemTypeKnife     ItemType = "knife"
+	ItemTypeGloves    ItemType = "gloves"
+	ItemTypeSticker   ItemType = "sticker"
+	ItemTypeGraffiti  ItemType = "graffiti"
+	ItemTypePatch     ItemType = "patch"
+	ItemTypeCharm     ItemType = "charm"
+	ItemTypeMusicKit  ItemType = "music_kit"
+	ItemTypeAgent     ItemType = "agent"
+	ItemTypeContainer ItemType = "container"
+	ItemTypeKey       ItemType = "key"
+	ItemTypeOther     ItemType = "other"
+)
+
+// Exterior - степень износа скина
+type Exterior string
+
+const (
+	ExteriorFactoryNew    Exterior = "Factory New"
+	ExteriorMinimalWear   Exterior = "Minimal Wear"
+	ExteriorFieldTested   Exterior = "Field-Tested"
+	ExteriorWellWorn      Exterior = "Well-Worn"
+	ExteriorBattleScarred Exterior = "Battle-Scarred"
+)
+
+// exteriors - все износы в порядке от лучшего к худшему
+var exteriors = []Exterior{
+	ExteriorFactoryNew,
+	ExteriorMinimalWear,
+	ExteriorFieldTested,
+	ExteriorWellWorn,
+	ExteriorBattleScarred,
+}
+
+// ParseExterior - "field-tested" → ExteriorFieldTested (без учёта регистра)
+func ParseExterior(s string) (Exterior, bool) {
+	for _, e := range exteriors {
+		if strings.EqualFold(s, string(e)) {
+			return e, true
+		}
+	}
+	return "", false
+}
+
+// Префиксы market_hash_name CS2
+const (
+	starPrefix     = "★ "
+	statTrakPrefix = "StatTrak™ "
+	souvenirPrefix = "Souvenir "
+)
+
+// cs2Weapons - оружие CS2 (левая часть "AK-47 | Redline")
+var cs2Weapons = []string{
+	"AK-47", "M4A4", "M4A1-S", "AWP", "Desert Eagle", "Glock-18", "USP-S", "P2000",
+	"P250", "Five-SeveN", "Tec-9", "CZ75-Auto", "Dual Berettas", "R8 Revolver",
+	"MP9", "MAC-10", "MP7", "MP5-SD", "UMP-45", "P90", "PP-Bizon",
+	"FAMAS", "Galil AR", "SG 553", "AUG", "SSG 08", "SCAR-20", "G3SG1",
+	"Nova", "XM1014", "Sawed-Off", "MAG-7", "M249", "Negev", "Zeus x27",
+}
+
+// typedPrefixes - предметы вида "Sticker | Crown (Foil)": тип определяется левой частью
+var typedPrefixes = map[string]ItemType{
+	"Sticker":         ItemTypeSticker,
+	"Sealed Graffiti": ItemTypeGraffiti,
+	"Graffiti":        ItemTypeGraffiti,
+	"Patch":           ItemTypePatch,
+	"Charm":           ItemTypeCharm,
+	"Music Kit":       ItemTypeMusicKit,
+}
+
+// ParsedName - структура market_hash_name CS2
+//
+//	"★ StatTrak™ Karambit | Doppler (Factory New)"
+//	  → Type=knife, Weapon="Karambit", Skin="Doppler", Exterior=Factory New, StatTrak
+type ParsedName struct {
+	MarketHashName string   `json:"market_hash_name"` // Нормализованное имя
+	Type           ItemType `json:"type"`
+	Weapon         string   `json:"weapon"`   // Оружие / нож / перчатки; "" для стикеров и т.п.
+	Skin           string   `json:"skin"`     // Название раскраски / стикера / агента
+	Exterior       Exterior `json:"exterior"` // "" если у предмета нет износа
+	StatTrak       bool     `json:"stattrak"`
+	Souvenir       bool     `json:"souvenir"`
+}
+
+var (
+	pipeSpacing    = regexp.MustCompile(`\s*\|\s*`)
+	trailingParens = regexp.MustCompile(`\s*\(([^()]*)\)$`)
+)
+
+// NormalizeMarketHashName - приводит введённое вручную имя к виду Steam
+//
+// Исправляет то, в чём люди ошибаются чаще всего: пробелы, "|" без пробелов,
+// регистр износа, "StatTrak" без ™, регистр названия оружия.
+// Уже корректное имя не меняется.
+func NormalizeMarketHashName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return ""
+	}
+	name = pipeSpacing.ReplaceAllString(name, " | ")
+
+	var prefix strings.Builder
+	rest := name
+
+	if after, ok := strings.CutPrefix(rest, "★"); ok {
+		prefix.WriteString(starPrefix)
+		rest = strings.TrimSpace(after)
+	}
+	if after, ok := cutPrefixFold(rest, "StatTrak™"); ok {
+		prefix.WriteString(statTrakPrefix)
+		rest = strings.TrimSpace(after)
+	} else if after, ok := cutPrefixFold(rest, "StatTrak"); ok {
+		prefix.WriteString(statTrakPrefix)
+		rest = strings.TrimSpace(after)
+	} else if after, ok := cutPrefixFold(rest, "Souvenir "); ok {
+		prefix.WriteString(souvenirPrefix)
+		rest = strings.TrimSpace(after)
+	}
+
+	// Регистр оружия: "ak-47 | Redline" → "AK-47 | Redline"
+	if left, right, ok := strings.Cut(rest, " | "); ok {
+		for _, weapon := range cs2Weapons {
+			if strings.EqualFold(left, weapon) {
+				rest = weapon + " | " + right
+				break
+			}
+		}
+	}
+
+	// Регистр износа: "(field-tested)" → "(Field-Tested)"
+	if m := trailingParens.FindStringSubmatchIndex(rest); m != nil {
+		if exterior, ok := ParseExterior(rest[m[2]:m[3]]); ok {
+			rest = rest[:m[0]] + " (" + string(exterior) + ")"
+		}
+	}
+
+	return prefix.String() + rest
+}
+
+// ParseMarketHashName - разбирает имя CS2 предмета на составляющие
+// Имя предварительно нормализуется; нераспознанное получает Type=other
+func ParseMarketHashName(name string) ParsedName {
+	normalized := NormalizeMarketHashName(name)
+	parsed := ParsedName{MarketHashName: normalized, Type: ItemTypeOther}
+
+	rest := normalized
+	star := false
+	if after, ok := strings.CutPrefix(rest, starPrefix); ok {
+		star = true
+		rest = after
+	}
+	if after, ok := strings.CutPrefix(rest, statTrakPrefix); ok {
+		parsed.StatTrak = true
+		rest = after
+	} else if after, ok := strings.CutPrefix(rest, souvenirPrefix); ok {
+		parsed.Souvenir = true
+		rest = after
+	}
+
+	if m := trailingParens.FindStringSubmatchIndex(rest); m != nil {
+		if exterior, ok := ParseExterior(rest[m[2]:m[3]]); ok {
+			parsed.Exterior = exterior
+			rest = rest[:m[0]]
+		}
+	}
+
+	left, right, hasPipe := strings.Cut(rest, " | ")
+
+	switch {
+	case star:
+		// Ножи и перчатки - единственные предметы со звездой; "★ Karambit" - ванильный нож без раскраски
+		parsed.Type = ItemTypeKnife
+		if strings.Contains(left, "Gloves") || strings.Contains(left, "Hand Wraps") {
+			parsed.Type = ItemTypeGloves
+		}
+		parsed.Weapon = left
+		if hasPipe {
+			parsed.Skin = right
+		}
+
+	case hasPipe && typedPrefixes[left] != "":
+		parsed.Type = typedPrefixes[left]
+		parsed.Skin = right
+
+	case hasPipe && isCS2Weapon(left):
+		parsed.Type = ItemTypeWeapon
+		parsed.Weapon = left
+		parsed.Skin = right
+
+	case hasPipe && parsed.Exterior == "":
+		// "Sir Bloody Miami Darryl | The Professionals"
+		parsed.Type = ItemTypeAgent
+		parsed.Skin = left
+
+	case !hasPipe && strings.HasSuffix(rest, " Key"):
+		parsed.Type = ItemTypeKey
+
+	case !hasPipe && (strings.HasSuffix(rest, " Case") || strings.HasSuffix(rest, " Capsule") ||
+		strings.HasSuffix(rest, " Package") || strings.Contains(rest, " Case ")):
+		parsed.Type = ItemTypeContainer
+	}
+
+	return parsed
+}
+
+// SkinName - имя скина без StatTrak, Souvenir и износа, как в справочнике коллекций
+// "StatTrak™ AK-47 | Redline (Field-Tested)" → "AK-47 | Redline"; "" для предметов без раскраски
+func (p ParsedName) SkinName() string {
+	switch p.Type {
+	case ItemTypeWeapon:
+		return p.Weapon + " | " + p.Skin
+	case ItemTypeKnife, ItemTypeGloves:
+		if p.Skin == "" {
+			return starPrefix + p.Weapon
+		}
+		return starPrefix + p.Weapon + " | " + p.Skin
+	}
+	return ""
+}
+
+// isCS2Weapon - левая часть имени - оружие CS2
+func isCS2Weapon(s string) bool {
+	for _, weapon := range cs2Weapons {
+		if s == weapon {
+			return true
+		}
+	}
+	return false
+}
+
+// cutPrefixFold - strings.CutPrefix без учёта регистра
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+// CatalogItem - карточка предмета маркета
+// Структурные поля (тип, оружие, износ) берутся из парсера имени,
+// иконка и редкость - из описаний Steam (инвентарь), если они известны
+type CatalogItem struct {
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Name           string    `json:"name"` // Отображаемое имя
+	IconURL        string    `json:"icon_url"`
+	Type           ItemType  `json:"type"`
+	Weapon         string    `json:"weapon"`
+	Skin           string    `json:"skin"`
+	Rarity         string    `json:"rarity"` // "Covert", "Classified", ... ("" - неизвестна)
+	Exterior       Exterior  `json:"exterior"`
+	StatTrak       bool      `json:"stattrak"`
+	Souvenir       bool      `json:"souvenir"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// NewCatalogItem - карточка из имени предмета
+// Для игр кроме CS2 парсер не применяется: только имя и Type=other
+func NewCatalogItem(appID int, marketHashName, name string) *CatalogItem {
+	now := time.Now().UTC()
+	item := &CatalogItem{
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		Name:           strings.TrimSpace(name),
+		Type:           ItemTypeOther,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if item.Name == "" {
+		item.Name = marketHashName
+	}
+
+	if appID == AppIDCS2 {
+		parsed := ParseMarketHashName(marketHashName)
+		item.Type = parsed.Type
+		item.Weapon = parsed.Weapon
+		item.Skin = parsed.Skin
+		item.Exterior = parsed.Exterior
+		item.StatTrak = parsed.StatTrak
+		item.Souvenir = parsed.Souvenir
+	}
+
+	return item
+}
+
+// Key - ключ предмета на маркете
+func (c *CatalogItem) Key() ItemKey {
+	return ItemKey{AppID: c.AppID, MarketHashName: c.MarketHashName}
+}
+
+// CatalogFilter - фильтр каталога; пустые поля не фильтруют
+type CatalogFilter struct {
+	AppID    int
+	Type     ItemType
+	Weapon   string
+	Exterior Exterior
+	Rarity   string
+	StatTrak *bool
+	Souvenir *bool
+	Limit    int
+	Offset   int
+}
+
+// Ограничения поиска по каталогу
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 100
+)
+
+// CatalogSearchQuery - поиск по каталогу: текст + фильтры по разобранным полям
+// Remote - если в каталоге мало совпадений, спросить поиск Steam и дополнить каталог
+type CatalogSearchQuery struct {
+	Query  string
+	Filter CatalogFilter
+	Remote bool
+}
+
+// Validate - проверка поискового запроса
+func (q *CatalogSearchQuery) Validate() error {
+	n := len([]rune(strings.TrimSpace(q.Query)))
+	if n < MinSearchQueryLength || n > MaxSearchQueryLength {
+		return fmt.Errorf("%w: q must be %d to %d characters", ErrValidation, MinSearchQueryLength, MaxSearchQueryLength)
+	}
+	return nil
+}
+
+// CatalogSearchResult - подсказка поиска
+type CatalogSearchResult struct {
+	CatalogItem
+	Score         float64 `json:"score"`                     // 0..1, выше - ближе к запросу
+	TrackedItemID string  `json:"tracked_item_id,omitempty"` // Если пользователь уже следит за предметом
+}
+
+// steamRarities - редкости CS2 в том виде, в каком они стоят в начале поля type Steam
+// ("Classified Rifle", "★ Covert Knife", "High Grade Sticker")
+var steamRarities = []string{
+	"Consumer Grade", "Industrial Grade", "Mil-Spec Grade", "Restricted", "Classified", "Covert",
+	"Contraband", "Base Grade", "High Grade", "Remarkable", "Exotic", "Extraordinary",
+	"Distinguished", "Exceptional", "Superior", "Master",
+}
+
+// RarityFromSteamType - редкость из поля type описания Steam; "" если не распознана
+func RarityFromSteamType(steamType string) string {
+	steamType = strings.TrimSpace(strings.TrimPrefix(steamType, "★"))
+	steamType = strings.TrimPrefix(steamType, "StatTrak™ ")
+	steamType = strings.TrimPrefix(steamType, "Souvenir ")
+	for _, rarity := range steamRarities {
+		if strings.HasPrefix(steamType, rarity) {
+			return rarity
+		}
+	}
+	return ""
+}
@@ -0,0 +1,392 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Rarity - качество (редкость) скина CS2
+type Rarity string
+
+const (
+	RarityConsumer    Rarity = "consumer"     // Ширпотреб
+	RarityIndustrial  Rarity = "industrial"   // Промышленное
+	RarityMilSpec     Rarity = "mil_spec"     // Армейское
+	RarityRestricted  Rarity = "restricted"   // Запрещённое
+	RarityClassified  Rarity = "classified"   // Засекреченное
+	RarityCovert      Rarity = "covert"       // Тайное
+	RarityRareSpecial Rarity = "rare_special" // Ножи и перчатки из кейсов
+)
+
+// rarities - от низшего качества к высшему
+var rarities = []Rarity{
+	RarityConsumer,
+	RarityIndustrial,
+	RarityMilSpec,
+	RarityRestricted,
+	RarityClassified,
+	RarityCovert,
+	RarityRareSpecial,
+}
+
+// Rank - порядковый номер качества (0 - consumer), -1 для неизвестного
+func (r Rarity) Rank() int {
+	return slices.Index(rarities, r)
+}
+
+// Valid - известное качество
+func (r Rarity) Valid() bool {
+	return r.Rank() >= 0
+}
+
+// exteriorBounds - верхние границы float для износов (порядок = exteriors)
+var exteriorBounds = []float64{0.07, 0.15, 0.38, 0.45, 1}
+
+// ExteriorForFloat - износ по float скина
+func ExteriorForFloat(f float64) Exterior {
+	for i, upper := range exteriorBounds {
+		if f < upper {
+			return exteriors[i]
+		}
+	}
+	return ExteriorBattleScarred
+}
+
+// ExteriorShare - вероятность выпадения скина в износе
+type ExteriorShare struct {
+	Exterior    Exterior `json:"exterior"`
+	Probability float64  `json:"probability"`
+}
+
+// WearDistribution - доли износов у скина с float в [minFloat, maxFloat]
+// Float выпадает равномерно в диапазоне скина, поэтому доля износа - длина пересечения диапазонов
+func WearDistribution(minFloat, maxFloat float64) []ExteriorShare {
+	if maxFloat <= minFloat {
+		return []ExteriorShare{{Exterior: ExteriorForFloat(minFloat), Probability: 1}}
+	}
+
+	shares := make([]ExteriorShare, 0, len(exteriors))
+	lower := 0.0
+	for i, upper := range exteriorBounds {
+		overlap := math.Min(upper, maxFloat) - math.Max(lower, minFloat)
+		if overlap > 0 {
+			shares = append(shares, ExteriorShare{Exterior: exteriors[i], Probability: overlap / (maxFloat - minFloat)})
+		}
+		lower = upper
+	}
+	return shares
+}
+
+// SkinItem - скин в коллекции или в списке редких предметов кейса
+type SkinItem struct {
+	Name     string  `json:"name"` // Без износа и StatTrak: "AK-47 | Redline", "★ Karambit | Fade"
+	Rarity   Rarity  `json:"rarity"`
+	MinFloat float64 `json:"min_float"`
+	MaxFloat float64 `json:"max_float"`
+	NoWear   bool    `json:"no_wear,omitempty"` // Ванильный нож: в имени нет износа
+}
+
+// MarketHashName - имя на маркете Steam для варианта скина
+func (s *SkinItem) MarketHashName(statTrak bool, exterior Exterior) string {
+	name := s.Name
+	if statTrak {
+		if rest, ok := strings.CutPrefix(name, starPrefix); ok {
+			name = starPrefix + statTrakPrefix + rest
+		} else {
+			name = statTrakPrefix + name
+		}
+	}
+	if !s.NoWear && exterior != "" {
+		name += " (" + string(exterior) + ")"
+	}
+	return name
+}
+
+// HasStatTrak - бывает ли у скина StatTrak (у перчаток - нет)
+func (s *SkinItem) HasStatTrak() bool {
+	return ParseMarketHashName(s.Name).Type != ItemTypeGloves
+}
+
+// Collection - коллекция скинов CS2 (кейс, карта, операция)
+type Collection struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	Items []SkinItem `json:"items"`
+}
+
+// ItemsOf - скины коллекции указанного качества
+func (c *Collection) ItemsOf(rarity Rarity) []SkinItem {
+	var items []SkinItem
+	for _, item := range c.Items {
+		if item.Rarity == rarity {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// DefaultStatTrakChance - доля StatTrak среди выпадений из кейса
+const DefaultStatTrakChance = 0.1
+
+// DefaultCaseOdds - официальные шансы качеств при открытии кейса
+var DefaultCaseOdds = map[Rarity]float64{
+	RarityMilSpec:     0.7992,
+	RarityRestricted:  0.1598,
+	RarityClassified:  0.0320,
+	RarityCovert:      0.0064,
+	RarityRareSpecial: 0.0026,
+}
+
+// Container - кейс CS2 с таблицей выпадений
+//
+// Обычные выпадения - скины коллекции Collection, редкие (ножи, перчатки) - Rare.
+// Внутри качества все скины равновероятны, float - равномерно в диапазоне скина.
+type Container struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	MarketHashName string             `json:"market_hash_name"`
+	Collection     string             `json:"collection"` // ID коллекции
+	KeyPrice       int64              `json:"key_price"`  // Цена ключа в сотых долях KeyCurrency; 0 - ключ не нужен
+	KeyCurrency    Currency           `json:"key_currency"`
+	StatTrak       bool               `json:"stattrak"`       // Бывают ли StatTrak выпадения
+	Odds           map[Rarity]float64 `json:"odds,omitempty"` // nil - DefaultCaseOdds
+	Rare           []SkinItem         `json:"rare"`
+}
+
+// DropOdds - шансы качеств кейса
+func (c *Container) DropOdds() map[Rarity]float64 {
+	if len(c.Odds) > 0 {
+		return c.Odds
+	}
+	return DefaultCaseOdds
+}
+
+// Validate - таблица выпадений кейса согласована с его коллекцией
+func (c *Container) Validate(collection *Collection) error {
+	if c.ID == "" || c.MarketHashName == "" {
+		return fmt.Errorf("container %q: id and market_hash_name are required", c.Name)
+	}
+
+	var total float64
+	for rarity, p := range c.DropOdds() {
+		if !rarity.Valid() || p < 0 {
+			return fmt.Errorf("container %s: invalid odds for %q", c.ID, rarity)
+		}
+		if p > 0 && len(c.pool(collection, rarity)) == 0 {
+			return fmt.Errorf("container %s: no %s items to drop", c.ID, rarity)
+		}
+		total += p
+	}
+	if math.Abs(total-1) > 1e-6 {
+		return fmt.Errorf("container %s: odds sum to %.4f, want 1", c.ID, total)
+	}
+
+	for _, item := range slices.Concat(collection.Items, c.Rare) {
+		if item.MinFloat < 0 || item.MaxFloat > 1 || item.MinFloat > item.MaxFloat {
+			return fmt.Errorf("container %s: invalid float range of %q", c.ID, item.Name)
+		}
+	}
+	return nil
+}
+
+// pool - скины, которые выпадают с качеством rarity
+func (c *Container) pool(collection *Collection, rarity Rarity) []SkinItem {
+	if rarity == RarityRareSpecial {
+		return c.Rare
+	}
+	return collection.ItemsOf(rarity)
+}
+
+// ContainerSummary - кейс в списке доступных
+type ContainerSummary struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	MarketHashName string `json:"market_hash_name"`
+	Collection     string `json:"collection"`
+	StatTrak       bool   `json:"stattrak"`
+	Items          int    `json:"items"`      // Обычных скинов
+	RareItems      int    `json:"rare_items"` // Ножей и перчаток
+}
+
+// ContainerOutcome - один вариант выпадения (скин + StatTrak + износ)
+type ContainerOutcome struct {
+	MarketHashName string   `json:"market_hash_name"`
+	Rarity         Rarity   `json:"rarity"`
+	StatTrak       bool     `json:"stattrak"`
+	Exterior       Exterior `json:"exterior,omitempty"`
+	Probability    float64  `json:"probability"`
+	Price          int64    `json:"price"`           // Самый дешёвый лот на площадке; 0 - цены нет
+	SellerReceives int64    `json:"seller_receives"` // Price за вычетом комиссий площадки
+	Value          float64  `json:"value"`           // Вклад в ожидаемую выручку: Probability × SellerReceives
+}
+
+// statTrakVariant - обычная или StatTrak версия скина и её вероятность
+type statTrakVariant struct {
+	statTrak bool
+	p        float64
+}
+
+// ContainerOutcomes - все варианты выпадения кейса с вероятностями (без цен)
+func ContainerOutcomes(c *Container, collection *Collection) []ContainerOutcome {
+	var outcomes []ContainerOutcome
+
+	for _, rarity := range rarities {
+		tierP := c.DropOdds()[rarity]
+		pool := c.pool(collection, rarity)
+		if tierP <= 0 || len(pool) == 0 {
+			continue
+		}
+
+		for _, item := range pool {
+			itemP := tierP / float64(len(pool))
+
+			variants := []statTrakVariant{{false, itemP}}
+			if c.StatTrak && item.HasStatTrak() {
+				variants = []statTrakVariant{
+					{false, itemP * (1 - DefaultStatTrakChance)},
+					{true, itemP * DefaultStatTrakChance},
+				}
+			}
+
+			wear := []ExteriorShare{{Probability: 1}}
+			if !item.NoWear {
+				wear = WearDistribution(item.MinFloat, item.MaxFloat)
+			}
+
+			for _, variant := range variants {
+				for _, share := range wear {
+					outcomes = append(outcomes, ContainerOutcome{
+						MarketHashName: item.MarketHashName(variant.statTrak, share.Exterior),
+						Rarity:         rarity,
+						StatTrak:       variant.statTrak,
+						Exterior:       share.Exterior,
+						Probability:    variant.p * share.Probability,
+					})
+				}
+			}
+		}
+	}
+
+	return outcomes
+}
+
+// ContainerTier - итог по качеству
+type ContainerTier struct {
+	Rarity      Rarity  `json:"rarity"`
+	Probability float64 `json:"probability"`
+	ExpectedNet float64 `json:"expected_net"` // Вклад качества в ожидаемую выручку
+	Coverage    float64 `json:"coverage"`     // Доля вероятности качества, для которой есть цены
+}
+
+// ContainerEV - ожидаемая ценность открытия кейса
+//
+// Выпадения без цены на площадке в ожидание не входят, поэтому при Coverage < 1
+// ExpectedValue/ExpectedNet - оценка снизу. Profit и ROI считаются только по полной
+// картине: есть цена кейса и цены всех выпадений, иначе null. Суммы в сотых долях Currency.
+type ContainerEV struct {
+	ContainerID    string             `json:"container_id"`
+	Name           string             `json:"name"`
+	MarketHashName string             `json:"market_hash_name"`
+	Venue          string             `json:"venue"`
+	Currency       Currency           `json:"currency"`
+	CasePrice      int64              `json:"case_price"`
+	CasePriced     bool               `json:"case_priced"` // false - цены кейса на площадке нет
+	KeyPrice       int64              `json:"key_price"`
+	Cost           int64              `json:"cost"`           // Кейс + ключ; 0 - цена кейса неизвестна
+	ExpectedValue  float64            `json:"expected_value"` // Σ вероятность × цена
+	ExpectedNet    float64            `json:"expected_net"`   // Σ вероятность × цена за вычетом комиссий
+	Profit         *float64           `json:"profit"`         // ExpectedNet - Cost; nil - картина неполная
+	ROI            *float64           `json:"roi"`            // Profit / Cost, %; nil - картина неполная
+	Coverage       float64            `json:"coverage"`       // Доля вероятности выпадений с известной ценой
+	Tiers          []ContainerTier    `json:"tiers"`
+	Outcomes       []ContainerOutcome `json:"outcomes"` // По убыванию вклада
+	CalculatedAt   time.Time          `json:"calculated_at"`
+}
+
+// EvaluateContainer - ожидаемая ценность кейса по ценам выпадений
+//
+// prices - цена (самый дешёвый лот) по market_hash_name; sellerReceives - выручка продавца
+// на площадке при цене gross. Цены, кейс и ключ - уже в одной валюте; casePrice <= 0 - цены кейса нет.
+func EvaluateContainer(
+	c *Container,
+	outcomes []ContainerOutcome,
+	prices map[string]int64,
+	casePrice, keyPrice int64,
+	sellerReceives func(gross int64) int64,
+) *ContainerEV {
+	ev := &ContainerEV{
+		ContainerID:    c.ID,
+		Name:           c.Name,
+		MarketHashName: c.MarketHashName,
+		CasePrice:      max(casePrice, 0),
+		CasePriced:     casePrice > 0,
+		KeyPrice:       keyPrice,
+		Outcomes:       outcomes,
+		CalculatedAt:   time.Now().UTC(),
+	}
+
+	tiers := make(map[Rarity]*ContainerTier)
+	for i := range ev.Outcomes {
+		o := &ev.Outcomes[i]
+
+		tier, ok := tiers[o.Rarity]
+		if !ok {
+			tier = &ContainerTier{Rarity: o.Rarity}
+			tiers[o.Rarity] = tier
+		}
+		tier.Probability += o.Probability
+
+		price := prices[o.MarketHashName]
+		if price <= 0 {
+			continue
+		}
+		o.Price = price
+		o.SellerReceives = sellerReceives(price)
+		o.Value = o.Probability * float64(o.SellerReceives)
+
+		ev.ExpectedValue += o.Probability * float64(price)
+		ev.ExpectedNet += o.Value
+		ev.Coverage += o.Probability
+		tier.ExpectedNet += o.Value
+		tier.Coverage += o.Probability
+	}
+
+	for _, rarity := range rarities {
+		tier, ok := tiers[rarity]
+		if !ok {
+			continue
+		}
+		tier.Coverage = roundTo(tier.Coverage/tier.Probability, 4)
+		tier.Probability = roundTo(tier.Probability, 6)
+		tier.ExpectedNet = roundTo(tier.ExpectedNet, 2)
+		ev.Tiers = append(ev.Tiers, *tier)
+	}
+
+	// Без цены кейса или части выпадений прибыль была бы завышена - не выдаём её
+	if ev.CasePriced {
+		ev.Cost = casePrice + keyPrice
+		if ev.Coverage >= 1-1e-9 {
+			profit := ev.ExpectedNet - float64(ev.Cost)
+			roi := roundTo(100*profit/float64(ev.Cost), 2)
+			profit = roundTo(profit, 2)
+			ev.Profit, ev.ROI = &profit, &roi
+		}
+	}
+	ev.ExpectedValue = roundTo(ev.ExpectedValue, 2)
+	ev.ExpectedNet = roundTo(ev.ExpectedNet, 2)
+	ev.Coverage = roundTo(ev.Coverage, 4)
+
+	slices.SortStableFunc(ev.Outcomes, func(a, b ContainerOutcome) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	for i := range ev.Outcomes {
+		ev.Outcomes[i].Probability = roundTo(ev.Outcomes[i].Probability, 8)
+		ev.Outcomes[i].Value = roundTo(ev.Outcomes[i].Value, 4)
+	}
+
+	return ev
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWearDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		want     []ExteriorShare
+	}{
+		{"full range", 0, 1, []ExteriorShare{
+			{ExteriorFactoryNew, 0.07},
+			{ExteriorMinimalWear, 0.08},
+			{ExteriorFieldTested, 0.23},
+			{ExteriorWellWorn, 0.07},
+			{ExteriorBattleScarred, 0.55},
+		}},
+		{"partial range", 0.1, 0.5, []ExteriorShare{
+			{ExteriorMinimalWear, 0.125},
+			{ExteriorFieldTested, 0.575},
+			{ExteriorWellWorn, 0.175},
+			{ExteriorBattleScarred, 0.125},
+		}},
+		{"single exterior", 0, 0.07, []ExteriorShare{{ExteriorFactoryNew, 1}}},
+		{"fixed float", 0.2, 0.2, []ExteriorShare{{ExteriorFieldTested, 1}}},
+	}
+
+	for _, tt := range tests {
+		got := WearDistribution(tt.min, tt.max)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i].Exterior != tt.want[i].Exterior || math.Abs(got[i].Probability-tt.want[i].Probability) > 1e-9 {
+				t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// containerFixture - кейс с двумя армейскими, одним запрещённым скином и двумя редкими предметами
+func containerFixture() (*Container, *Collection) {
+	collection := &Collection{ID: "c", Name: "Test Collection", Items: []SkinItem{
+		{Name: "MP9 | Test", Rarity: RarityMilSpec, MinFloat: 0, MaxFloat: 0.07},
+		{Name: "P250 | Test", Rarity: RarityMilSpec, MinFloat: 0, MaxFloat: 1},
+		{Name: "AK-47 | Test", Rarity: RarityRestricted, MinFloat: 0.1, MaxFloat: 0.5},
+	}}
+	container := &Container{
+		ID:             "test_case",
+		Name:           "Test Case",
+		MarketHashName: "Test Case",
+		Collection:     collection.ID,
+		StatTrak:       true,
+		Odds: map[Rarity]float64{
+			RarityMilSpec:     0.8,
+			RarityRestricted:  0.15,
+			RarityRareSpecial: 0.05,
+		},
+		Rare: []SkinItem{
+			{Name: "★ Karambit", Rarity: RarityRareSpecial, NoWear: true},
+			{Name: "★ Sport Gloves | Test", Rarity: RarityRareSpecial, MinFloat: 0.06, MaxFloat: 0.8},
+		},
+	}
+	return container, collection
+}
+
+func TestContainerOutcomes(t *testing.T) {
+	container, collection := containerFixture()
+	if err := container.Validate(collection); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	outcomes := ContainerOutcomes(container, collection)
+
+	byName := make(map[string]ContainerOutcome, len(outcomes))
+	var total float64
+	for _, o := range outcomes {
+		if _, dup := byName[o.MarketHashName]; dup {
+			t.Errorf("duplicate outcome %q", o.MarketHashName)
+		}
+		byName[o.MarketHashName] = o
+		total += o.Probability
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("probabilities sum to %v, want 1", total)
+	}
+
+	tests := []struct {
+		name string
+		want float64
+	}{
+		// 0.8 / 2 скина × 0.9 без StatTrak, float только в Factory New
+		{"MP9 | Test (Factory New)", 0.36},
+		{"StatTrak™ MP9 | Test (Factory New)", 0.04},
+		{"StatTrak™ P250 | Test (Battle-Scarred)", 0.4 * 0.1 * 0.55},
+		{"AK-47 | Test (Field-Tested)", 0.15 * 0.9 * 0.575},
+		// Ванильный нож без износа в имени, StatTrak со звездой впереди
+		{"★ Karambit", 0.025 * 0.9},
+		{"★ StatTrak™ Karambit", 0.025 * 0.1},
+		// У перчаток StatTrak не бывает
+		{"★ Sport Gloves | Test (Factory New)", 0.025 * 0.01 / 0.74},
+	}
+	for _, tt := range tests {
+		o, ok := byName[tt.name]
+		if !ok {
+			t.Errorf("no outcome %q", tt.name)
+			continue
+		}
+		if math.Abs(o.Probability-tt.want) > 1e-12 {
+			t.Errorf("%s: probability %v, want %v", tt.name, o.Probability, tt.want)
+		}
+	}
+
+	for name, o := range byName {
+		if o.Rarity == RarityRareSpecial && o.StatTrak && o.Exterior != "" {
+			t.Errorf("%s: gloves must not have a StatTrak variant", name)
+		}
+		if o.MarketHashName == "★ Karambit" && o.Exterior != "" {
+			t.Errorf("vanilla knife has exterior %q", o.Exterior)
+		}
+	}
+}
+
+func TestContainerOutcomesWithoutStatTrak(t *testing.T) {
+	container, collection := containerFixture()
+	container.StatTrak = false
+
+	for _, o := range ContainerOutcomes(container, collection) {
+		if o.StatTrak {
+			t.Errorf("%s: StatTrak outcome in a case without StatTrak", o.MarketHashName)
+		}
+	}
+}
+
+func TestContainerValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Container, col *Collection)
+	}{
+		{"odds do not sum to 1", func(c *Container, _ *Collection) { c.Odds[RarityMilSpec] = 0.5 }},
+		{"no items for a tier", func(c *Container, _ *Collection) {
+			c.Odds = map[Rarity]float64{RarityMilSpec: 0.8, RarityClassified: 0.2}
+		}},
+		{"unknown rarity", func(c *Container, _ *Collection) {
+			delete(c.Odds, RarityRestricted)
+			c.Odds["legendary"] = 0.15
+		}},
+		{"invalid float range", func(_ *Container, col *Collection) { col.Items[0].MinFloat = 0.5 }},
+		{"missing id", func(c *Container, _ *Collection) { c.ID = "" }},
+	}
+
+	for _, tt := range tests {
+		container, collection := containerFixture()
+		tt.mutate(container, collection)
+		if err := container.Validate(collection); err == nil {
+			t.Errorf("%s: Validate = nil, want an error", tt.name)
+		}
+	}
+}
+
+func TestEvaluateContainer(t *testing.T) {
+	container, collection := containerFixture()
+	outcomes := ContainerOutcomes(container, collection)
+
+	allPrices := make(map[string]int64, len(outcomes))
+	for _, o := range outcomes {
+		allPrices[o.MarketHashName] = 100
+	}
+	withoutKnife := make(map[string]int64, len(allPrices))
+	for name, price := range allPrices {
+		if name != "★ Karambit" {
+			withoutKnife[name] = price
+		}
+	}
+	sellerReceives := func(gross int64) int64 { return gross - 10 }
+
+	tests := []struct {
+		name       string
+		prices     map[string]int64
+		casePrice  int64
+		wantCost   int64
+		wantProfit *float64
+		wantROI    *float64
+	}{
+		// Ожидаемая выручка 90, затраты 30 + 50 = 80
+		{"full picture", allPrices, 30, 80, ptr(10.0), ptr(12.5)},
+		{"case without price", allPrices, 0, 0, nil, nil},
+		{"outcome without price", withoutKnife, 30, 80, nil, nil},
+	}
+
+	for _, tt := range tests {
+		ev := EvaluateContainer(container, slices.Clone(outcomes), tt.prices, tt.casePrice, 50, sellerReceives)
+
+		if ev.CasePriced != (tt.casePrice > 0) || ev.Cost != tt.wantCost {
+			t.Errorf("%s: case priced %v, cost %d; want %v, %d", tt.name, ev.CasePriced, ev.Cost, tt.casePrice > 0, tt.wantCost)
+		}
+		if !equalPtr(ev.Profit, tt.wantProfit) || !equalPtr(ev.ROI, tt.wantROI) {
+			t.Errorf("%s: profit %v, roi %v; want %v, %v", tt.name, deref(ev.Profit), deref(ev.ROI), deref(tt.wantProfit), deref(tt.wantROI))
+		}
+	}
+
+	partial := EvaluateContainer(container, slices.Clone(outcomes), withoutKnife, 30, 50, sellerReceives)
+	if want := 1 - 0.025*0.9; math.Abs(partial.Coverage-want) > 1e-4 {
+		t.Errorf("coverage %v, want %v", partial.Coverage, want)
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func equalPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(*a-*b) < 1e-9
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
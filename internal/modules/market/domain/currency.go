@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrNoRate - нет курса для одной из валют конвертации
+var ErrNoRate = errors.New("no exchange rate")
+
+// ExchangeRates - курсы валют относительно канонической валюты Base
+//
+// Rates[c] - сколько единиц валюты c стоит 1 единица Base: при Base = USD
+// Rates[EUR] = 0.92 означает 1 USD = 0.92 EUR. Rates[Base] всегда 1.
+type ExchangeRates struct {
+	Base      Currency             `json:"base"`
+	Rates     map[Currency]float64 `json:"rates"`
+	Source    string               `json:"source"` // Имя источника курсов (static, file, ...)
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// NewExchangeRates - курсы с гарантированным Rates[base] = 1
+func NewExchangeRates(base Currency, rates map[Currency]float64, updatedAt time.Time) *ExchangeRates {
+	normalized := make(map[Currency]float64, len(rates)+1)
+	for c, r := range rates {
+		if r > 0 {
+			normalized[c] = r
+		}
+	}
+	normalized[base] = 1
+
+	return &ExchangeRates{Base: base, Rates: normalized, UpdatedAt: updatedAt}
+}
+
+// Rebase - те же курсы относительно другой базовой валюты
+// Нужен когда источник курсов отдаёт их, например, относительно EUR, а каноническая валюта - USD
+func (r *ExchangeRates) Rebase(base Currency) (*ExchangeRates, error) {
+	if base == r.Base {
+		return r, nil
+	}
+
+	pivot, ok := r.Rates[base]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRate, base.Code())
+	}
+
+	rebased := make(map[Currency]float64, len(r.Rates))
+	for c, rate := range r.Rates {
+		rebased[c] = rate / pivot
+	}
+
+	out := NewExchangeRates(base, rebased, r.UpdatedAt)
+	out.Source = r.Source
+	return out, nil
+}
+
+// Convert - переводит сумму в сотых долях из from в to с округлением до ближайшего
+func (r *ExchangeRates) Convert(amount int64, from, to Currency) (int64, error) {
+	if from == to || amount == 0 {
+		return amount, nil
+	}
+
+	fromRate, ok := r.Rates[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoRate, from.Code())
+	}
+	toRate, ok := r.Rates[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoRate, to.Code())
+	}
+
+	return int64(math.Round(float64(amount) * toRate / fromRate)), nil
+}
+
+// ConvertSnapshot - копия снимка с ценами в валюте to (объём не меняется)
+func (r *ExchangeRates) ConvertSnapshot(s PriceSnapshot, to Currency) (PriceSnapshot, error) {
+	if s.Currency == to {
+		return s, nil
+	}
+
+	var err error
+	if s.LowestPrice, err = r.Convert(s.LowestPrice, s.Currency, to); err != nil {
+		return s, err
+	}
+	if s.MedianPrice, err = r.Convert(s.MedianPrice, s.Currency, to); err != nil {
+		return s, err
+	}
+	s.Currency = to
+
+	return s, nil
+}
+
+// UserPreferences - персональные настройки пользователя в модуле market
+type UserPreferences struct {
+	UserID          string    `json:"user_id"`
+	DisplayCurrency Currency  `json:"display_currency"` // Во что конвертируются цены в ответах API
+	SteamID         string    `json:"steam_id"`         // Привязанный SteamID64 (для импорта инвентаря), "" = не привязан
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Validate - проверка настроек
+func (p *UserPreferences) Validate() error {
+	if p.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if !p.DisplayCurrency.IsKnown() {
+		return fmt.Errorf("%w: unsupported display currency %d", ErrValidation, int(p.DisplayCurrency))
+	}
+	if p.SteamID != "" {
+		if err := ValidateSteamID(p.SteamID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFiredAlert - копия срабатывания с ценовыми полями в валюте to
+// Message не меняется: это текст, который уже ушёл пользователю
+func (r *ExchangeRates) ConvertFiredAlert(a FiredAlert, to Currency) (FiredAlert, error) {
+	if a.Currency == to {
+		return a, nil
+	}
+
+	convert := func(v float64) (float64, error) {
+		converted, err := r.Convert(int64(math.Round(v)), a.Currency, to)
+		return float64(converted), err
+	}
+
+	var err error
+	if a.Price, err = r.Convert(a.Price, a.Currency, to); err != nil {
+		return a, err
+	}
+
+	switch a.Type {
+	case AlertPriceBelow, AlertPriceAbove:
+		if a.Threshold, err = convert(a.Threshold); err != nil {
+			return a, err
+		}
+		if a.Reference, err = convert(a.Reference); err != nil {
+			return a, err
+		}
+		if a.Observed, err = convert(a.Observed); err != nil {
+			return a, err
+		}
+	case AlertPercentChange:
+		// Reference - цена в начале окна; Observed - проценты, от валюты не зависят
+		if a.Reference, err = convert(a.Reference); err != nil {
+			return a, err
+		}
+	}
+	a.Currency = to
+
+	return a, nil
+}
+
+// ConvertLot - копия сделки с ценой и комиссией в валюте to
+func (r *ExchangeRates) ConvertLot(l Lot, to Currency) (Lot, error) {
+	if l.Currency == to {
+		return l, nil
+	}
+
+	var err error
+	if l.UnitPrice, err = r.Convert(l.UnitPrice, l.Currency, to); err != nil {
+		return l, err
+	}
+	if l.Fee, err = r.Convert(l.Fee, l.Currency, to); err != nil {
+		return l, err
+	}
+	l.Currency = to
+
+	return l, nil
+}
+
+// ConvertQuote - копия котировки с ценами в валюте to
+func (r *ExchangeRates) ConvertQuote(q VenueQuote, to Currency) (VenueQuote, error) {
+	if q.Currency == to {
+		return q, nil
+	}
+
+	var err error
+	if q.LowestPrice, err = r.Convert(q.LowestPrice, q.Currency, to); err != nil {
+		return q, err
+	}
+	if q.MedianPrice, err = r.Convert(q.MedianPrice, q.Currency, to); err != nil {
+		return q, err
+	}
+	q.Currency = to
+
+	return q, nil
+}
+
+// ConvertOrderBook - копия стакана с ценами уровней в валюте to (количества не меняются)
+func (r *ExchangeRates) ConvertOrderBook(b OrderBook, to Currency) (OrderBook, error) {
+	if b.Currency == to {
+		return b, nil
+	}
+
+	convert := func(levels []OrderBookLevel) ([]OrderBookLevel, error) {
+		converted := make([]OrderBookLevel, len(levels))
+		for i, level := range levels {
+			price, err := r.Convert(level.Price, b.Currency, to)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = OrderBookLevel{Price: price, Quantity: level.Quantity}
+		}
+		return converted, nil
+	}
+
+	var err error
+	if b.Bids, err = convert(b.Bids); err != nil {
+		return b, err
+	}
+	if b.Asks, err = convert(b.Asks); err != nil {
+		return b, err
+	}
+	b.Currency = to
+
+	return b, nil
+}
+
+// ConvertAnomaly - копия аномалии с ценами в валюте to (у volume цен нет, меняется только Currency)
+func (r *ExchangeRates) ConvertAnomaly(a Anomaly, to Currency) (Anomaly, error) {
+	if a.Currency == to {
+		return a, nil
+	}
+
+	if a.IsPrice() {
+		convert := func(v float64) (float64, error) {
+			converted, err := r.Convert(int64(math.Round(v)), a.Currency, to)
+			return float64(converted), err
+		}
+
+		var err error
+		if a.Value, err = convert(a.Value); err != nil {
+			return a, err
+		}
+		if a.Baseline, err = convert(a.Baseline); err != nil {
+			return a, err
+		}
+	}
+	a.Currency = to
+
+	return a, nil
+}
@@ -0,0 +1,136 @@
+package domain
+
+import "fmt"
+
+// FeeSchedule - модель комиссий Steam Community Market
+//
+// Комиссии считаются от суммы, которую получает продавец (net):
+//
+//	steam_fee     = max(floor(net * SteamFeeBps / 10000), SteamFeeMinimum) + SteamFeeBase
+//	publisher_fee = max(floor(net * PublisherFeeBps / 10000), 1)   (0 если ставка 0)
+//	buyer_pays    = net + steam_fee + publisher_fee
+//
+// Ставки в базисных пунктах (500 = 5%), чтобы округление было целочисленным
+// и совпадало со Steam до цента.
+type FeeSchedule struct {
+	SteamFeeBps     int64 `json:"steam_fee_bps"`
+	SteamFeeMinimum int64 `json:"steam_fee_minimum"`
+	SteamFeeBase    int64 `json:"steam_fee_base"`
+	PublisherFeeBps int64 `json:"publisher_fee_bps"`
+}
+
+// Комиссия Steam одинакова для всех игр
+const (
+	steamFeeBps      = 500 // 5%
+	steamFeeMinimum  = 1
+	defaultPublisher = 1000 // 10% - у CS2, Dota 2, TF2, Rust и большинства игр
+)
+
+// publisherFeeBps - игры с нестандартной комиссией издателя
+var publisherFeeBps = map[int]int64{}
+
+// FeeScheduleFor - комиссии для игры appID
+func FeeScheduleFor(appID int) FeeSchedule {
+	publisher, ok := publisherFeeBps[appID]
+	if !ok {
+		publisher = defaultPublisher
+	}
+
+	return FeeSchedule{
+		SteamFeeBps:     steamFeeBps,
+		SteamFeeMinimum: steamFeeMinimum,
+		PublisherFeeBps: publisher,
+	}
+}
+
+// FeeBreakdown - разложение цены сделки на получение продавца и комиссии
+type FeeBreakdown struct {
+	BuyerPays      int64 `json:"buyer_pays"`
+	SellerReceives int64 `json:"seller_receives"`
+	SteamFee       int64 `json:"steam_fee"`
+	PublisherFee   int64 `json:"publisher_fee"`
+	TotalFee       int64 `json:"total_fee"`
+}
+
+// MaxFeePrice - наибольшая сумма для калькулятора комиссий (10 млрд единиц валюты)
+// С запасом покрывает цены в любой валюте Steam и гарантирует, что net * bps не переполнит int64
+const MaxFeePrice int64 = 1_000_000_000_000
+
+// FromSellerReceives - сколько заплатит покупатель, чтобы продавец получил net
+func (f FeeSchedule) FromSellerReceives(net int64) (FeeBreakdown, error) {
+	if net < 1 || net > MaxFeePrice {
+		return FeeBreakdown{}, fmt.Errorf("%w: seller price must be between 1 and %d", ErrValidation, MaxFeePrice)
+	}
+	return f.breakdown(net), nil
+}
+
+// breakdown - комиссии для net из [1, MaxFeePrice]
+func (f FeeSchedule) breakdown(net int64) FeeBreakdown {
+	steamFee := max(net*f.SteamFeeBps/10000, f.SteamFeeMinimum) + f.SteamFeeBase
+
+	var publisherFee int64
+	if f.PublisherFeeBps > 0 {
+		publisherFee = max(net*f.PublisherFeeBps/10000, 1)
+	}
+
+	return FeeBreakdown{
+		BuyerPays:      net + steamFee + publisherFee,
+		SellerReceives: net,
+		SteamFee:       steamFee,
+		PublisherFee:   publisherFee,
+		TotalFee:       steamFee + publisherFee,
+	}
+}
+
+// MinBuyerPays - минимальная цена лота (продавец получает 1 цент)
+func (f FeeSchedule) MinBuyerPays() int64 {
+	return f.breakdown(1).BuyerPays
+}
+
+// FromBuyerPays - сколько получит продавец, если покупатель платит gross
+//
+// Обратная функция не всегда существует: из-за округления некоторые gross
+// недостижимы ни для какого net. Тогда, как и Steam, берём наибольший net
+// с buyer_pays < gross, а разницу добавляем к комиссии Steam.
+func (f FeeSchedule) FromBuyerPays(gross int64) (FeeBreakdown, error) {
+	if minimum := f.MinBuyerPays(); gross < minimum || gross > MaxFeePrice {
+		return FeeBreakdown{}, fmt.Errorf("%w: buyer price must be between %d and %d", ErrValidation, minimum, MaxFeePrice)
+	}
+
+	// buyer_pays(net) строго растёт, поэтому наибольший net с buyer_pays <= gross
+	// ищем бинарным поиском: buyer_pays(1) <= gross, а buyer_pays(gross) >= gross
+	lo, hi := int64(1), gross
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if f.breakdown(mid).BuyerPays <= gross {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	fees := f.breakdown(lo)
+	if diff := gross - fees.BuyerPays; diff > 0 {
+		fees.SteamFee += diff
+		fees.TotalFee += diff
+		fees.BuyerPays = gross
+	}
+
+	return fees, nil
+}
+
+// SellerReceivesAt - сколько продавец получит при продаже по цене gross (0 если цена ниже минимальной)
+func (f FeeSchedule) SellerReceivesAt(gross int64) int64 {
+	fees, err := f.FromBuyerPays(gross)
+	if err != nil {
+		return 0
+	}
+	return fees.SellerReceives
+}
+
+// FeeQuote - результат калькулятора комиссий для игры
+type FeeQuote struct {
+	AppID    int         `json:"app_id"`
+	Schedule FeeSchedule `json:"schedule"`
+	FeeBreakdown
+}
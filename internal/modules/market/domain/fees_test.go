@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFromSellerReceives(t *testing.T) {
+	fees := FeeScheduleFor(AppIDCS2)
+
+	tests := []struct {
+		net  int64
+		want FeeBreakdown
+	}{
+		{1, FeeBreakdown{BuyerPays: 3, SellerReceives: 1, SteamFee: 1, PublisherFee: 1, TotalFee: 2}},
+		{19, FeeBreakdown{BuyerPays: 21, SellerReceives: 19, SteamFee: 1, PublisherFee: 1, TotalFee: 2}},
+		{20, FeeBreakdown{BuyerPays: 23, SellerReceives: 20, SteamFee: 1, PublisherFee: 2, TotalFee: 3}},
+		{100, FeeBreakdown{BuyerPays: 115, SellerReceives: 100, SteamFee: 5, PublisherFee: 10, TotalFee: 15}},
+	}
+
+	for _, tt := range tests {
+		got, err := fees.FromSellerReceives(tt.net)
+		if err != nil {
+			t.Fatalf("FromSellerReceives(%d): %v", tt.net, err)
+		}
+		if got != tt.want {
+			t.Errorf("FromSellerReceives(%d) = %+v, want %+v", tt.net, got, tt.want)
+		}
+	}
+}
+
+func TestFromBuyerPays(t *testing.T) {
+	fees := FeeScheduleFor(AppIDCS2)
+
+	tests := []struct {
+		gross int64
+		want  FeeBreakdown
+	}{
+		{3, FeeBreakdown{BuyerPays: 3, SellerReceives: 1, SteamFee: 1, PublisherFee: 1, TotalFee: 2}},
+		{21, FeeBreakdown{BuyerPays: 21, SellerReceives: 19, SteamFee: 1, PublisherFee: 1, TotalFee: 2}},
+		// 22 недостижима: net 19 → 21, net 20 → 23; остаток уходит в комиссию Steam
+		{22, FeeBreakdown{BuyerPays: 22, SellerReceives: 19, SteamFee: 2, PublisherFee: 1, TotalFee: 3}},
+		{23, FeeBreakdown{BuyerPays: 23, SellerReceives: 20, SteamFee: 1, PublisherFee: 2, TotalFee: 3}},
+		{115, FeeBreakdown{BuyerPays: 115, SellerReceives: 100, SteamFee: 5, PublisherFee: 10, TotalFee: 15}},
+	}
+
+	for _, tt := range tests {
+		got, err := fees.FromBuyerPays(tt.gross)
+		if err != nil {
+			t.Fatalf("FromBuyerPays(%d): %v", tt.gross, err)
+		}
+		if got != tt.want {
+			t.Errorf("FromBuyerPays(%d) = %+v, want %+v", tt.gross, got, tt.want)
+		}
+	}
+}
+
+func TestFeeRoundTrip(t *testing.T) {
+	fees := FeeScheduleFor(AppIDCS2)
+
+	for net := int64(1); net <= 20000; net++ {
+		forward, err := fees.FromSellerReceives(net)
+		if err != nil {
+			t.Fatalf("FromSellerReceives(%d): %v", net, err)
+		}
+		back, err := fees.FromBuyerPays(forward.BuyerPays)
+		if err != nil {
+			t.Fatalf("FromBuyerPays(%d): %v", forward.BuyerPays, err)
+		}
+		if back != forward {
+			t.Fatalf("round trip for net %d: got %+v, want %+v", net, back, forward)
+		}
+	}
+
+	for gross := fees.MinBuyerPays(); gross <= 20000; gross++ {
+		got, err := fees.FromBuyerPays(gross)
+		if err != nil {
+			t.Fatalf("FromBuyerPays(%d): %v", gross, err)
+		}
+		if got.BuyerPays != gross || got.SellerReceives+got.TotalFee != gross {
+			t.Fatalf("FromBuyerPays(%d) = %+v: parts do not add up", gross, got)
+		}
+		if next, _ := fees.FromSellerReceives(got.SellerReceives + 1); next.BuyerPays <= gross {
+			t.Fatalf("FromBuyerPays(%d) = net %d, but net %d also fits", gross, got.SellerReceives, got.SellerReceives+1)
+		}
+	}
+}
+
+func TestFeeBounds(t *testing.T) {
+	fees := FeeScheduleFor(AppIDCS2)
+
+	for _, gross := range []int64{0, 2, MaxFeePrice + 1, 1_000_000_000_000_000} {
+		if _, err := fees.FromBuyerPays(gross); !errors.Is(err, ErrValidation) {
+			t.Errorf("FromBuyerPays(%d) error = %v, want ErrValidation", gross, err)
+		}
+	}
+	for _, net := range []int64{0, -1, MaxFeePrice + 1, 1_000_000_000_000_000} {
+		if _, err := fees.FromSellerReceives(net); !errors.Is(err, ErrValidation) {
+			t.Errorf("FromSellerReceives(%d) error = %v, want ErrValidation", net, err)
+		}
+	}
+
+	got, err := fees.FromBuyerPays(MaxFeePrice)
+	if err != nil {
+		t.Fatalf("FromBuyerPays(MaxFeePrice): %v", err)
+	}
+	if got.BuyerPays != MaxFeePrice || got.SellerReceives+got.TotalFee != MaxFeePrice {
+		t.Errorf("FromBuyerPays(MaxFeePrice) = %+v: parts do not add up", got)
+	}
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ограничения запроса прогноза
+const (
+	MaxForecastDays        = 30
+	DefaultForecastDays    = 7
+	MinForecastHistoryDays = 14
+	MaxForecastHistoryDays = 730
+	DefaultForecastHistory = 180
+)
+
+// ForecastConfidence - уровень доверительной полосы прогноза и соответствующий ему z
+const (
+	ForecastConfidence = 0.95
+	forecastZ          = 1.96
+)
+
+// ErrNotEnoughHistory - модели не хватает точек ряда, чтобы подобрать параметры
+var ErrNotEnoughHistory = errors.New("not enough price history")
+
+// ForecastBand - прогноз на один шаг вперёд: ожидаемое значение и доверительная полоса
+type ForecastBand struct {
+	Mean  float64
+	Lower float64
+	Upper float64
+}
+
+// ForecastModel - статистическая модель прогноза ряда с равным шагом
+//
+// Модель получает только значения (цены по дням, по возрастанию времени) и не знает
+// о валютах и датах. Новая модель подключается реализацией интерфейса и добавлением
+// в список, который сервис получает в DI.
+type ForecastModel interface {
+	// Name - имя модели в API (?models=ema,holt_winters)
+	Name() string
+
+	// MinPoints - сколько точек нужно, чтобы подобрать параметры
+	MinPoints() int
+
+	// Forecast - прогноз на horizon шагов; z - ширина полосы в стандартных ошибках
+	// Ряд короче MinPoints → ErrNotEnoughHistory
+	Forecast(values []float64, horizon int, z float64) ([]ForecastBand, error)
+}
+
+// ForecastPoint - прогноз на один день в ответе API (цены в сотых долях валюты прогноза)
+type ForecastPoint struct {
+	Date  time.Time `json:"date"`
+	Mean  int64     `json:"mean"`
+	Lower int64     `json:"lower"`
+	Upper int64     `json:"upper"`
+}
+
+// BacktestError - точность модели на истории
+//
+// Считается скользящим началом: модель обучается на ряде без последних k*horizon дней
+// и прогнозирует следующие horizon, для нескольких k. Ошибки в сотых долях валюты.
+type BacktestError struct {
+	Folds    int     `json:"folds"`    // Сколько раз повторён прогноз
+	Points   int     `json:"points"`   // Сколько прогнозных точек сравнено с фактом
+	MAE      float64 `json:"mae"`      // Средняя абсолютная ошибка
+	RMSE     float64 `json:"rmse"`     // Среднеквадратичная ошибка
+	MAPE     float64 `json:"mape"`     // Средняя абсолютная ошибка в процентах
+	Coverage float64 `json:"coverage"` // Доля фактических значений внутри полосы (в идеале ≈ ForecastConfidence)
+}
+
+// ModelForecast - прогноз одной модели
+// Если модель не смогла построить прогноз (мало истории), заполнен только Error
+type ModelForecast struct {
+	Model    string          `json:"model"`
+	Points   []ForecastPoint `json:"points,omitempty"`
+	Backtest *BacktestError  `json:"backtest,omitempty"` // nil - истории не хватает даже на один прогон
+	Error    string          `json:"error,omitempty"`
+}
+
+// ItemForecast - прогнозы всех запрошенных моделей по предмету
+type ItemForecast struct {
+	TrackedItemID  string          `json:"tracked_item_id"`
+	AppID          int             `json:"app_id"`
+	MarketHashName string          `json:"market_hash_name"`
+	Currency       Currency        `json:"currency"`
+	Days           int             `json:"days"`
+	Confidence     float64         `json:"confidence"`
+	HistoryPoints  int             `json:"history_points"` // Дней в ряде, на котором обучены модели
+	LastDate       time.Time       `json:"last_date"`      // Последний день истории (прогноз начинается со следующего)
+	LastPrice      int64           `json:"last_price"`
+	Best           string          `json:"best,omitempty"` // Модель с наименьшей MAPE на бэктесте
+	Models         []ModelForecast `json:"models"`
+}
+
+// DailySeries - дневной ряд цен из снимков: цена закрытия дня, пропущенные дни
+// заполняются ценой предыдущего (модели рассчитаны на равный шаг)
+//
+// Ожидает снимки по возрастанию времени в одной валюте. Возвращает значения и дату последнего дня.
+func DailySeries(snapshots []PriceSnapshot) ([]float64, time.Time) {
+	candles := BuildCandles(snapshots, Interval1d)
+	if len(candles) == 0 {
+		return nil, time.Time{}
+	}
+
+	day := 24 * time.Hour
+	values := []float64{float64(candles[0].Close)}
+	last := candles[0].Time
+	for _, candle := range candles[1:] {
+		for gap := last.Add(day); gap.Before(candle.Time); gap = gap.Add(day) {
+			values = append(values, values[len(values)-1])
+		}
+		values = append(values, float64(candle.Close))
+		last = candle.Time
+	}
+
+	return values, last
+}
+
+// RunForecast - прогноз модели на horizon дней после lastDate и её ошибка на истории
+func RunForecast(model ForecastModel, values []float64, horizon int, lastDate time.Time) ModelForecast {
+	result := ModelForecast{Model: model.Name()}
+
+	bands, err := model.Forecast(values, horizon, forecastZ)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Points = make([]ForecastPoint, len(bands))
+	for i, band := range bands {
+		result.Points[i] = ForecastPoint{
+			Date:  lastDate.AddDate(0, 0, i+1),
+			Mean:  roundPrice(band.Mean),
+			Lower: roundPrice(band.Lower),
+			Upper: roundPrice(band.Upper),
+		}
+	}
+	result.Backtest = Backtest(model, values, horizon)
+
+	return result
+}
+
+// maxBacktestFolds - сколько раз повторяется прогноз при бэктесте
+const maxBacktestFolds = 3
+
+// Backtest - ошибка прогноза модели на последних днях истории
+// nil - истории не хватает даже на один прогон
+func Backtest(model ForecastModel, values []float64, horizon int) *BacktestError {
+	var (
+		result            BacktestError
+		absSum, sqSum     float64
+		pctSum            float64
+		pctPoints, inside int
+	)
+
+	for fold := 1; fold <= maxBacktestFolds; fold++ {
+		cut := len(values) - fold*horizon
+		if cut < model.MinPoints() {
+			break
+		}
+
+		bands, err := model.Forecast(values[:cut], horizon, forecastZ)
+		if err != nil {
+			break
+		}
+
+		for i, band := range bands {
+			actual := values[cut+i]
+			diff := band.Mean - actual
+			absSum += math.Abs(diff)
+			sqSum += diff * diff
+			if actual != 0 {
+				pctSum += math.Abs(diff / actual)
+				pctPoints++
+			}
+			if actual >= band.Lower && actual <= band.Upper {
+				inside++
+			}
+			result.Points++
+		}
+		result.Folds++
+	}
+
+	if result.Points == 0 {
+		return nil
+	}
+
+	n := float64(result.Points)
+	result.MAE = roundTo(absSum/n, 2)
+	result.RMSE = roundTo(math.Sqrt(sqSum/n), 2)
+	if pctPoints > 0 {
+		result.MAPE = roundTo(100*pctSum/float64(pctPoints), 2)
+	}
+	result.Coverage = roundTo(float64(inside)/n, 3)
+
+	return &result
+}
+
+// BestForecast - модель с наименьшей MAPE на бэктесте ("" - ни у одной нет бэктеста)
+func BestForecast(forecasts []ModelForecast) string {
+	best := ""
+	bestMAPE := math.Inf(1)
+	for i := range forecasts {
+		if bt := forecasts[i].Backtest; bt != nil && bt.MAPE < bestMAPE {
+			best, bestMAPE = forecasts[i].Model, bt.MAPE
+		}
+	}
+	return best
+}
+
+// ValidateForecastRange - проверяет горизонт и глубину истории запроса
+func ValidateForecastRange(days, historyDays int) error {
+	if days < 1 || days > MaxForecastDays {
+		return fmt.Errorf("%w: days must be between 1 and %d", ErrValidation, MaxForecastDays)
+	}
+	if historyDays < MinForecastHistoryDays || historyDays > MaxForecastHistoryDays {
+		return fmt.Errorf("%w: history_days must be between %d and %d", ErrValidation, MinForecastHistoryDays, MaxForecastHistoryDays)
+	}
+	return nil
+}
+
+// roundPrice - прогноз в сотых долях; отрицательная цена не имеет смысла
+func roundPrice(v float64) int64 {
+	return max(int64(math.Round(v)), 0)
+}
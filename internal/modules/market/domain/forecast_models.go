@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Имена моделей прогноза
+const (
+	ModelEMA         = "ema"
+	ModelHoltWinters = "holt_winters"
+	ModelLogLinear   = "log_linear"
+)
+
+// DefaultForecastModels - модели, доступные в API, в порядке вывода
+func DefaultForecastModels() []ForecastModel {
+	return []ForecastModel{
+		EMAModel{},
+		HoltWintersModel{Period: 7},
+		LogLinearModel{},
+	}
+}
+
+// EMAModel - простое экспоненциальное сглаживание
+//
+// Прогноз - последний сглаженный уровень (без тренда). Коэффициент сглаживания
+// подбирается по сетке по минимуму ошибки прогноза на шаг вперёд.
+type EMAModel struct{}
+
+func (EMAModel) Name() string   { return ModelEMA }
+func (EMAModel) MinPoints() int { return 8 }
+
+func (m EMAModel) Forecast(values []float64, horizon int, z float64) ([]ForecastBand, error) {
+	if len(values) < m.MinPoints() {
+		return nil, fmt.Errorf("%w: %s needs at least %d days", ErrNotEnoughHistory, m.Name(), m.MinPoints())
+	}
+
+	bestSSE := math.Inf(1)
+	var bestAlpha, bestLevel float64
+	for alpha := 0.05; alpha < 1; alpha += 0.05 {
+		level, sse := values[0], 0.0
+		for _, v := range values[1:] {
+			e := v - level
+			sse += e * e
+			level += alpha * e
+		}
+		if sse < bestSSE {
+			bestSSE, bestAlpha, bestLevel = sse, alpha, level
+		}
+	}
+
+	sigma := math.Sqrt(bestSSE / float64(len(values)-1))
+	bands := make([]ForecastBand, horizon)
+	for h := 1; h <= horizon; h++ {
+		// Дисперсия ошибки на h шагов у SES: σ²(1 + (h-1)α²)
+		se := sigma * math.Sqrt(1+float64(h-1)*bestAlpha*bestAlpha)
+		bands[h-1] = ForecastBand{Mean: bestLevel, Lower: bestLevel - z*se, Upper: bestLevel + z*se}
+	}
+
+	return bands, nil
+}
+
+// HoltWintersModel - аддитивная модель Хольта-Уинтерса: уровень, тренд и сезонность
+// с периодом Period дней (недельный цикл активности на торговой площадке)
+//
+// Параметры сглаживания подбираются по сетке по минимуму ошибки на шаг вперёд.
+type HoltWintersModel struct {
+	Period int
+}
+
+func (HoltWintersModel) Name() string { return ModelHoltWinters }
+
+// MinPoints - две полные сезонности для начальных оценок и хотя бы пара точек для подбора
+func (m HoltWintersModel) MinPoints() int { return 2*m.Period + 2 }
+
+// Сетка параметров сглаживания: уровень, тренд, сезонность
+var (
+	hwAlphas = []float64{0.1, 0.3, 0.5, 0.7, 0.9}
+	hwBetas  = []float64{0.01, 0.05, 0.1, 0.2}
+	hwGammas = []float64{0.05, 0.1, 0.2, 0.4}
+)
+
+// holtWintersState - состояние модели после прохода по ряду
+type holtWintersState struct {
+	level, trend float64
+	season       []float64
+	sse          float64
+	steps        int
+}
+
+func (m HoltWintersModel) Forecast(values []float64, horizon int, z float64) ([]ForecastBand, error) {
+	if len(values) < m.MinPoints() {
+		return nil, fmt.Errorf("%w: %s needs at least %d days", ErrNotEnoughHistory, m.Name(), m.MinPoints())
+	}
+
+	var (
+		best               *holtWintersState
+		alpha, beta, gamma float64
+		bestSSE            = math.Inf(1)
+	)
+	for _, a := range hwAlphas {
+		for _, b := range hwBetas {
+			for _, g := range hwGammas {
+				state := m.fit(values, a, b, g)
+				if state.sse < bestSSE {
+					best, bestSSE = state, state.sse
+					alpha, beta, gamma = a, b, g
+				}
+			}
+		}
+	}
+
+	p := m.Period
+	n := len(values)
+	sigma := math.Sqrt(best.sse / float64(best.steps))
+
+	bands := make([]ForecastBand, horizon)
+	variance := 1.0
+	for h := 1; h <= horizon; h++ {
+		// Дисперсия ошибки на h шагов: σ²(1 + Σ c_j²), c_j = α(1 + jβ) + γ·[j кратно периоду]
+		if j := h - 1; j > 0 {
+			c := alpha * (1 + float64(j)*beta)
+			if j%p == 0 {
+				c += gamma
+			}
+			variance += c * c
+		}
+		mean := best.level + float64(h)*best.trend + best.season[(n+h-1)%p]
+		se := sigma * math.Sqrt(variance)
+		bands[h-1] = ForecastBand{Mean: mean, Lower: mean - z*se, Upper: mean + z*se}
+	}
+
+	return bands, nil
+}
+
+// fit - проход по ряду с заданными параметрами
+// Начальные уровень и сезонность - по первому периоду, тренд - по разнице первых двух
+func (m HoltWintersModel) fit(values []float64, alpha, beta, gamma float64) *holtWintersState {
+	p := m.Period
+
+	var first, second float64
+	for i := range p {
+		first += values[i]
+		second += values[p+i]
+	}
+	first /= float64(p)
+	second /= float64(p)
+
+	state := &holtWintersState{
+		level:  first,
+		trend:  (second - first) / float64(p),
+		season: make([]float64, p),
+	}
+	for i := range p {
+		state.season[i] = values[i] - first
+	}
+
+	for t := p; t < len(values); t++ {
+		s := state.season[t%p]
+		e := values[t] - (state.level + state.trend + s)
+		state.sse += e * e
+		state.steps++
+
+		level := alpha*(values[t]-s) + (1-alpha)*(state.level+state.trend)
+		state.trend = beta*(level-state.level) + (1-beta)*state.trend
+		state.season[t%p] = gamma*(values[t]-level) + (1-gamma)*s
+		state.level = level
+	}
+
+	return state
+}
+
+// LogLinearModel - линейная регрессия логарифма цены по времени (постоянный темп роста)
+//
+// Полоса - интервал предсказания регрессии в логарифмах, поэтому она несимметрична
+// и не уходит в отрицательные цены. Mean - медиана прогноза (exp от прогноза логарифма).
+type LogLinearModel struct{}
+
+func (LogLinearModel) Name() string   { return ModelLogLinear }
+func (LogLinearModel) MinPoints() int { return 7 }
+
+func (m LogLinearModel) Forecast(values []float64, horizon int, z float64) ([]ForecastBand, error) {
+	n := len(values)
+	if n < m.MinPoints() {
+		return nil, fmt.Errorf("%w: %s needs at least %d days", ErrNotEnoughHistory, m.Name(), m.MinPoints())
+	}
+
+	logs := make([]float64, n)
+	for i, v := range values {
+		if v <= 0 {
+			return nil, fmt.Errorf("%s: price history has non-positive values", m.Name())
+		}
+		logs[i] = math.Log(v)
+	}
+
+	xMean := float64(n-1) / 2
+	var yMean float64
+	for _, y := range logs {
+		yMean += y
+	}
+	yMean /= float64(n)
+
+	var sxx, sxy float64
+	for i, y := range logs {
+		dx := float64(i) - xMean
+		sxx += dx * dx
+		sxy += dx * (y - yMean)
+	}
+	slope := sxy / sxx
+	intercept := yMean - slope*xMean
+
+	var ssr float64
+	for i, y := range logs {
+		r := y - (intercept + slope*float64(i))
+		ssr += r * r
+	}
+	s := math.Sqrt(ssr / float64(n-2))
+
+	bands := make([]ForecastBand, horizon)
+	for h := 1; h <= horizon; h++ {
+		x := float64(n - 1 + h)
+		y := intercept + slope*x
+		se := s * math.Sqrt(1+1/float64(n)+(x-xMean)*(x-xMean)/sxx)
+		bands[h-1] = ForecastBand{Mean: math.Exp(y), Lower: math.Exp(y - z*se), Upper: math.Exp(y + z*se)}
+	}
+
+	return bands, nil
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// seasonalSeries - weeks недель ряда 100 + недельная сезонность с нулевой суммой
+func seasonalSeries(weeks int) []float64 {
+	season := []float64{-3, -2, -1, 0, 1, 2, 3}
+	values := make([]float64, 0, weeks*len(season))
+	for range weeks {
+		for _, s := range season {
+			values = append(values, 100+s)
+		}
+	}
+	return values
+}
+
+func constantSeries(n int, v float64) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = v
+	}
+	return values
+}
+
+func TestForecastModelsNotEnoughHistory(t *testing.T) {
+	for _, model := range DefaultForecastModels() {
+		values := constantSeries(model.MinPoints()-1, 100)
+		if _, err := model.Forecast(values, 3, forecastZ); !errors.Is(err, ErrNotEnoughHistory) {
+			t.Errorf("%s: error = %v, want ErrNotEnoughHistory", model.Name(), err)
+		}
+		if _, err := model.Forecast(constantSeries(model.MinPoints(), 100), 3, forecastZ); err != nil {
+			t.Errorf("%s with %d points: %v", model.Name(), model.MinPoints(), err)
+		}
+	}
+}
+
+func TestEMAModelConstantSeries(t *testing.T) {
+	bands, err := EMAModel{}.Forecast(constantSeries(30, 250), 5, forecastZ)
+	if err != nil {
+		t.Fatalf("Forecast: %v", err)
+	}
+	if len(bands) != 5 {
+		t.Fatalf("got %d bands, want 5", len(bands))
+	}
+	for i, band := range bands {
+		if band != (ForecastBand{Mean: 250, Lower: 250, Upper: 250}) {
+			t.Errorf("band %d = %+v, want a flat 250", i, band)
+		}
+	}
+}
+
+func TestEMAModelBandWidens(t *testing.T) {
+	values := []float64{100, 104, 98, 103, 99, 105, 97, 102, 100, 104, 98, 101}
+	bands, err := EMAModel{}.Forecast(values, 5, forecastZ)
+	if err != nil {
+		t.Fatalf("Forecast: %v", err)
+	}
+	for i := 1; i < len(bands); i++ {
+		if bands[i].Mean != bands[0].Mean {
+			t.Errorf("band %d mean %v, want the flat level %v", i, bands[i].Mean, bands[0].Mean)
+		}
+		if bands[i].Upper-bands[i].Lower < bands[i-1].Upper-bands[i-1].Lower {
+			t.Errorf("band %d is narrower than band %d", i, i-1)
+		}
+	}
+}
+
+func TestHoltWintersModelSeasonalSeries(t *testing.T) {
+	values := seasonalSeries(6)
+	want := seasonalSeries(8)[len(values):]
+
+	bands, err := HoltWintersModel{Period: 7}.Forecast(values, 14, forecastZ)
+	if err != nil {
+		t.Fatalf("Forecast: %v", err)
+	}
+	// Ряд без шума и тренда модель продолжает точно
+	for i, band := range bands {
+		if math.Abs(band.Mean-want[i]) > 1e-9 {
+			t.Errorf("day %d: mean %v, want %v", i+1, band.Mean, want[i])
+		}
+		if band.Lower > band.Mean || band.Upper < band.Mean {
+			t.Errorf("day %d: band %+v does not contain the mean", i+1, band)
+		}
+	}
+}
+
+func TestLogLinearModelGrowth(t *testing.T) {
+	values := make([]float64, 30)
+	for i := range values {
+		values[i] = 100 * math.Pow(1.01, float64(i))
+	}
+
+	bands, err := LogLinearModel{}.Forecast(values, 3, forecastZ)
+	if err != nil {
+		t.Fatalf("Forecast: %v", err)
+	}
+	for h, band := range bands {
+		want := 100 * math.Pow(1.01, float64(len(values)+h))
+		if math.Abs(band.Mean-want) > 1e-6 {
+			t.Errorf("day %d: mean %v, want %v", h+1, band.Mean, want)
+		}
+	}
+
+	values[5] = 0
+	if _, err := (LogLinearModel{}).Forecast(values, 3, forecastZ); err == nil {
+		t.Error("Forecast with a zero price: want an error")
+	}
+}
+
+func TestLogLinearModelBandIsPositive(t *testing.T) {
+	values := []float64{10, 30, 5, 40, 8, 35, 6, 25, 12}
+	bands, err := LogLinearModel{}.Forecast(values, 7, forecastZ)
+	if err != nil {
+		t.Fatalf("Forecast: %v", err)
+	}
+	for i, band := range bands {
+		if band.Lower <= 0 || band.Lower >= band.Mean || band.Upper <= band.Mean {
+			t.Errorf("day %d: band %+v, want 0 < lower < mean < upper", i+1, band)
+		}
+	}
+}
+
+func TestBacktest(t *testing.T) {
+	got := Backtest(EMAModel{}, constantSeries(40, 100), 7)
+	want := &BacktestError{Folds: 3, Points: 21, Coverage: 1}
+	if got == nil || *got != *want {
+		t.Errorf("Backtest = %+v, want %+v", got, want)
+	}
+
+	// 8 точек без последних 7 - меньше MinPoints у EMA
+	if got := Backtest(EMAModel{}, constantSeries(14, 100), 7); got != nil {
+		t.Errorf("Backtest on a short series = %+v, want nil", got)
+	}
+
+	// Хватает только на один прогон
+	if got := Backtest(EMAModel{}, constantSeries(16, 100), 7); got == nil || got.Folds != 1 {
+		t.Errorf("Backtest = %+v, want a single fold", got)
+	}
+}
+
+func TestBacktestErrors(t *testing.T) {
+	// Константа, затем ступенька: EMA продолжает 100, факт - 110
+	values := append(constantSeries(20, 100), constantSeries(3, 110)...)
+	values = append(values, constantSeries(4, 100)...)
+
+	got := Backtest(EMAModel{}, values, 3)
+	if got == nil || got.Folds != 3 {
+		t.Fatalf("Backtest = %+v, want 3 folds", got)
+	}
+	if got.MAE <= 0 || got.RMSE < got.MAE || got.MAPE <= 0 {
+		t.Errorf("Backtest = %+v, want positive errors with RMSE >= MAE", got)
+	}
+}
+
+func TestRunForecast(t *testing.T) {
+	lastDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got := RunForecast(EMAModel{}, constantSeries(30, 99.6), 2, lastDate)
+	if got.Model != ModelEMA || got.Error != "" || got.Backtest == nil {
+		t.Fatalf("RunForecast = %+v", got)
+	}
+	want := []ForecastPoint{
+		{Date: lastDate.AddDate(0, 0, 1), Mean: 100, Lower: 100, Upper: 100},
+		{Date: lastDate.AddDate(0, 0, 2), Mean: 100, Lower: 100, Upper: 100},
+	}
+	for i := range want {
+		if got.Points[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, got.Points[i], want[i])
+		}
+	}
+
+	short := RunForecast(EMAModel{}, constantSeries(3, 100), 2, lastDate)
+	if short.Error == "" || short.Points != nil || short.Backtest != nil {
+		t.Errorf("RunForecast on a short series = %+v, want only an error", short)
+	}
+}
+
+func TestBestForecast(t *testing.T) {
+	forecasts := []ModelForecast{
+		{Model: ModelEMA, Backtest: &BacktestError{MAPE: 4.2}},
+		{Model: ModelHoltWinters, Error: "not enough price history"},
+		{Model: ModelLogLinear, Backtest: &BacktestError{MAPE: 1.5}},
+	}
+	if got := BestForecast(forecasts); got != ModelLogLinear {
+		t.Errorf("BestForecast = %q, want %q", got, ModelLogLinear)
+	}
+	if got := BestForecast(forecasts[1:2]); got != "" {
+		t.Errorf("BestForecast without backtests = %q, want empty", got)
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	day := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	snapshots := []PriceSnapshot{
+		{ObservedAt: day.Add(2 * time.Hour), LowestPrice: 100},
+		{ObservedAt: day.Add(20 * time.Hour), LowestPrice: 120},
+		// 11 и 12 января без снимков
+		{ObservedAt: day.AddDate(0, 0, 3).Add(time.Hour), LowestPrice: 90},
+	}
+
+	values, last := DailySeries(snapshots)
+	want := []float64{120, 120, 120, 90}
+	if len(values) != len(want) {
+		t.Fatalf("values = %v, want %v", values, want)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("values = %v, want %v", values, want)
+			break
+		}
+	}
+	if !last.Equal(day.AddDate(0, 0, 3)) {
+		t.Errorf("last date = %v, want %v", last, day.AddDate(0, 0, 3))
+	}
+
+	if values, last := DailySeries(nil); values != nil || !last.IsZero() {
+		t.Errorf("DailySeries(nil) = %v, %v; want nothing", values, last)
+	}
+}
+
+func TestValidateForecastRange(t *testing.T) {
+	tests := []struct {
+		days, history int
+		valid         bool
+	}{
+		{DefaultForecastDays, DefaultForecastHistory, true},
+		{1, MinForecastHistoryDays, true},
+		{MaxForecastDays, MaxForecastHistoryDays, true},
+		{0, DefaultForecastHistory, false},
+		{MaxForecastDays + 1, DefaultForecastHistory, false},
+		{DefaultForecastDays, MinForecastHistoryDays - 1, false},
+		{DefaultForecastDays, MaxForecastHistoryDays + 1, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateForecastRange(tt.days, tt.history)
+		if tt.valid != (err == nil) || (err != nil && !errors.Is(err, ErrValidation)) {
+			t.Errorf("ValidateForecastRange(%d, %d) = %v, valid %v", tt.days, tt.history, err, tt.valid)
+		}
+	}
+}
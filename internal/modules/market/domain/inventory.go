@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContextIDCS2 - контекст инвентаря CS2 с предметами маркета
+const ContextIDCS2 = "2"
+
+// steamID64Base - наименьший SteamID64 индивидуального аккаунта (universe 1, type 1)
+const steamID64Base = 76561197960265728
+
+// ValidateSteamID - проверка SteamID64 ("76561198000000000")
+func ValidateSteamID(steamID string) error {
+	id, err := strconv.ParseUint(steamID, 10, 64)
+	if err != nil || len(steamID) != 17 || id <= steamID64Base {
+		return fmt.Errorf("%w: steam_id must be a SteamID64 (17 digits, e.g. 76561198000000000)", ErrValidation)
+	}
+	return nil
+}
+
+// InventoryAsset - экземпляр предмета в инвентаре Steam
+// Один и тот же market_hash_name встречается в инвентаре столько раз, сколько копий у пользователя
+type InventoryAsset struct {
+	AssetID        string `json:"asset_id"`
+	AppID          int    `json:"app_id"`
+	ContextID      string `json:"context_id"`
+	MarketHashName string `json:"market_hash_name"`
+	Name           string `json:"name"`
+	Amount         int64  `json:"amount"`     // >1 только у стакающихся предметов
+	Marketable     bool   `json:"marketable"` // Можно ли выставить на маркет
+	Tradable       bool   `json:"tradable"`
+	IconURL        string `json:"icon_url"`
+	Rarity         string `json:"rarity"` // Тег Rarity из описания Steam ("Covert")
+}
+
+// Key - ключ предмета на маркете
+func (a *InventoryAsset) Key() ItemKey {
+	return ItemKey{AppID: a.AppID, MarketHashName: a.MarketHashName}
+}
+
+// InventoryPosition - все копии одного предмета инвентаря с оценкой
+type InventoryPosition struct {
+	AppID          int        `json:"app_id"`
+	MarketHashName string     `json:"market_hash_name"`
+	Name           string     `json:"name"`
+	IconURL        string     `json:"icon_url"`
+	Rarity         string     `json:"rarity"`
+	Quantity       int64      `json:"quantity"`
+	Marketable     bool       `json:"marketable"`
+	UnitPrice      int64      `json:"unit_price"`  // 0 = цена неизвестна
+	TotalValue     int64      `json:"total_value"` // UnitPrice * Quantity
+	PricedAt       *time.Time `json:"priced_at"`   // Время снимка, по которому оценено
+	TrackedItemID  string     `json:"tracked_item_id,omitempty"`
+}
+
+// Key - ключ предмета на маркете
+func (p *InventoryPosition) Key() ItemKey {
+	return ItemKey{AppID: p.AppID, MarketHashName: p.MarketHashName}
+}
+
+// InventoryValuation - оценка импортированного инвентаря
+type InventoryValuation struct {
+	SteamID      string              `json:"steam_id"`
+	AppID        int                 `json:"app_id"`
+	ContextID    string              `json:"context_id"`
+	Currency     Currency            `json:"currency"`
+	TotalValue   int64               `json:"total_value"`
+	TotalItems   int64               `json:"total_items"`   // Сумма Quantity
+	PricedItems  int64               `json:"priced_items"`  // Из них с известной ценой
+	NewlyTracked int                 `json:"newly_tracked"` // Сколько позиций добавлено в отслеживаемые
+	Positions    []InventoryPosition `json:"positions"`     // Дорогие сверху
+	ImportedAt   time.Time           `json:"imported_at"`
+}
+
+// GroupInventory - сворачивает экземпляры в позиции по market_hash_name
+// Предметы без market_hash_name (служебные, медали без описания) пропускаются
+func GroupInventory(assets []InventoryAsset) []InventoryPosition {
+	index := map[ItemKey]int{}
+	positions := []InventoryPosition{}
+
+	for i := range assets {
+		a := &assets[i]
+		if strings.TrimSpace(a.MarketHashName) == "" {
+			continue
+		}
+
+		amount := max(a.Amount, 1)
+		if pos, ok := index[a.Key()]; ok {
+			positions[pos].Quantity += amount
+			positions[pos].Marketable = positions[pos].Marketable || a.Marketable
+			continue
+		}
+
+		index[a.Key()] = len(positions)
+		positions = append(positions, InventoryPosition{
+			AppID:          a.AppID,
+			MarketHashName: a.MarketHashName,
+			Name:           a.Name,
+			IconURL:        a.IconURL,
+			Rarity:         a.Rarity,
+			Quantity:       amount,
+			Marketable:     a.Marketable,
+		})
+	}
+
+	return positions
+}
+
+// CatalogItem - карточка каталога по данным инвентаря (иконка и редкость из описания Steam)
+func (p *InventoryPosition) CatalogItem() *CatalogItem {
+	item := NewCatalogItem(p.AppID, p.MarketHashName, p.Name)
+	item.IconURL = p.IconURL
+	item.Rarity = p.Rarity
+	return item
+}
+
+// Price - проставляет цену позиции по снимку (lowest, иначе median)
+func (p *InventoryPosition) Price(snapshot *PriceSnapshot) {
+	p.UnitPrice = snapshot.Price()
+	p.TotalValue = p.UnitPrice * p.Quantity
+	observedAt := snapshot.ObservedAt
+	p.PricedAt = &observedAt
+}
+
+// Summarize - считает итоги и сортирует позиции по стоимости (дорогие сверху)
+func (v *InventoryValuation) Summarize() {
+	v.TotalValue, v.TotalItems, v.PricedItems = 0, 0, 0
+	for i := range v.Positions {
+		p := &v.Positions[i]
+		v.TotalItems += p.Quantity
+		if p.UnitPrice > 0 {
+			v.TotalValue += p.TotalValue
+			v.PricedItems += p.Quantity
+		}
+	}
+
+	sort.SliceStable(v.Positions, func(i, j int) bool {
+		if v.Positions[i].TotalValue != v.Positions[j].TotalValue {
+			return v.Positions[i].TotalValue > v.Positions[j].TotalValue
+		}
+		return v.Positions[i].MarketHashName < v.Positions[j].MarketHashName
+	})
+}
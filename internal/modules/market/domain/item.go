@@ -1,8 +1,159 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// AppIDCS2 - appid Counter-Strike 2 в Steam (по умолчанию для новых предметов)
+const AppIDCS2 = 730
+
+// TrackedItem - предмет Steam Community Market, за которым следит пользователь
+//
+// Предмет однозначно определяется парой (AppID, MarketHashName):
+// именно её Steam принимает в priceoverview / pricehistory.
+// Один и тот же предмет пользователь может отслеживать только один раз.
 type TrackedItem struct {
-	ID     string
-	Name   string
-	UserID string
-	// позже: цена, steamId предмета, и т.п.
+	ID             string         `json:"id"`
+	UserID         string         `json:"user_id"`
+	AppID          int            `json:"app_id"`
+	MarketHashName string         `json:"market_hash_name"` // "AK-47 | Redline (Field-Tested)"
+	Name           string         `json:"name"`             // Отображаемое имя (по умолчанию = MarketHashName)
+	Notes          string         `json:"notes"`            // Заметка пользователя
+	Tags           []string       `json:"tags"`             // В нижнем регистре, по алфавиту
+	WatchlistIDs   []string       `json:"watchlist_ids"`    // Списки, в которых состоит предмет (только чтение)
+	Liquidity      *ItemLiquidity `json:"liquidity"`        // nil - ещё не посчитана (только чтение)
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// ErrValidation - базовая ошибка нарушения инвариантов домена market
+// Все ошибки валидации оборачивают её, чтобы HTTP слой мог
+// одной проверкой errors.Is(err, domain.ErrValidation) отдать 400
+var ErrValidation = errors.New("validation error")
+
+// Ошибки валидации TrackedItem
+var (
+	ErrEmptyMarketHashName = fmt.Errorf("%w: market_hash_name is required", ErrValidation)
+	ErrInvalidAppID        = fmt.Errorf("%w: app_id must be positive", ErrValidation)
+	ErrEmptyUserID         = fmt.Errorf("%w: user_id is required", ErrValidation)
+)
+
+// NewTrackedItem - фабричный метод для нового отслеживаемого предмета
+// Если appID не указан (0) - считаем что это CS2
+// Если name пустой - используем market_hash_name
+func NewTrackedItem(userID string, appID int, marketHashName, name, notes string) *TrackedItem {
+	now := time.Now()
+
+	if appID == 0 {
+		appID = AppIDCS2
+	}
+
+	marketHashName = strings.TrimSpace(marketHashName)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = marketHashName
+	}
+
+	return &TrackedItem{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		Name:           name,
+		Notes:          strings.TrimSpace(notes),
+		Tags:           []string{},
+		WatchlistIDs:   []string{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Validate - проверка инвариантов отслеживаемого предмета
+func (i *TrackedItem) Validate() error {
+	if i.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if i.AppID <= 0 {
+		return ErrInvalidAppID
+	}
+	if i.MarketHashName == "" {
+		return ErrEmptyMarketHashName
+	}
+	return nil
+}
+
+// Rename - меняет отображаемое имя (пустое имя сбрасывает к market_hash_name)
+func (i *TrackedItem) Rename(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = i.MarketHashName
+	}
+	i.Name = name
+	i.UpdatedAt = time.Now()
+}
+
+// UpdateNotes - обновляет заметку пользователя
+func (i *TrackedItem) UpdateNotes(notes string) {
+	i.Notes = strings.TrimSpace(notes)
+	i.UpdatedAt = time.Now()
+}
+
+// SetTags - заменяет теги предмета (nil/пустой список снимает все)
+func (i *TrackedItem) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	i.Tags = normalized
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// Ограничения на теги
+const (
+	MaxTagsPerItem = 20
+	MaxTagLength   = 32
+)
+
+// NormalizeTags - теги в нижнем регистре без пробелов по краям, без повторов, по алфавиту
+// Запятая запрещена: теги перечисляются через неё в query параметрах и CSV
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		switch {
+		case tag == "":
+			return nil, fmt.Errorf("%w: tag must not be empty", ErrValidation)
+		case utf8.RuneCountInString(tag) > MaxTagLength:
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrValidation, tag, MaxTagLength)
+		case strings.ContainsRune(tag, ','):
+			return nil, fmt.Errorf("%w: tag %q must not contain commas", ErrValidation, tag)
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > MaxTagsPerItem {
+		return nil, fmt.Errorf("%w: at most %d tags per item", ErrValidation, MaxTagsPerItem)
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// TrackedItemFilter - фильтр списка отслеживаемых предметов
+// Пустые поля не ограничивают выборку; Tags - предмет должен иметь все перечисленные
+// Sort/Reverse задают порядок выдачи (пустой Sort - новые сверху)
+type TrackedItemFilter struct {
+	WatchlistID string
+	Tags        []string
+	Sort        TrackedItemSort
+	Reverse     bool
 }
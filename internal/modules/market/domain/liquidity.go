@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LiquidityWindowDays - за сколько последних полных дней считается ликвидность
+const LiquidityWindowDays = 30
+
+// liquidityTrendDays - короткое окно, которое сравнивается со всем окном в VolumeTrend
+const liquidityTrendDays = 7
+
+// liquidityFullVolume - продаж в день, при которых объём даёт максимум в оценке
+// Шкала логарифмическая: 5 продаж в день уже заметно лучше одной, 500 и 1000 почти не отличаются
+const liquidityFullVolume = 500
+
+// LiquiditySource - откуда взяты продажи
+type LiquiditySource string
+
+const (
+	// LiquidityPriceHistory - история продаж Steam (pricehistory, нужен steamLoginSecure)
+	LiquidityPriceHistory LiquiditySource = "price_history"
+	// LiquiditySnapshots - объёмы за 24ч из собственных снимков цен (без логина в Steam)
+	LiquiditySnapshots LiquiditySource = "snapshots"
+)
+
+// ItemLiquidity - насколько быстро предмет продаётся
+//
+// Метрики общие для всех пользователей и пересчитываются фоновым воркером.
+// TimeToSellHours - ожидание следующей продажи при равномерном потоке покупок
+// (24 / DailyVolume): оценка для лота по рыночной цене, а не гарантия.
+type ItemLiquidity struct {
+	AppID           int             `json:"app_id"`
+	MarketHashName  string          `json:"market_hash_name"`
+	Source          LiquiditySource `json:"source"`
+	Days            int             `json:"days"`               // Дней с данными в окне
+	Sales           int64           `json:"sales"`              // Продаж за эти дни
+	DailyVolume     float64         `json:"daily_volume"`       // Среднее продаж в день
+	Volume7d        float64         `json:"volume_7d"`          // Среднее продаж в день за последние 7 дней
+	VolumeTrend     float64         `json:"volume_trend"`       // Изменение Volume7d к DailyVolume, %
+	ActiveDays      int             `json:"active_days"`        // Дней хотя бы с одной продажей
+	TimeToSellHours *float64        `json:"time_to_sell_hours"` // nil - продаж не было
+	Score           int             `json:"score"`              // Оценка ликвидности 0-100
+	ComputedAt      time.Time       `json:"computed_at"`
+}
+
+// Key - ключ предмета
+func (l *ItemLiquidity) Key() ItemKey {
+	return ItemKey{AppID: l.AppID, MarketHashName: l.MarketHashName}
+}
+
+// liquidityWindow - начало окна и начало текущего (неполного) дня, UTC
+func liquidityWindow(now time.Time) (from, to time.Time) {
+	to = now.UTC().Truncate(24 * time.Hour)
+	return to.AddDate(0, 0, -LiquidityWindowDays), to
+}
+
+// DailySalesFromHistory - продажи по дням окна из pricehistory (по возрастанию дат)
+// Steam отдаёт только периоды с продажами, поэтому дни без точек - это ноль продаж
+func DailySalesFromHistory(points []PriceHistoryPoint, now time.Time) []int64 {
+	from, to := liquidityWindow(now)
+
+	daily := make([]int64, LiquidityWindowDays)
+	for _, point := range points {
+		t := point.Time.UTC()
+		if t.Before(from) || !t.Before(to) {
+			continue
+		}
+		daily[int(t.Sub(from)/(24*time.Hour))] += point.Volume
+	}
+	return daily
+}
+
+// DailySalesFromSnapshots - продажи по дням окна из снимков цен (по возрастанию дат)
+//
+// Volume снимка - продажи за 24ч до него, поэтому продажи дня - объём последнего снимка дня.
+// Дни без снимков пропускаются: про них ничего не известно, считать их нулём нельзя.
+func DailySalesFromSnapshots(snapshots []PriceSnapshot, now time.Time) []int64 {
+	from, to := liquidityWindow(now)
+
+	last := make(map[int]*PriceSnapshot, LiquidityWindowDays)
+	for i := range snapshots {
+		s := &snapshots[i]
+		t := s.ObservedAt.UTC()
+		if t.Before(from) || !t.Before(to) {
+			continue
+		}
+		day := int(t.Sub(from) / (24 * time.Hour))
+		if prev, ok := last[day]; !ok || s.ObservedAt.After(prev.ObservedAt) {
+			last[day] = s
+		}
+	}
+
+	days := make([]int, 0, len(last))
+	for day := range last {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+
+	daily := make([]int64, len(days))
+	for i, day := range days {
+		daily[i] = last[day].Volume
+	}
+	return daily
+}
+
+// ComputeLiquidity - метрики по продажам за дни окна (по возрастанию дат)
+//
+// Оценка складывается из среднего объёма (70 баллов, логарифмическая шкала до
+// liquidityFullVolume продаж в день), доли дней с продажами (20) и тренда объёма (10,
+// от -100% до +100%). Без продаж - 0.
+func ComputeLiquidity(key ItemKey, source LiquiditySource, daily []int64, now time.Time) *ItemLiquidity {
+	liquidity := &ItemLiquidity{
+		AppID:          key.AppID,
+		MarketHashName: key.MarketHashName,
+		Source:         source,
+		Days:           len(daily),
+		ComputedAt:     now.UTC(),
+	}
+	if len(daily) == 0 {
+		return liquidity
+	}
+
+	for _, sales := range daily {
+		liquidity.Sales += sales
+		if sales > 0 {
+			liquidity.ActiveDays++
+		}
+	}
+	if liquidity.Sales == 0 {
+		return liquidity
+	}
+
+	recent := daily[max(len(daily)-liquidityTrendDays, 0):]
+	var recentSales int64
+	for _, sales := range recent {
+		recentSales += sales
+	}
+
+	liquidity.DailyVolume = float64(liquidity.Sales) / float64(len(daily))
+	liquidity.Volume7d = float64(recentSales) / float64(len(recent))
+	liquidity.VolumeTrend = 100 * (liquidity.Volume7d/liquidity.DailyVolume - 1)
+
+	timeToSell := roundTo(24/liquidity.DailyVolume, 1)
+	liquidity.TimeToSellHours = &timeToSell
+
+	volumeScore := min(math.Log1p(liquidity.DailyVolume)/math.Log1p(liquidityFullVolume), 1)
+	activityScore := float64(liquidity.ActiveDays) / float64(len(daily))
+	trendScore := (max(min(liquidity.VolumeTrend, 100), -100) + 100) / 200
+	liquidity.Score = int(math.Round(70*volumeScore + 20*activityScore + 10*trendScore))
+
+	liquidity.DailyVolume = roundTo(liquidity.DailyVolume, 2)
+	liquidity.Volume7d = roundTo(liquidity.Volume7d, 2)
+	liquidity.VolumeTrend = roundTo(liquidity.VolumeTrend, 1)
+
+	return liquidity
+}
+
+// TrackedItemSort - порядок списка отслеживаемых предметов
+type TrackedItemSort string
+
+const (
+	SortCreatedAt  TrackedItemSort = "created_at"   // Новые сверху (по умолчанию)
+	SortName       TrackedItemSort = "name"         // По имени, А-Я
+	SortLiquidity  TrackedItemSort = "liquidity"    // По оценке ликвидности, ликвидные сверху
+	SortVolume     TrackedItemSort = "volume"       // По продажам в день, больше сверху
+	SortTimeToSell TrackedItemSort = "time_to_sell" // По времени продажи, быстрые сверху
+)
+
+// ParseTrackedItemSort - порядок из query параметра
+func ParseTrackedItemSort(s string) (TrackedItemSort, error) {
+	switch sort := TrackedItemSort(s); sort {
+	case SortCreatedAt, SortName, SortLiquidity, SortVolume, SortTimeToSell:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("%w: sort must be created_at, name, liquidity, volume or time_to_sell", ErrValidation)
+	}
+}
+
+// Descending - естественный порядок поля по убыванию (новые, ликвидные, продаваемые - сверху)
+func (s TrackedItemSort) Descending() bool {
+	switch s {
+	case SortCreatedAt, SortLiquidity, SortVolume:
+		return true
+	}
+	return false
+}
+
+// SortTrackedItems - сортирует предметы на месте; reverse разворачивает естественный порядок поля
+// Предметы без посчитанной ликвидности при сортировке по её метрикам всегда в конце
+func SortTrackedItems(items []TrackedItem, sort TrackedItemSort, reverse bool) {
+	// Естественный порядок поля
+	compare := func(a, b *TrackedItem) int {
+		switch sort {
+		case SortName:
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case SortLiquidity:
+			return b.Liquidity.Score - a.Liquidity.Score
+		case SortVolume:
+			return cmp.Compare(b.Liquidity.DailyVolume, a.Liquidity.DailyVolume)
+		case SortTimeToSell:
+			return cmp.Compare(*a.Liquidity.TimeToSellHours, *b.Liquidity.TimeToSellHours)
+		default:
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+	}
+
+	hasValue := func(item *TrackedItem) bool {
+		switch sort {
+		case SortLiquidity, SortVolume:
+			return item.Liquidity != nil
+		case SortTimeToSell:
+			return item.Liquidity != nil && item.Liquidity.TimeToSellHours != nil
+		default:
+			return true
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b TrackedItem) int {
+		hasA, hasB := hasValue(&a), hasValue(&b)
+		switch {
+		case !hasA && !hasB:
+			return 0
+		case !hasA:
+			return 1
+		case !hasB:
+			return -1
+		}
+		if reverse {
+			return -compare(&a, &b)
+		}
+		return compare(&a, &b)
+	})
+}
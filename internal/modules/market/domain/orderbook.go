@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ограничения и значения по умолчанию для метрик стакана (в процентах)
+const (
+	DefaultDepthPercent      = 5.0
+	DefaultDepthChartPercent = 20.0
+	MaxDepthPercent          = 100.0
+)
+
+// OrderBookLevel - ценовой уровень стакана
+type OrderBookLevel struct {
+	Price    int64 `json:"price"`    // Цена в сотых долях валюты
+	Quantity int64 `json:"quantity"` // Количество на этом уровне (не накопленное)
+}
+
+// OrderBook - снимок стакана предмета (ордера на покупку и лоты на продажу)
+//
+// Steam отдаёт не весь стакан, а его верхушку: хвост графика сворачивается
+// в последний уровень. Поэтому BidOrders/AskOrders (общее число) могут быть
+// больше суммы Quantity по уровням.
+type OrderBook struct {
+	AppID          int              `json:"app_id"`
+	MarketHashName string           `json:"market_hash_name"`
+	Currency       Currency         `json:"currency"`
+	Bids           []OrderBookLevel `json:"bids"` // Покупка, по убыванию цены
+	Asks           []OrderBookLevel `json:"asks"` // Продажа, по возрастанию цены
+	BidOrders      int64            `json:"bid_orders"`
+	AskOrders      int64            `json:"ask_orders"`
+	ObservedAt     time.Time        `json:"observed_at"`
+}
+
+// Key - ключ предмета
+func (b *OrderBook) Key() ItemKey {
+	return ItemKey{AppID: b.AppID, MarketHashName: b.MarketHashName}
+}
+
+// HighestBid - лучшая цена покупки, 0 если ордеров нет
+func (b *OrderBook) HighestBid() int64 {
+	if len(b.Bids) == 0 {
+		return 0
+	}
+	return b.Bids[0].Price
+}
+
+// LowestAsk - лучшая цена продажи, 0 если лотов нет
+func (b *OrderBook) LowestAsk() int64 {
+	if len(b.Asks) == 0 {
+		return 0
+	}
+	return b.Asks[0].Price
+}
+
+// MidPrice - середина спреда; если одной стороны нет - лучшая цена другой
+func (b *OrderBook) MidPrice() int64 {
+	bid, ask := b.HighestBid(), b.LowestAsk()
+	switch {
+	case bid > 0 && ask > 0:
+		return (bid + ask) / 2
+	case bid > 0:
+		return bid
+	default:
+		return ask
+	}
+}
+
+// OrderBookMetrics - сводка по стакану для решений о перепродаже
+type OrderBookMetrics struct {
+	AppID          int      `json:"app_id"`
+	MarketHashName string   `json:"market_hash_name"`
+	Currency       Currency `json:"currency"`
+	HighestBid     int64    `json:"highest_bid"`
+	LowestAsk      int64    `json:"lowest_ask"`
+	MidPrice       int64    `json:"mid_price"`
+	Spread         int64    `json:"spread"`         // LowestAsk - HighestBid; 0 если одной стороны нет
+	SpreadPercent  float64  `json:"spread_percent"` // Spread от LowestAsk
+	// FlipMargin - сколько останется после комиссий, если перебить лучший ордер
+	// на покупку (+1) и выставить лот на 1 дешевле лучшего (-1). Может быть отрицательным
+	FlipMargin   int64     `json:"flip_margin"`
+	BidOrders    int64     `json:"bid_orders"`
+	AskOrders    int64     `json:"ask_orders"`
+	DepthPercent float64   `json:"depth_percent"`
+	BidDepth     int64     `json:"bid_depth"` // Количество на покупку не дальше DepthPercent от MidPrice
+	AskDepth     int64     `json:"ask_depth"` // Количество на продажу не дальше DepthPercent от MidPrice
+	ObservedAt   time.Time `json:"observed_at"`
+}
+
+// ValidateDepthPercent - проверка ширины окна глубины
+func ValidateDepthPercent(pct float64) error {
+	if pct <= 0 || pct > MaxDepthPercent || math.IsNaN(pct) {
+		return fmt.Errorf("%w: depth percent must be in (0, %.0f]", ErrValidation, MaxDepthPercent)
+	}
+	return nil
+}
+
+// Metrics - спред, маржа перепродажи и глубина в пределах depthPercent от середины
+func (b *OrderBook) Metrics(depthPercent float64) OrderBookMetrics {
+	bid, ask := b.HighestBid(), b.LowestAsk()
+	mid := b.MidPrice()
+
+	m := OrderBookMetrics{
+		AppID:          b.AppID,
+		MarketHashName: b.MarketHashName,
+		Currency:       b.Currency,
+		HighestBid:     bid,
+		LowestAsk:      ask,
+		MidPrice:       mid,
+		BidOrders:      b.BidOrders,
+		AskOrders:      b.AskOrders,
+		DepthPercent:   depthPercent,
+		ObservedAt:     b.ObservedAt,
+	}
+
+	if bid > 0 && ask > 0 {
+		m.Spread = ask - bid
+		m.SpreadPercent = float64(m.Spread) / float64(ask) * 100
+		m.FlipMargin = FeeScheduleFor(b.AppID).SellerReceivesAt(ask-1) - (bid + 1)
+	}
+
+	lower := float64(mid) * (1 - depthPercent/100)
+	upper := float64(mid) * (1 + depthPercent/100)
+	for _, level := range b.Bids {
+		if float64(level.Price) < lower {
+			break
+		}
+		m.BidDepth += level.Quantity
+	}
+	for _, level := range b.Asks {
+		if float64(level.Price) > upper {
+			break
+		}
+		m.AskDepth += level.Quantity
+	}
+
+	return m
+}
+
+// DepthPoint - точка графика глубины: сколько можно купить/продать по цене Price и лучше
+type DepthPoint struct {
+	Price      int64 `json:"price"`
+	Cumulative int64 `json:"cumulative"`
+}
+
+// DepthChart - накопленные объёмы обеих сторон стакана вокруг середины
+type DepthChart struct {
+	AppID          int          `json:"app_id"`
+	MarketHashName string       `json:"market_hash_name"`
+	Currency       Currency     `json:"currency"`
+	MidPrice       int64        `json:"mid_price"`
+	RangePercent   float64      `json:"range_percent"`
+	Bids           []DepthPoint `json:"bids"` // По убыванию цены
+	Asks           []DepthPoint `json:"asks"` // По возрастанию цены
+	ObservedAt     time.Time    `json:"observed_at"`
+}
+
+// DepthChart - график глубины в пределах rangePercent от середины
+func (b *OrderBook) DepthChart(rangePercent float64) DepthChart {
+	mid := b.MidPrice()
+	lower := float64(mid) * (1 - rangePercent/100)
+	upper := float64(mid) * (1 + rangePercent/100)
+
+	chart := DepthChart{
+		AppID:          b.AppID,
+		MarketHashName: b.MarketHashName,
+		Currency:       b.Currency,
+		MidPrice:       mid,
+		RangePercent:   rangePercent,
+		Bids:           []DepthPoint{},
+		Asks:           []DepthPoint{},
+		ObservedAt:     b.ObservedAt,
+	}
+
+	var cumulative int64
+	for _, level := range b.Bids {
+		if float64(level.Price) < lower {
+			break
+		}
+		cumulative += level.Quantity
+		chart.Bids = append(chart.Bids, DepthPoint{Price: level.Price, Cumulative: cumulative})
+	}
+
+	cumulative = 0
+	for _, level := range b.Asks {
+		if float64(level.Price) > upper {
+			break
+		}
+		cumulative += level.Quantity
+		chart.Asks = append(chart.Asks, DepthPoint{Price: level.Price, Cumulative: cumulative})
+	}
+
+	return chart
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LotSide - покупка или продажа
+type LotSide string
+
+const (
+	LotBuy  LotSide = "buy"
+	LotSell LotSide = "sell"
+)
+
+// maxLotClockSkew - насколько executed_at может быть в будущем (разница часов клиента и сервера)
+const maxLotClockSkew = 5 * time.Minute
+
+// Lot - сделка пользователя по отслеживаемому предмету
+//
+// Цены и комиссия в сотых долях Currency. Комиссия покупки увеличивает
+// себестоимость, комиссия продажи уменьшает выручку.
+type Lot struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	TrackedItemID string    `json:"tracked_item_id"`
+	Side          LotSide   `json:"side"`
+	Quantity      int64     `json:"quantity"`
+	UnitPrice     int64     `json:"unit_price"`
+	Fee           int64     `json:"fee"` // На весь лот, а не на штуку
+	Currency      Currency  `json:"currency"`
+	ExecutedAt    time.Time `json:"executed_at"`
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewLot - фабричный метод новой сделки
+// Нулевой executedAt → текущее время
+func NewLot(userID, trackedItemID string, side LotSide, quantity, unitPrice, fee int64, currency Currency, executedAt time.Time, notes string) *Lot {
+	now := time.Now().UTC()
+	if executedAt.IsZero() {
+		executedAt = now
+	}
+
+	return &Lot{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		TrackedItemID: trackedItemID,
+		Side:          side,
+		Quantity:      quantity,
+		UnitPrice:     unitPrice,
+		Fee:           fee,
+		Currency:      currency,
+		ExecutedAt:    executedAt.UTC(),
+		Notes:         strings.TrimSpace(notes),
+		CreatedAt:     now,
+	}
+}
+
+// Validate - проверка инвариантов сделки
+func (l *Lot) Validate() error {
+	if l.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if l.TrackedItemID == "" {
+		return fmt.Errorf("%w: tracked_item_id is required", ErrValidation)
+	}
+	if l.Side != LotBuy && l.Side != LotSell {
+		return fmt.Errorf("%w: side must be buy or sell", ErrValidation)
+	}
+	if l.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrValidation)
+	}
+	if l.UnitPrice < 0 || l.Fee < 0 {
+		return fmt.Errorf("%w: unit_price and fee must not be negative", ErrValidation)
+	}
+	if !l.Currency.IsKnown() {
+		return fmt.Errorf("%w: unsupported currency %d", ErrValidation, int(l.Currency))
+	}
+	if l.ExecutedAt.After(time.Now().Add(maxLotClockSkew)) {
+		return fmt.Errorf("%w: executed_at must not be in the future", ErrValidation)
+	}
+	return nil
+}
+
+// Gross - сумма сделки без комиссии
+func (l *Lot) Gross() int64 {
+	return l.Quantity * l.UnitPrice
+}
+
+// SortLots - хронологический порядок для FIFO (при равном времени - порядок ввода)
+func SortLots(lots []Lot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		if !lots[i].ExecutedAt.Equal(lots[j].ExecutedAt) {
+			return lots[i].ExecutedAt.Before(lots[j].ExecutedAt)
+		}
+		return lots[i].CreatedAt.Before(lots[j].CreatedAt)
+	})
+}
+
+// ValidateLotSequence - не продаётся ли в какой-то момент больше, чем куплено
+// Проверяет только количества, поэтому валюта лотов не важна
+func ValidateLotSequence(lots []Lot) error {
+	sorted := append([]Lot(nil), lots...)
+	SortLots(sorted)
+
+	var held int64
+	for i := range sorted {
+		switch sorted[i].Side {
+		case LotBuy:
+			held += sorted[i].Quantity
+		case LotSell:
+			held -= sorted[i].Quantity
+			if held < 0 {
+				return fmt.Errorf("%w: sell on %s exceeds the position (short by %d)",
+					ErrValidation, sorted[i].ExecutedAt.Format(time.RFC3339), -held)
+			}
+		}
+	}
+	return nil
+}
+
+// PositionPnL - позиция по одному предмету и её P&L (FIFO)
+type PositionPnL struct {
+	TrackedItemID  string   `json:"tracked_item_id"`
+	AppID          int      `json:"app_id"`
+	MarketHashName string   `json:"market_hash_name"`
+	Currency       Currency `json:"currency"`
+	Quantity       int64    `json:"quantity"`     // Сколько штук на руках
+	CostBasis      int64    `json:"cost_basis"`   // Себестоимость остатка (с комиссиями покупок)
+	AverageCost    int64    `json:"average_cost"` // CostBasis / Quantity
+	BoughtQuantity int64    `json:"bought_quantity"`
+	SoldQuantity   int64    `json:"sold_quantity"`
+	FeesPaid       int64    `json:"fees_paid"`
+	RealizedPnL    int64    `json:"realized_pnl"`     // По закрытым (проданным) штукам
+	MarketPrice    int64    `json:"market_price"`     // 0 = цена неизвестна
+	MarketValue    int64    `json:"market_value"`     // MarketPrice * Quantity
+	MarketValueNet int64    `json:"market_value_net"` // Сколько получим при продаже по MarketPrice за вычетом комиссий
+	UnrealizedPnL  int64    `json:"unrealized_pnl"`   // MarketValueNet - CostBasis, 0 без цены
+	Priced         bool     `json:"priced"`
+}
+
+// openLot - непроданный остаток покупки в очереди FIFO
+type openLot struct {
+	quantity int64
+	cost     int64 // Себестоимость остатка: gross + fee, уменьшается пропорционально продажам
+}
+
+// ComputePositionPnL - FIFO сопоставление продаж с покупками
+//
+// Все лоты должны быть в валюте currency (конвертация на вызывающем).
+// Нереализованный P&L считается от выручки после комиссий маркета (fees):
+// marketPrice - цена для покупателя, продавец получит меньше.
+// Продажа закрывает самые ранние покупки; себестоимость частично закрытой покупки
+// списывается пропорционально количеству, остаток от деления остаётся на лоте,
+// поэтому при полном закрытии списывается ровно вся себестоимость.
+// marketPrice = 0 → нереализованный P&L не считается (Priced = false).
+func ComputePositionPnL(lots []Lot, currency Currency, marketPrice int64, fees FeeSchedule) (PositionPnL, error) {
+	pnl := PositionPnL{Currency: currency}
+
+	sorted := append([]Lot(nil), lots...)
+	SortLots(sorted)
+
+	var queue []openLot
+	for i := range sorted {
+		lot := &sorted[i]
+		if lot.Currency != currency {
+			return pnl, fmt.Errorf("lot %s is in %s, expected %s", lot.ID, lot.Currency.Code(), currency.Code())
+		}
+		pnl.FeesPaid += lot.Fee
+
+		if lot.Side == LotBuy {
+			pnl.BoughtQuantity += lot.Quantity
+			queue = append(queue, openLot{quantity: lot.Quantity, cost: lot.Gross() + lot.Fee})
+			continue
+		}
+
+		pnl.SoldQuantity += lot.Quantity
+		remaining := lot.Quantity
+		var closedCost int64
+		for remaining > 0 {
+			if len(queue) == 0 {
+				return pnl, fmt.Errorf("%w: sell lot %s exceeds the position", ErrValidation, lot.ID)
+			}
+			head := &queue[0]
+			take := min(remaining, head.quantity)
+
+			portion := head.cost * take / head.quantity
+			if take == head.quantity {
+				portion = head.cost
+			}
+			closedCost += portion
+			head.cost -= portion
+			head.quantity -= take
+			remaining -= take
+
+			if head.quantity == 0 {
+				queue = queue[1:]
+			}
+		}
+
+		pnl.RealizedPnL += lot.Gross() - lot.Fee - closedCost
+	}
+
+	for _, open := range queue {
+		pnl.Quantity += open.quantity
+		pnl.CostBasis += open.cost
+	}
+	if pnl.Quantity > 0 {
+		pnl.AverageCost = pnl.CostBasis / pnl.Quantity
+	}
+
+	if marketPrice > 0 {
+		pnl.Priced = true
+		pnl.MarketPrice = marketPrice
+		pnl.MarketValue = marketPrice * pnl.Quantity
+		pnl.MarketValueNet = fees.SellerReceivesAt(marketPrice) * pnl.Quantity
+		pnl.UnrealizedPnL = pnl.MarketValueNet - pnl.CostBasis
+	}
+
+	return pnl, nil
+}
+
+// Portfolio - сводка по всем позициям пользователя в одной валюте
+type Portfolio struct {
+	Currency       Currency      `json:"currency"`
+	CostBasis      int64         `json:"cost_basis"`
+	MarketValue    int64         `json:"market_value"`     // Только по позициям с известной ценой
+	MarketValueNet int64         `json:"market_value_net"` // То же за вычетом комиссий маркета
+	RealizedPnL    int64         `json:"realized_pnl"`
+	UnrealizedPnL  int64         `json:"unrealized_pnl"`
+	TotalPnL       int64         `json:"total_pnl"` // Realized + Unrealized
+	FeesPaid       int64         `json:"fees_paid"`
+	UnpricedItems  int           `json:"unpriced_items"` // Открытые позиции без цены (не входят в MarketValue)
+	Items          []PositionPnL `json:"items"`
+	CalculatedAt   time.Time     `json:"calculated_at"`
+}
+
+// Summarize - итоги по позициям; позиции сортируются по рыночной стоимости
+func (p *Portfolio) Summarize() {
+	p.CostBasis, p.MarketValue, p.MarketValueNet, p.RealizedPnL, p.UnrealizedPnL, p.FeesPaid, p.UnpricedItems = 0, 0, 0, 0, 0, 0, 0
+	for i := range p.Items {
+		item := &p.Items[i]
+		p.CostBasis += item.CostBasis
+		p.MarketValue += item.MarketValue
+		p.MarketValueNet += item.MarketValueNet
+		p.RealizedPnL += item.RealizedPnL
+		p.UnrealizedPnL += item.UnrealizedPnL
+		p.FeesPaid += item.FeesPaid
+		if item.Quantity > 0 && !item.Priced {
+			p.UnpricedItems++
+		}
+	}
+	p.TotalPnL = p.RealizedPnL + p.UnrealizedPnL
+
+	sort.SliceStable(p.Items, func(i, j int) bool {
+		if p.Items[i].MarketValue != p.Items[j].MarketValue {
+			return p.Items[i].MarketValue > p.Items[j].MarketValue
+		}
+		return p.Items[i].MarketHashName < p.Items[j].MarketHashName
+	})
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var lotsStart = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testLot(side LotSide, day int, quantity, unitPrice, fee int64) Lot {
+	at := lotsStart.AddDate(0, 0, day)
+	return Lot{
+		ID:         string(side) + "-" + at.Format("0102"),
+		Side:       side,
+		Quantity:   quantity,
+		UnitPrice:  unitPrice,
+		Fee:        fee,
+		Currency:   CurrencyUSD,
+		ExecutedAt: at,
+		CreatedAt:  at,
+	}
+}
+
+func TestComputePositionPnLFIFO(t *testing.T) {
+	// Лоты вне хронологического порядка: FIFO идёт по executed_at
+	lots := []Lot{
+		testLot(LotSell, 2, 4, 250, 50),
+		testLot(LotBuy, 0, 3, 100, 30),
+		testLot(LotBuy, 1, 2, 200, 0),
+	}
+
+	pnl, err := ComputePositionPnL(lots, CurrencyUSD, 115, FeeScheduleFor(AppIDCS2))
+	if err != nil {
+		t.Fatalf("ComputePositionPnL: %v", err)
+	}
+
+	// Продажа закрывает первую покупку целиком (330) и половину второй (200):
+	// 4 × 250 - 50 - 530 = 420
+	want := PositionPnL{
+		Currency:       CurrencyUSD,
+		Quantity:       1,
+		CostBasis:      200,
+		AverageCost:    200,
+		BoughtQuantity: 5,
+		SoldQuantity:   4,
+		FeesPaid:       80,
+		RealizedPnL:    420,
+		MarketPrice:    115,
+		MarketValue:    115,
+		MarketValueNet: 100, // 115 для покупателя = 100 продавцу
+		UnrealizedPnL:  -100,
+		Priced:         true,
+	}
+	if pnl != want {
+		t.Errorf("pnl = %+v\nwant  %+v", pnl, want)
+	}
+}
+
+func TestComputePositionPnLPartialCloseRounding(t *testing.T) {
+	// Себестоимость 301 на 3 штуки не делится нацело: после всех продаж
+	// должно быть списано ровно 301, без потерянного цента
+	lots := []Lot{
+		testLot(LotBuy, 0, 3, 100, 1),
+		testLot(LotSell, 1, 1, 100, 0),
+		testLot(LotSell, 2, 1, 100, 0),
+		testLot(LotSell, 3, 1, 100, 0),
+	}
+
+	pnl, err := ComputePositionPnL(lots, CurrencyUSD, 0, FeeScheduleFor(AppIDCS2))
+	if err != nil {
+		t.Fatalf("ComputePositionPnL: %v", err)
+	}
+	if pnl.RealizedPnL != -1 || pnl.Quantity != 0 || pnl.CostBasis != 0 {
+		t.Errorf("realized=%d quantity=%d cost=%d, want -1 0 0", pnl.RealizedPnL, pnl.Quantity, pnl.CostBasis)
+	}
+	if pnl.Priced || pnl.UnrealizedPnL != 0 {
+		t.Errorf("position without market price: priced=%v unrealized=%d", pnl.Priced, pnl.UnrealizedPnL)
+	}
+}
+
+func TestComputePositionPnLSameTimeUsesEntryOrder(t *testing.T) {
+	buy := testLot(LotBuy, 0, 1, 100, 0)
+	sell := testLot(LotSell, 0, 1, 150, 0)
+	sell.CreatedAt = buy.CreatedAt.Add(time.Second)
+
+	pnl, err := ComputePositionPnL([]Lot{sell, buy}, CurrencyUSD, 0, FeeScheduleFor(AppIDCS2))
+	if err != nil {
+		t.Fatalf("ComputePositionPnL: %v", err)
+	}
+	if pnl.RealizedPnL != 50 {
+		t.Errorf("realized = %d, want 50", pnl.RealizedPnL)
+	}
+}
+
+func TestComputePositionPnLErrors(t *testing.T) {
+	oversold := []Lot{testLot(LotBuy, 0, 1, 100, 0), testLot(LotSell, 1, 2, 100, 0)}
+	if _, err := ComputePositionPnL(oversold, CurrencyUSD, 0, FeeScheduleFor(AppIDCS2)); !errors.Is(err, ErrValidation) {
+		t.Errorf("oversold: error = %v, want ErrValidation", err)
+	}
+	if err := ValidateLotSequence(oversold); !errors.Is(err, ErrValidation) {
+		t.Errorf("ValidateLotSequence(oversold): error = %v, want ErrValidation", err)
+	}
+
+	// Продажа раньше покупки - короткая позиция
+	early := []Lot{testLot(LotSell, 0, 1, 100, 0), testLot(LotBuy, 1, 1, 100, 0)}
+	if err := ValidateLotSequence(early); !errors.Is(err, ErrValidation) {
+		t.Errorf("ValidateLotSequence(sell before buy): error = %v, want ErrValidation", err)
+	}
+
+	mixed := []Lot{testLot(LotBuy, 0, 1, 100, 0)}
+	mixed[0].Currency = CurrencyEUR
+	if _, err := ComputePositionPnL(mixed, CurrencyUSD, 0, FeeScheduleFor(AppIDCS2)); err == nil {
+		t.Error("lot in another currency: want an error")
+	}
+}
+
+func TestPortfolioSummarize(t *testing.T) {
+	p := Portfolio{Items: []PositionPnL{
+		{MarketHashName: "B", Quantity: 1, CostBasis: 100, RealizedPnL: 10, FeesPaid: 3},
+		{MarketHashName: "A", Quantity: 2, CostBasis: 200, MarketValue: 300, MarketValueNet: 260, UnrealizedPnL: 60, Priced: true},
+		{MarketHashName: "C", RealizedPnL: -5, FeesPaid: 1},
+	}}
+	p.Summarize()
+
+	if p.CostBasis != 300 || p.MarketValue != 300 || p.RealizedPnL != 5 || p.UnrealizedPnL != 60 ||
+		p.TotalPnL != 65 || p.FeesPaid != 4 || p.UnpricedItems != 1 {
+		t.Errorf("summary = %+v", p)
+	}
+	if p.Items[0].MarketHashName != "A" || p.Items[1].MarketHashName != "B" {
+		t.Errorf("items are not sorted by market value: %s, %s", p.Items[0].MarketHashName, p.Items[1].MarketHashName)
+	}
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Currency - ID валюты кошелька Steam (параметр currency в API маркета)
+// Steam принимает числовые ID, а не ISO коды: 1 = USD, 3 = EUR, 5 = RUB и т.д.
+type Currency int
+
+const (
+	CurrencyUSD Currency = 1
+	CurrencyGBP Currency = 2
+	CurrencyEUR Currency = 3
+	CurrencyCHF Currency = 4
+	CurrencyRUB Currency = 5
+	CurrencyPLN Currency = 6
+	CurrencyBRL Currency = 7
+	CurrencyJPY Currency = 8
+	CurrencyTRY Currency = 17
+	CurrencyUAH Currency = 18
+	CurrencyCAD Currency = 20
+	CurrencyAUD Currency = 21
+	CurrencyCNY Currency = 23
+	CurrencyKZT Currency = 37
+)
+
+// currencyCodes - ISO 4217 коды поддерживаемых валют
+var currencyCodes = map[Currency]string{
+	CurrencyUSD: "USD",
+	CurrencyGBP: "GBP",
+	CurrencyEUR: "EUR",
+	CurrencyCHF: "CHF",
+	CurrencyRUB: "RUB",
+	CurrencyPLN: "PLN",
+	CurrencyBRL: "BRL",
+	CurrencyJPY: "JPY",
+	CurrencyTRY: "TRY",
+	CurrencyUAH: "UAH",
+	CurrencyCAD: "CAD",
+	CurrencyAUD: "AUD",
+	CurrencyCNY: "CNY",
+	CurrencyKZT: "KZT",
+}
+
+// Code - ISO код валюты ("USD"), для неизвестных ID - "CUR<id>"
+func (c Currency) Code() string {
+	if code, ok := currencyCodes[c]; ok {
+		return code
+	}
+	return fmt.Sprintf("CUR%d", int(c))
+}
+
+// IsKnown - поддерживается ли валюта
+func (c Currency) IsKnown() bool {
+	_, ok := currencyCodes[c]
+	return ok
+}
+
+// ParseCurrency - ISO код ("usd", "EUR") → Currency
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for c, known := range currencyCodes {
+		if known == code {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unsupported currency %q", ErrValidation, code)
+}
+
+// FormatPrice - 1250, USD → "12.50 USD"
+func FormatPrice(cents int64, currency Currency) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, cents/100, cents%100, currency.Code())
+}
+
+// PriceOverview - текущая сводка цены предмета (ответ priceoverview)
+//
+// Все цены хранятся в сотых долях валюты (центы, копейки): 12.34$ → 1234.
+// Целые числа избавляют от ошибок округления float при расчёте комиссий и P&L.
+// 0 означает "Steam не вернул значение" (у редких предметов нет median/lowest).
+type PriceOverview struct {
+	AppID          int      `json:"app_id"`
+	MarketHashName string   `json:"market_hash_name"`
+	Currency       Currency `json:"currency"`
+	LowestPrice    int64    `json:"lowest_price"` // Минимальная цена на продажу сейчас
+	MedianPrice    int64    `json:"median_price"` // Медианная цена продаж за 24ч
+	Volume         int64    `json:"volume"`       // Количество продаж за 24ч
+}
+
+// PriceHistoryPoint - точка истории продаж (ответ pricehistory)
+// Steam агрегирует продажи: по часам за последний месяц, по дням - раньше
+type PriceHistoryPoint struct {
+	Time   time.Time `json:"time"`
+	Price  int64     `json:"price"`  // Медианная цена за период, в сотых долях валюты
+	Volume int64     `json:"volume"` // Количество продаж за период
+}
+
+// ItemKey - идентичность предмета на маркете Steam
+// Цена зависит только от предмета, поэтому poller работает с ключами, а не с TrackedItem
+type ItemKey struct {
+	AppID          int    `json:"app_id"`
+	MarketHashName string `json:"market_hash_name"`
+}
+
+// Key - ключ предмета, за которым следит пользователь
+func (i *TrackedItem) Key() ItemKey {
+	return ItemKey{AppID: i.AppID, MarketHashName: i.MarketHashName}
+}
+
+// PriceSnapshot - зафиксированная в момент ObservedAt цена предмета
+type PriceSnapshot struct {
+	ID             int64     `json:"id"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Currency       Currency  `json:"currency"`
+	LowestPrice    int64     `json:"lowest_price"` // 0 = нет данных
+	MedianPrice    int64     `json:"median_price"` // 0 = нет данных
+	Volume         int64     `json:"volume"`
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
+// NewPriceSnapshot - снимок из ответа priceoverview
+func NewPriceSnapshot(overview *PriceOverview, observedAt time.Time) *PriceSnapshot {
+	return &PriceSnapshot{
+		AppID:          overview.AppID,
+		MarketHashName: overview.MarketHashName,
+		Currency:       overview.Currency,
+		LowestPrice:    overview.LowestPrice,
+		MedianPrice:    overview.MedianPrice,
+		Volume:         overview.Volume,
+		ObservedAt:     observedAt,
+	}
+}
+
+// Key - ключ предмета снимка
+func (s *PriceSnapshot) Key() ItemKey {
+	return ItemKey{AppID: s.AppID, MarketHashName: s.MarketHashName}
+}
+
+// MarshalText - валюта в JSON отдаётся ISO кодом ("USD"), а не ID Steam
+func (c Currency) MarshalText() ([]byte, error) {
+	return []byte(c.Code()), nil
+}
+
+// UnmarshalText - принимает ISO код ("EUR") или ID Steam ("3")
+func (c *Currency) UnmarshalText(text []byte) error {
+	if id, err := strconv.Atoi(string(text)); err == nil {
+		*c = Currency(id)
+		return nil
+	}
+
+	parsed, err := ParseCurrency(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// UnmarshalJSON - как UnmarshalText, но дополнительно принимает ID числом (3)
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	if id, err := strconv.Atoi(string(data)); err == nil {
+		*c = Currency(id)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTooManyStreams - у пользователя уже открыто максимальное число потоков событий
+var ErrTooManyStreams = errors.New("too many open streams")
+
+// StreamEventType - вид события потока GET /market/stream (поле event в SSE)
+type StreamEventType string
+
+const (
+	// StreamPrice - новый снимок цены отслеживаемого предмета, Data - PriceUpdate
+	StreamPrice StreamEventType = "price"
+	// StreamAlert - сработал алерт пользователя, Data - FiredAlert
+	StreamAlert StreamEventType = "alert"
+	// StreamResync - часть событий после Last-Event-ID уже не восстановить
+	// (буфер переполнен или сервер перезапущен): клиенту нужно перечитать состояние целиком
+	StreamResync StreamEventType = "resync"
+)
+
+// StreamEvent - событие потока
+// ID растут монотонно и не повторяются после рестарта - клиент передаёт последний в Last-Event-ID
+type StreamEvent struct {
+	ID   uint64
+	Type StreamEventType
+	Data any
+}
+
+// PriceUpdate - новая цена отслеживаемого предмета в валюте потока
+type PriceUpdate struct {
+	TrackedItemID  string    `json:"tracked_item_id"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Currency       Currency  `json:"currency"`
+	LowestPrice    int64     `json:"lowest_price"`
+	MedianPrice    int64     `json:"median_price"`
+	Volume         int64     `json:"volume"`
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
+// NewPriceUpdate - событие цены из снимка (снимок уже в нужной валюте)
+func NewPriceUpdate(trackedItemID string, snapshot *PriceSnapshot) PriceUpdate {
+	return PriceUpdate{
+		TrackedItemID:  trackedItemID,
+		AppID:          snapshot.AppID,
+		MarketHashName: snapshot.MarketHashName,
+		Currency:       snapshot.Currency,
+		LowestPrice:    snapshot.LowestPrice,
+		MedianPrice:    snapshot.MedianPrice,
+		Volume:         snapshot.Volume,
+		ObservedAt:     snapshot.ObservedAt,
+	}
+}
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TradeUpSize - сколько скинов отдаётся в контракт обмена
+const TradeUpSize = 10
+
+// TradeUpItem - входной скин контракта
+type TradeUpItem struct {
+	MarketHashName string  `json:"market_hash_name"`
+	Float          float64 `json:"float"`
+	Price          *int64  `json:"price"` // Цена покупки в валюте расчёта; nil - текущая цена площадки
+}
+
+// TradeUpSlot - входной скин, найденный в справочнике коллекций
+type TradeUpSlot struct {
+	Item       TradeUpItem
+	Skin       *SkinItem
+	Collection *Collection
+	StatTrak   bool
+	Souvenir   bool
+}
+
+// normalizedFloat - положение float входа в диапазоне его скина, 0..1
+func (s *TradeUpSlot) normalizedFloat() float64 {
+	if s.Skin.MaxFloat <= s.Skin.MinFloat {
+		return 0
+	}
+	return (s.Item.Float - s.Skin.MinFloat) / (s.Skin.MaxFloat - s.Skin.MinFloat)
+}
+
+// TradeUpOutcome - возможный результат контракта
+type TradeUpOutcome struct {
+	MarketHashName string   `json:"market_hash_name"`
+	Collection     string   `json:"collection"` // ID коллекции
+	Rarity         Rarity   `json:"rarity"`
+	Exterior       Exterior `json:"exterior"`
+	Float          float64  `json:"float"`
+	Probability    float64  `json:"probability"`
+	Price          int64    `json:"price"`           // Самый дешёвый лот на площадке; 0 - цены нет
+	SellerReceives int64    `json:"seller_receives"` // Price за вычетом комиссий площадки
+	Value          float64  `json:"value"`           // Вклад в ожидаемую выручку: Probability × SellerReceives
+	Profit         int64    `json:"profit"`          // SellerReceives - стоимость входов (при известных ценах)
+}
+
+// TradeUpOutcomes - возможные результаты контракта с вероятностями и float (без цен)
+//
+// Правила CS2: десять скинов одного качества (от ширпотреба до засекреченного),
+// все StatTrak или все обычные, без сувенирных. Вероятности - по модели билетов,
+// как в игре: каждый вход даёт по одному билету каждому скину следующего качества
+// своей коллекции, результат - случайный билет. Поэтому P(скин) = входов из его
+// коллекции / Σ по входам числа скинов следующего качества в коллекции входа:
+// 9 входов из коллекции с одним результатом и 1 из коллекции с пятью дают 9/14 и 5 × 1/14.
+// Float результата - минимум его диапазона плюс средний нормализованный float входов
+// (положение float входа в диапазоне его скина), умноженный на ширину диапазона.
+func TradeUpOutcomes(slots []TradeUpSlot) ([]TradeUpOutcome, error) {
+	if len(slots) != TradeUpSize {
+		return nil, fmt.Errorf("%w: trade-up needs exactly %d items, got %d", ErrValidation, TradeUpSize, len(slots))
+	}
+
+	rarity, statTrak := slots[0].Skin.Rarity, slots[0].StatTrak
+	if rarity.Rank() >= RarityCovert.Rank() {
+		return nil, fmt.Errorf("%w: %s items cannot be traded up", ErrValidation, rarity)
+	}
+	output := rarities[rarity.Rank()+1]
+
+	var average float64
+	for i := range slots {
+		slot := &slots[i]
+		name := slot.Item.MarketHashName
+		switch {
+		case slot.Souvenir:
+			return nil, fmt.Errorf("%w: souvenir item %q cannot be used in a trade-up", ErrValidation, name)
+		case slot.Skin.Rarity != rarity:
+			return nil, fmt.Errorf("%w: all items must be %s, %q is %s", ErrValidation, rarity, name, slot.Skin.Rarity)
+		case slot.StatTrak != statTrak:
+			return nil, fmt.Errorf("%w: StatTrak and regular items cannot be mixed", ErrValidation)
+		case slot.Item.Float < slot.Skin.MinFloat || slot.Item.Float > slot.Skin.MaxFloat:
+			return nil, fmt.Errorf("%w: float of %q must be within %g..%g", ErrValidation, name, slot.Skin.MinFloat, slot.Skin.MaxFloat)
+		case len(slot.Collection.ItemsOf(output)) == 0:
+			return nil, fmt.Errorf("%w: %s has no %s items to trade %q into", ErrValidation, slot.Collection.Name, output, name)
+		}
+		average += slot.normalizedFloat()
+	}
+	average /= TradeUpSize
+
+	var tickets int
+	for i := range slots {
+		tickets += len(slots[i].Collection.ItemsOf(output))
+	}
+	p := 1 / float64(tickets)
+
+	var outcomes []TradeUpOutcome
+	index := make(map[string]int)
+	for i := range slots {
+		pool := slots[i].Collection.ItemsOf(output)
+		for j := range pool {
+			skin := &pool[j]
+
+			// Один скин может выпасть из нескольких входов одной коллекции
+			if k, ok := index[skin.Name]; ok {
+				outcomes[k].Probability += p
+				continue
+			}
+
+			f := skin.MinFloat + average*(skin.MaxFloat-skin.MinFloat)
+			exterior := ExteriorForFloat(f)
+			index[skin.Name] = len(outcomes)
+			outcomes = append(outcomes, TradeUpOutcome{
+				MarketHashName: skin.MarketHashName(statTrak && skin.HasStatTrak(), exterior),
+				Collection:     slots[i].Collection.ID,
+				Rarity:         output,
+				Exterior:       exterior,
+				Float:          roundTo(f, 8),
+				Probability:    p,
+			})
+		}
+	}
+
+	return outcomes, nil
+}
+
+// TradeUpInputPrice - входной скин с ценой
+type TradeUpInputPrice struct {
+	MarketHashName string  `json:"market_hash_name"`
+	Collection     string  `json:"collection"`
+	Float          float64 `json:"float"`
+	Price          int64   `json:"price"` // 0 - цены нет (в Cost не входит)
+}
+
+// TradeUp - ожидаемый результат контракта обмена
+//
+// Результаты без цены на площадке в ожидание не входят, поэтому при Coverage < 1
+// ExpectedValue/ExpectedNet - оценка снизу. Суммы в сотых долях Currency.
+type TradeUp struct {
+	Rarity        Rarity              `json:"rarity"`
+	OutputRarity  Rarity              `json:"output_rarity"`
+	StatTrak      bool                `json:"stattrak"`
+	Venue         string              `json:"venue"`
+	Currency      Currency            `json:"currency"`
+	Inputs        []TradeUpInputPrice `json:"inputs"`
+	Cost          int64               `json:"cost"`           // Стоимость входов
+	CostCoverage  float64             `json:"cost_coverage"`  // Доля входов с известной ценой
+	ExpectedValue float64             `json:"expected_value"` // Σ вероятность × цена
+	ExpectedNet   float64             `json:"expected_net"`   // Σ вероятность × цена за вычетом комиссий
+	Profit        float64             `json:"profit"`         // ExpectedNet - Cost
+	ROI           float64             `json:"roi"`            // Profit / Cost, %
+	ProfitChance  float64             `json:"profit_chance"`  // Вероятность результата, который окупает входы
+	Coverage      float64             `json:"coverage"`       // Доля вероятности результатов с известной ценой
+	Outcomes      []TradeUpOutcome    `json:"outcomes"`       // По убыванию вклада
+	CalculatedAt  time.Time           `json:"calculated_at"`
+}
+
+// EvaluateTradeUp - ожидаемый результат контракта по ценам
+//
+// prices - цена (самый дешёвый лот) по market_hash_name; цена входа из запроса
+// важнее рыночной. sellerReceives - выручка продавца на площадке при цене gross.
+func EvaluateTradeUp(
+	slots []TradeUpSlot,
+	outcomes []TradeUpOutcome,
+	prices map[string]int64,
+	sellerReceives func(gross int64) int64,
+) *TradeUp {
+	tradeUp := &TradeUp{
+		Rarity:       slots[0].Skin.Rarity,
+		OutputRarity: outcomes[0].Rarity,
+		StatTrak:     slots[0].StatTrak,
+		Inputs:       make([]TradeUpInputPrice, len(slots)),
+		Outcomes:     outcomes,
+		CalculatedAt: time.Now().UTC(),
+	}
+
+	priced := 0
+	for i := range slots {
+		slot := &slots[i]
+		price := prices[slot.Item.MarketHashName]
+		if slot.Item.Price != nil {
+			price = *slot.Item.Price
+		}
+		tradeUp.Inputs[i] = TradeUpInputPrice{
+			MarketHashName: slot.Item.MarketHashName,
+			Collection:     slot.Collection.ID,
+			Float:          slot.Item.Float,
+			Price:          price,
+		}
+		if price > 0 || slot.Item.Price != nil {
+			tradeUp.Cost += price
+			priced++
+		}
+	}
+	tradeUp.CostCoverage = roundTo(float64(priced)/float64(len(slots)), 4)
+
+	for i := range tradeUp.Outcomes {
+		o := &tradeUp.Outcomes[i]
+		price := prices[o.MarketHashName]
+		if price <= 0 {
+			continue
+		}
+		o.Price = price
+		o.SellerReceives = sellerReceives(price)
+		o.Value = o.Probability * float64(o.SellerReceives)
+		o.Profit = o.SellerReceives - tradeUp.Cost
+
+		tradeUp.ExpectedValue += o.Probability * float64(price)
+		tradeUp.ExpectedNet += o.Value
+		tradeUp.Coverage += o.Probability
+		if o.Profit > 0 {
+			tradeUp.ProfitChance += o.Probability
+		}
+	}
+
+	tradeUp.Profit = tradeUp.ExpectedNet - float64(tradeUp.Cost)
+	if tradeUp.Cost > 0 {
+		tradeUp.ROI = roundTo(100*tradeUp.Profit/float64(tradeUp.Cost), 2)
+	}
+	tradeUp.ExpectedValue = roundTo(tradeUp.ExpectedValue, 2)
+	tradeUp.ExpectedNet = roundTo(tradeUp.ExpectedNet, 2)
+	tradeUp.Profit = roundTo(tradeUp.Profit, 2)
+	tradeUp.ProfitChance = roundTo(tradeUp.ProfitChance, 4)
+	tradeUp.Coverage = roundTo(tradeUp.Coverage, 4)
+
+	slices.SortStableFunc(tradeUp.Outcomes, func(a, b TradeUpOutcome) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	for i := range tradeUp.Outcomes {
+		tradeUp.Outcomes[i].Probability = roundTo(tradeUp.Outcomes[i].Probability, 6)
+		tradeUp.Outcomes[i].Value = roundTo(tradeUp.Outcomes[i].Value, 4)
+	}
+
+	return tradeUp
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// tradeUpCollections - коллекция A с одним результатом и коллекция B с пятью
+func tradeUpCollections() (*Collection, *Collection) {
+	a := &Collection{ID: "a", Name: "Collection A", Items: []SkinItem{
+		{Name: "AK-47 | Alpha", Rarity: RarityMilSpec, MinFloat: 0, MaxFloat: 1},
+		{Name: "M4A4 | Alpha", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 0.5},
+	}}
+	b := &Collection{ID: "b", Name: "Collection B", Items: []SkinItem{
+		{Name: "AWP | Beta", Rarity: RarityMilSpec, MinFloat: 0.2, MaxFloat: 0.6},
+		{Name: "Glock-18 | Beta 1", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 1},
+		{Name: "Glock-18 | Beta 2", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 1},
+		{Name: "Glock-18 | Beta 3", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 1},
+		{Name: "Glock-18 | Beta 4", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 1},
+		{Name: "Glock-18 | Beta 5", Rarity: RarityRestricted, MinFloat: 0, MaxFloat: 1},
+	}}
+	return a, b
+}
+
+func tradeUpSlot(c *Collection, skin int, float float64) TradeUpSlot {
+	s := &c.Items[skin]
+	return TradeUpSlot{
+		Item:       TradeUpItem{MarketHashName: s.MarketHashName(false, ExteriorForFloat(float)), Float: float},
+		Skin:       s,
+		Collection: c,
+	}
+}
+
+func TestTradeUpOutcomesTicketModel(t *testing.T) {
+	a, b := tradeUpCollections()
+
+	slots := make([]TradeUpSlot, 0, TradeUpSize)
+	for range 9 {
+		slots = append(slots, tradeUpSlot(a, 0, 0.2))
+	}
+	slots = append(slots, tradeUpSlot(b, 0, 0.4))
+
+	outcomes, err := TradeUpOutcomes(slots)
+	if err != nil {
+		t.Fatalf("TradeUpOutcomes: %v", err)
+	}
+	if len(outcomes) != 6 {
+		t.Fatalf("got %d outcomes, want 6", len(outcomes))
+	}
+
+	// 9 билетов у M4A4 из A и по одному у каждого из пяти скинов B
+	var total float64
+	for _, o := range outcomes {
+		want := 1.0 / 14
+		if o.Collection == "a" {
+			want = 9.0 / 14
+		}
+		if math.Abs(o.Probability-want) > 1e-12 {
+			t.Errorf("%s: probability %v, want %v", o.MarketHashName, o.Probability, want)
+		}
+		total += o.Probability
+	}
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("probabilities sum to %v, want 1", total)
+	}
+
+	// Средний нормализованный float: (9 × 0.2 + 0.5) / 10 = 0.23
+	if got := outcomes[0]; got.Float != 0.115 || got.Exterior != ExteriorMinimalWear {
+		t.Errorf("M4A4 float %v (%s), want 0.115 (%s)", got.Float, got.Exterior, ExteriorMinimalWear)
+	}
+	if got := outcomes[1]; got.Float != 0.23 || got.MarketHashName != "Glock-18 | Beta 1 (Field-Tested)" {
+		t.Errorf("Glock float %v (%s), want 0.23 (Glock-18 | Beta 1 (Field-Tested))", got.Float, got.MarketHashName)
+	}
+}
+
+func TestTradeUpOutcomesSingleCollection(t *testing.T) {
+	_, b := tradeUpCollections()
+
+	slots := make([]TradeUpSlot, TradeUpSize)
+	for i := range slots {
+		slots[i] = tradeUpSlot(b, 0, 0.3)
+	}
+
+	outcomes, err := TradeUpOutcomes(slots)
+	if err != nil {
+		t.Fatalf("TradeUpOutcomes: %v", err)
+	}
+	for _, o := range outcomes {
+		if math.Abs(o.Probability-0.2) > 1e-12 {
+			t.Errorf("%s: probability %v, want 0.2", o.MarketHashName, o.Probability)
+		}
+	}
+}
+
+func TestTradeUpOutcomesValidation(t *testing.T) {
+	a, b := tradeUpCollections()
+
+	full := func(mutate func(slots []TradeUpSlot)) []TradeUpSlot {
+		slots := make([]TradeUpSlot, TradeUpSize)
+		for i := range slots {
+			slots[i] = tradeUpSlot(a, 0, 0.2)
+		}
+		mutate(slots)
+		return slots
+	}
+
+	tests := []struct {
+		name  string
+		slots []TradeUpSlot
+	}{
+		{"too few items", full(func(s []TradeUpSlot) {})[:9]},
+		{"mixed rarity", full(func(s []TradeUpSlot) { s[3] = tradeUpSlot(b, 1, 0.2) })},
+		{"mixed stattrak", full(func(s []TradeUpSlot) { s[0].StatTrak = true })},
+		{"souvenir", full(func(s []TradeUpSlot) { s[5].Souvenir = true })},
+		{"float out of range", full(func(s []TradeUpSlot) { s[0] = tradeUpSlot(b, 0, 0.7) })},
+		{"no higher rarity", full(func(s []TradeUpSlot) {
+			for i := range s {
+				s[i] = tradeUpSlot(a, 1, 0.2)
+			}
+		})},
+	}
+
+	for _, tt := range tests {
+		if _, err := TradeUpOutcomes(tt.slots); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: error = %v, want ErrValidation", tt.name, err)
+		}
+	}
+}
+
+func TestEvaluateTradeUp(t *testing.T) {
+	a, b := tradeUpCollections()
+
+	slots := make([]TradeUpSlot, 0, TradeUpSize)
+	for range 9 {
+		slots = append(slots, tradeUpSlot(a, 0, 0.2))
+	}
+	slots = append(slots, tradeUpSlot(b, 0, 0.4))
+
+	outcomes, err := TradeUpOutcomes(slots)
+	if err != nil {
+		t.Fatalf("TradeUpOutcomes: %v", err)
+	}
+
+	prices := map[string]int64{
+		slots[0].Item.MarketHashName: 100,
+		slots[9].Item.MarketHashName: 100,
+		outcomes[0].MarketHashName:   1400,
+	}
+	tradeUp := EvaluateTradeUp(slots, outcomes, prices, func(gross int64) int64 { return gross })
+
+	if tradeUp.Cost != 1000 || tradeUp.CostCoverage != 1 {
+		t.Errorf("cost %d (coverage %v), want 1000 (1)", tradeUp.Cost, tradeUp.CostCoverage)
+	}
+	// Цена есть только у M4A4: 9/14 × 1400 = 900
+	if math.Abs(tradeUp.ExpectedNet-900) > 1e-9 {
+		t.Errorf("expected net %v, want 900", tradeUp.ExpectedNet)
+	}
+	if math.Abs(tradeUp.Coverage-9.0/14) > 1e-4 {
+		t.Errorf("coverage %v, want %v", tradeUp.Coverage, 9.0/14)
+	}
+}
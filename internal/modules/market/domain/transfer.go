@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ExportVersion - версия формата выгрузки; меняется при несовместимых изменениях полей
+const ExportVersion = 1
+
+// MaxImportRecords - сколько записей (всех видов) принимается за один импорт
+const MaxImportRecords = 10000
+
+// ItemRecord - отслеживаемый предмет в выгрузке
+type ItemRecord struct {
+	AppID          int      `json:"app_id"`
+	MarketHashName string   `json:"market_hash_name"`
+	Name           string   `json:"name"`
+	Notes          string   `json:"notes"`
+	Tags           []string `json:"tags"`
+}
+
+// LotRecord - сделка в выгрузке
+// Предмет указывается ключом, а не tracked_item_id: выгрузку можно загрузить в другой аккаунт.
+// ID - для повторного импорта своей же выгрузки; в таблицах его обычно нет.
+type LotRecord struct {
+	ID             string    `json:"id,omitempty"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Side           LotSide   `json:"side"`
+	Quantity       int64     `json:"quantity"`
+	UnitPrice      int64     `json:"unit_price"`
+	Fee            int64     `json:"fee"`
+	Currency       Currency  `json:"currency"`
+	ExecutedAt     time.Time `json:"executed_at"`
+	Notes          string    `json:"notes"`
+}
+
+// AlertRecord - правило алерта в выгрузке
+// nil поля при импорте - значения по умолчанию (для нового правила) или "не менять" (merge)
+type AlertRecord struct {
+	ID              string    `json:"id,omitempty"`
+	AppID           int       `json:"app_id"`
+	MarketHashName  string    `json:"market_hash_name"`
+	Type            AlertType `json:"type"`
+	Threshold       float64   `json:"threshold"`
+	Currency        *Currency `json:"currency"`
+	WindowSeconds   int64     `json:"window_seconds"`
+	Mode            AlertMode `json:"mode"`
+	CooldownSeconds *int64    `json:"cooldown_seconds"`
+	Enabled         *bool     `json:"enabled"`
+}
+
+// DataExport - выгрузка данных пользователя (она же - формат импорта JSON)
+type DataExport struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Items      []ItemRecord  `json:"items"`
+	Lots       []LotRecord   `json:"lots"`
+	Alerts     []AlertRecord `json:"alerts"`
+}
+
+// Size - число записей всех видов
+func (d *DataExport) Size() int {
+	return len(d.Items) + len(d.Lots) + len(d.Alerts)
+}
+
+// ImportMode - что делать с записью, которая уже есть у пользователя
+type ImportMode string
+
+const (
+	// ImportSkip - оставить существующую запись как есть (по умолчанию)
+	ImportSkip ImportMode = "skip"
+	// ImportOverwrite - заменить существующую запись импортируемой
+	ImportOverwrite ImportMode = "overwrite"
+	// ImportMerge - взять из импорта только заполненные поля; теги объединяются
+	ImportMerge ImportMode = "merge"
+)
+
+// ParseImportMode - режим из строки; пустая строка → ImportSkip
+func ParseImportMode(s string) (ImportMode, error) {
+	switch mode := ImportMode(s); mode {
+	case "":
+		return ImportSkip, nil
+	case ImportSkip, ImportOverwrite, ImportMerge:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: mode must be skip, overwrite or merge", ErrValidation)
+	}
+}
+
+// Что импорт сделал со строкой
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+	ImportActionError  = "error"
+)
+
+// Виды записей в отчёте импорта
+const (
+	RecordItem  = "item"
+	RecordLot   = "lot"
+	RecordAlert = "alert"
+)
+
+// ImportRowResult - результат по одной записи
+type ImportRowResult struct {
+	Kind           string `json:"kind"` // item | lot | alert
+	Row            int    `json:"row"`  // Номер записи своего вида, с 1 (для CSV - строка данных без заголовка)
+	MarketHashName string `json:"market_hash_name"`
+	Action         string `json:"action"` // create | update | skip | error
+	ID             string `json:"id,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// ImportReport - отчёт импорта
+// В режиме DryRun ничего не записывается, а отчёт показывает, что было бы сделано
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Mode    ImportMode        `json:"mode"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// Add - добавляет результат записи и обновляет счётчики
+func (r *ImportReport) Add(row ImportRowResult) {
+	switch row.Action {
+	case ImportActionCreate:
+		r.Created++
+	case ImportActionUpdate:
+		r.Updated++
+	case ImportActionSkip:
+		r.Skipped++
+	case ImportActionError:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// MergeTags - объединение тегов (оба списка уже нормализованы)
+func MergeTags(existing, imported []string) []string {
+	merged := append([]string{}, existing...)
+	for _, tag := range imported {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
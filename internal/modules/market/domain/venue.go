@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// VenueSteam - имя площадки Steam Community Market
+const VenueSteam = "steam"
+
+// VenueQuote - цена предмета на одной площадке
+type VenueQuote struct {
+	Venue          string    `json:"venue"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Currency       Currency  `json:"currency"`
+	LowestPrice    int64     `json:"lowest_price"` // Самый дешёвый лот (столько платит покупатель), 0 = нет лотов
+	MedianPrice    int64     `json:"median_price"` // 0 = площадка не отдаёт или нет продаж
+	Volume         int64     `json:"volume"`       // Продажи за сутки (Steam) или число лотов (прочие площадки)
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
+// NewVenueQuote - котировка из снимка цены Steam
+func NewVenueQuote(s *PriceSnapshot) VenueQuote {
+	return VenueQuote{
+		Venue:          VenueSteam,
+		AppID:          s.AppID,
+		MarketHashName: s.MarketHashName,
+		Currency:       s.Currency,
+		LowestPrice:    s.LowestPrice,
+		MedianPrice:    s.MedianPrice,
+		Volume:         s.Volume,
+		ObservedAt:     s.ObservedAt,
+	}
+}
+
+// Key - ключ предмета котировки
+func (q *VenueQuote) Key() ItemKey {
+	return ItemKey{AppID: q.AppID, MarketHashName: q.MarketHashName}
+}
+
+// Snapshot - снимок цены из котировки (для истории цен)
+func (q *VenueQuote) Snapshot() *PriceSnapshot {
+	return &PriceSnapshot{
+		AppID:          q.AppID,
+		MarketHashName: q.MarketHashName,
+		Currency:       q.Currency,
+		LowestPrice:    q.LowestPrice,
+		MedianPrice:    q.MedianPrice,
+		Volume:         q.Volume,
+		ObservedAt:     q.ObservedAt,
+	}
+}
+
+// DefaultArbitrageMinProfitPercent - порог выгоды по умолчанию
+const DefaultArbitrageMinProfitPercent = 5.0
+
+// ArbitrageOpportunity - купить на одной площадке и продать на другой
+//
+// Покупаем по самому дешёвому лоту BuyVenue, выставляем на SellVenue на 1 дешевле
+// её самого дешёвого лота и получаем SellerReceives после комиссии площадки.
+type ArbitrageOpportunity struct {
+	TrackedItemID  string    `json:"tracked_item_id"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	Currency       Currency  `json:"currency"`
+	BuyVenue       string    `json:"buy_venue"`
+	BuyPrice       int64     `json:"buy_price"`
+	SellVenue      string    `json:"sell_venue"`
+	SellPrice      int64     `json:"sell_price"`      // Цена нашего лота
+	SellerReceives int64     `json:"seller_receives"` // После комиссии SellVenue
+	Profit         int64     `json:"profit"`          // SellerReceives - BuyPrice
+	ProfitPercent  float64   `json:"profit_percent"`  // Profit от BuyPrice
+	SellVolume     int64     `json:"sell_volume"`     // Volume площадки продажи - насколько реально продать
+	QuotedAt       time.Time `json:"quoted_at"`       // Время более старой из двух котировок
+}
+
+// EvaluateArbitrage - выгода пары buy → sell; ok=false если у какой-то стороны нет лотов
+// sellerReceives - сколько продавец получит на площадке sell при цене лота gross
+// Котировки должны быть в одной валюте - конвертация на вызывающем
+func EvaluateArbitrage(buy, sell VenueQuote, sellerReceives func(gross int64) int64) (ArbitrageOpportunity, bool) {
+	if buy.LowestPrice <= 0 || sell.LowestPrice <= 1 {
+		return ArbitrageOpportunity{}, false
+	}
+
+	sellPrice := sell.LowestPrice - 1
+	receives := sellerReceives(sellPrice)
+	profit := receives - buy.LowestPrice
+
+	quotedAt := buy.ObservedAt
+	if sell.ObservedAt.Before(quotedAt) {
+		quotedAt = sell.ObservedAt
+	}
+
+	return ArbitrageOpportunity{
+		AppID:          buy.AppID,
+		MarketHashName: buy.MarketHashName,
+		Currency:       buy.Currency,
+		BuyVenue:       buy.Venue,
+		BuyPrice:       buy.LowestPrice,
+		SellVenue:      sell.Venue,
+		SellPrice:      sellPrice,
+		SellerReceives: receives,
+		Profit:         profit,
+		ProfitPercent:  float64(profit) / float64(buy.LowestPrice) * 100,
+		SellVolume:     sell.Volume,
+		QuotedAt:       quotedAt,
+	}, true
+}
+
+// ValidateArbitrageThreshold - проверка порога выгоды в процентах
+func ValidateArbitrageThreshold(minProfitPercent float64) error {
+	if minProfitPercent < -100 || minProfitPercent > 1000 {
+		return fmt.Errorf("%w: min_profit_pct must be within [-100, 1000]", ErrValidation)
+	}
+	return nil
+}
+
+// SellerReceivesAtBps - сумма продавцу при комиссии площадки feeBps (в сотых долях процента)
+// с округлением комиссии вверх, как это делают площадки
+func SellerReceivesAtBps(gross, feeBps int64) int64 {
+	if gross <= 0 {
+		return 0
+	}
+	fee := (gross*feeBps + 9999) / 10000
+	return gross - fee
+}
+
+// VenueStatus - как отработала площадка при сравнении
+type VenueStatus struct {
+	Venue  string `json:"venue"`
+	Quotes int    `json:"quotes"`          // Сколько предметов нашлось
+	Error  string `json:"error,omitempty"` // Площадка недоступна - её цены не участвуют
+}
+
+// PriceComparison - цены одного предмета на всех площадках
+type PriceComparison struct {
+	TrackedItemID  string                 `json:"tracked_item_id"`
+	AppID          int                    `json:"app_id"`
+	MarketHashName string                 `json:"market_hash_name"`
+	Currency       Currency               `json:"currency"`
+	Quotes         []VenueQuote           `json:"quotes"`        // По возрастанию LowestPrice
+	Opportunities  []ArbitrageOpportunity `json:"opportunities"` // Все пары площадок, выгодные первыми
+	Venues         []VenueStatus          `json:"venues"`
+}
+
+// ArbitrageReport - выгодные пары площадок по предметам пользователя
+type ArbitrageReport struct {
+	Currency         Currency               `json:"currency"`
+	MinProfitPercent float64                `json:"min_profit_percent"`
+	MinProfit        int64                  `json:"min_profit"`
+	Opportunities    []ArbitrageOpportunity `json:"opportunities"` // По убыванию ProfitPercent
+	Venues           []VenueStatus          `json:"venues"`
+	CalculatedAt     time.Time              `json:"calculated_at"`
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения на списки
+const (
+	MaxWatchlistNameLength        = 64
+	MaxWatchlistDescriptionLength = 500
+)
+
+// Watchlist - именованный список отслеживаемых предметов пользователя ("долгие", "флипы")
+//
+// Список только группирует предметы: удаление списка не трогает сами предметы,
+// удаление предмета убирает его из всех списков.
+// ShareToken задан - список доступен на чтение всем по публичной ссылке.
+type Watchlist struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ShareToken  *string   `json:"share_token"` // nil = список не опубликован
+	ItemCount   int       `json:"item_count"`  // Только чтение, считается при выборке
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewWatchlist - фабричный метод нового списка
+func NewWatchlist(userID, name, description string) *Watchlist {
+	now := time.Now()
+
+	return &Watchlist{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate - проверка инвариантов списка
+func (w *Watchlist) Validate() error {
+	if w.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if w.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(w.Name) > MaxWatchlistNameLength {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrValidation, MaxWatchlistNameLength)
+	}
+	if utf8.RuneCountInString(w.Description) > MaxWatchlistDescriptionLength {
+		return fmt.Errorf("%w: description is longer than %d characters", ErrValidation, MaxWatchlistDescriptionLength)
+	}
+	return nil
+}
+
+// Rename - меняет имя списка
+func (w *Watchlist) Rename(name string) {
+	w.Name = strings.TrimSpace(name)
+	w.UpdatedAt = time.Now()
+}
+
+// Describe - меняет описание списка
+func (w *Watchlist) Describe(description string) {
+	w.Description = strings.TrimSpace(description)
+	w.UpdatedAt = time.Now()
+}
+
+// Share - выдаёт новый токен публичной ссылки; старая ссылка перестаёт работать
+func (w *Watchlist) Share() error {
+	token, err := generateShareToken()
+	if err != nil {
+		return fmt.Errorf("generate share token: %w", err)
+	}
+	w.ShareToken = &token
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Unshare - закрывает публичную ссылку
+func (w *Watchlist) Unshare() {
+	w.ShareToken = nil
+	w.UpdatedAt = time.Now()
+}
+
+// generateShareToken - 256 бит из crypto/rand в base64url: токен нельзя подобрать перебором
+func generateShareToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// PublicWatchlistItem - предмет опубликованного списка
+// Заметки, теги и ID пользователя наружу не отдаются
+type PublicWatchlistItem struct {
+	AppID          int        `json:"app_id"`
+	MarketHashName string     `json:"market_hash_name"`
+	Name           string     `json:"name"`
+	LowestPrice    *int64     `json:"lowest_price"` // nil = цен ещё нет
+	MedianPrice    *int64     `json:"median_price"`
+	Volume         *int64     `json:"volume"`
+	ObservedAt     *time.Time `json:"observed_at"`
+}
+
+// PublicWatchlist - опубликованный список, как его видят по ссылке
+type PublicWatchlist struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Currency    Currency              `json:"currency"`
+	Items       []PublicWatchlistItem `json:"items"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
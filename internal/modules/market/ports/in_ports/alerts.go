@@ -0,0 +1,39 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// CreateAlertRuleInput - данные нового правила
+// Threshold: для price_below/price_above - цена в сотых долях валюты (1250 = 12.50),
+// для percent_change - проценты со знаком, для volume_spike - множитель к среднему объёму.
+// Currency - валюта ценового порога (ISO код); nil → валюта отображения пользователя
+type CreateAlertRuleInput struct {
+	TrackedItemID   string           `json:"tracked_item_id"`
+	Type            domain.AlertType `json:"type"`
+	Threshold       float64          `json:"threshold"`
+	Currency        *domain.Currency `json:"currency"`
+	WindowSeconds   int64            `json:"window_seconds"`
+	Mode            domain.AlertMode `json:"mode"`             // once (по умолчанию) | repeat
+	CooldownSeconds *int64           `json:"cooldown_seconds"` // nil → domain.DefaultAlertCooldown
+}
+
+// UpdateAlertRuleInput - частичное обновление (PATCH), nil = "не менять"
+type UpdateAlertRuleInput struct {
+	Threshold       *float64          `json:"threshold"`
+	Currency        *domain.Currency  `json:"currency"`
+	WindowSeconds   *int64            `json:"window_seconds"`
+	Mode            *domain.AlertMode `json:"mode"`
+	CooldownSeconds *int64            `json:"cooldown_seconds"`
+	Enabled         *bool             `json:"enabled"`
+}
+
+type AlertService interface {
+	ListRules(ctx context.Context, userID string) ([]domain.AlertRule, error)
+	CreateRule(ctx context.Context, userID string, input CreateAlertRuleInput) (*domain.AlertRule, error)
+	UpdateRule(ctx context.Context, userID, ruleID string, input UpdateAlertRuleInput) (*domain.AlertRule, error)
+	DeleteRule(ctx context.Context, userID, ruleID string) error
+	ListFired(ctx context.Context, userID string, limit int) ([]domain.FiredAlert, error)
+}
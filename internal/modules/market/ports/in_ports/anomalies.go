@@ -0,0 +1,31 @@
+package in_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// AnomalyQuery - выборка аномалий
+// Нулевые From/To - без ограничения; пустой Severity - любые уровни, иначе не ниже указанного
+// Нулевая Currency → валюта отображения из настроек пользователя
+type AnomalyQuery struct {
+	From     time.Time
+	To       time.Time
+	Severity domain.AnomalySeverity
+	Limit    int
+	Currency domain.Currency
+}
+
+type AnomalyService interface {
+	// ListAnomalies - аномалии по всем отслеживаемым предметам пользователя, новые сверху
+	ListAnomalies(ctx context.Context, userID string, query AnomalyQuery) ([]domain.Anomaly, error)
+
+	// ListItemAnomalies - аномалии одного отслеживаемого предмета
+	ListItemAnomalies(ctx context.Context, userID, itemID string, query AnomalyQuery) ([]domain.Anomaly, error)
+
+	// ScanItem - прогоняет детекторы по сохранённой истории предмета и сохраняет новые аномалии
+	// Нужен для истории, собранной до включения детекторов или до смены порогов
+	ScanItem(ctx context.Context, userID, itemID string) (*domain.AnomalyScan, error)
+}
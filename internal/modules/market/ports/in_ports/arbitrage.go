@@ -0,0 +1,30 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ArbitrageQuery - параметры поиска арбитража
+// nil MinProfitPercent → DefaultArbitrageMinProfitPercent
+// Пустой Venues → все площадки; нулевая Currency → валюта отображения пользователя
+type ArbitrageQuery struct {
+	MinProfitPercent *float64
+	MinProfit        int64 // Абсолютный минимум выгоды в Currency, 0 = без ограничения
+	Venues           []string
+	Currency         domain.Currency
+	Limit            int
+}
+
+type ArbitrageService interface {
+	// ListVenues - имена подключённых площадок
+	ListVenues() []string
+
+	// ComparePrices - цены отслеживаемого предмета на всех площадках
+	ComparePrices(ctx context.Context, userID, itemID string, currency domain.Currency) (*domain.PriceComparison, error)
+
+	// FindArbitrage - предметы пользователя, которые выгодно купить на одной площадке
+	// и продать на другой с учётом комиссий
+	FindArbitrage(ctx context.Context, userID string, query ArbitrageQuery) (*domain.ArbitrageReport, error)
+}
@@ -0,0 +1,19 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+type BackfillService interface {
+	// GetItemBackfill - последняя задача загрузки истории отслеживаемого предмета
+	GetItemBackfill(ctx context.Context, userID, itemID string) (*domain.BackfillJob, error)
+
+	// ListJobs - задачи всех предметов (для администратора)
+	ListJobs(ctx context.Context, filter domain.BackfillJobFilter) ([]domain.BackfillJob, error)
+
+	// TriggerBackfill - ставит повторную загрузку истории предмета в очередь
+	// ErrAlreadyExists если незавершённая задача уже есть
+	TriggerBackfill(ctx context.Context, adminID string, key domain.ItemKey) (*domain.BackfillJob, error)
+}
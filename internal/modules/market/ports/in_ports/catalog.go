@@ -0,0 +1,19 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+type CatalogService interface {
+	// ListCatalog - карточки предметов по фильтру
+	ListCatalog(ctx context.Context, filter domain.CatalogFilter) ([]domain.CatalogItem, error)
+
+	// Search - подсказки по тексту с учётом опечаток, лучшие совпадения первыми
+	// Предметы, за которыми пользователь уже следит, помечены tracked_item_id
+	Search(ctx context.Context, userID string, query domain.CatalogSearchQuery) ([]domain.CatalogSearchResult, error)
+
+	// ParseName - структура market_hash_name CS2 (без обращения к каталогу)
+	ParseName(name string) domain.ParsedName
+}
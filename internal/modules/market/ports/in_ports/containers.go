@@ -0,0 +1,23 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ContainerEVQuery - параметры расчёта ожидаемой ценности кейса
+// Пустой Venue → Steam; нулевая Currency → валюта отображения пользователя
+type ContainerEVQuery struct {
+	Venue    string
+	Currency domain.Currency
+}
+
+type ContainerService interface {
+	// ListContainers - кейсы из справочника
+	ListContainers(ctx context.Context) []domain.ContainerSummary
+
+	// ContainerEV - ожидаемая ценность открытия кейса по текущим ценам площадки
+	// с учётом ключа и комиссий, с разбивкой по вариантам выпадения
+	ContainerEV(ctx context.Context, userID, containerID string, query ContainerEVQuery) (*domain.ContainerEV, error)
+}
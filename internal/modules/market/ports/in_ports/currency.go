@@ -0,0 +1,23 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// UpdatePreferencesInput - частичное обновление настроек (PUT), nil = "не менять"
+// Валюта принимается ISO кодом ("EUR") или ID Steam (3); steam_id "" отвязывает аккаунт
+type UpdatePreferencesInput struct {
+	DisplayCurrency *domain.Currency `json:"display_currency"`
+	SteamID         *string          `json:"steam_id"`
+}
+
+type CurrencyService interface {
+	// GetPreferences - настройки пользователя (значения по умолчанию, если он их не менял)
+	GetPreferences(ctx context.Context, userID string) (*domain.UserPreferences, error)
+	UpdatePreferences(ctx context.Context, userID string, input UpdatePreferencesInput) (*domain.UserPreferences, error)
+
+	// GetRates - текущие курсы относительно канонической валюты
+	GetRates(ctx context.Context) (*domain.ExchangeRates, error)
+}
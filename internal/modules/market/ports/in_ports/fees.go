@@ -0,0 +1,20 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// FeeQuery - запрос калькулятора комиссий
+// Задаётся ровно одна из сумм (в сотых долях валюты); AppID 0 → CS2 (730)
+type FeeQuery struct {
+	AppID          int
+	BuyerPays      int64
+	SellerReceives int64
+}
+
+type FeeService interface {
+	// CalculateFees - "покупатель платит" → "продавец получает" или обратно
+	CalculateFees(ctx context.Context, query FeeQuery) (*domain.FeeQuote, error)
+}
@@ -0,0 +1,26 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ForecastQuery - параметры прогноза
+// Нулевые Days/HistoryDays → значения по умолчанию; пустой Models - все модели
+// Нулевая Currency → валюта отображения из настроек пользователя
+type ForecastQuery struct {
+	Days        int
+	HistoryDays int
+	Models      []string
+	Currency    domain.Currency
+}
+
+type ForecastService interface {
+	// ForecastItem - прогноз цены отслеживаемого предмета на query.Days дней
+	// по дневной истории за query.HistoryDays, с ошибкой каждой модели на этой истории
+	ForecastItem(ctx context.Context, userID, itemID string, query ForecastQuery) (*domain.ItemForecast, error)
+
+	// Models - имена доступных моделей
+	Models() []string
+}
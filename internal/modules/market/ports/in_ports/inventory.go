@@ -0,0 +1,22 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ImportInventoryInput - параметры импорта инвентаря
+// Пустой SteamID → привязанный в /market/preferences аккаунт; AppID 0 → CS2 (730), ContextID "" → "2"
+type ImportInventoryInput struct {
+	SteamID        string `json:"steam_id"`
+	AppID          int    `json:"app_id"`
+	ContextID      string `json:"context_id"`
+	Track          bool   `json:"track"`           // Добавить продаваемые предметы в отслеживаемые
+	MarketableOnly bool   `json:"marketable_only"` // Не показывать предметы, которые нельзя продать
+}
+
+type InventoryService interface {
+	// ImportInventory - загружает публичный инвентарь и оценивает его по последним известным ценам
+	ImportInventory(ctx context.Context, userID string, input ImportInventoryInput) (*domain.InventoryValuation, error)
+}
@@ -0,0 +1,13 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+type LiquidityService interface {
+	// GetItemLiquidity - метрики ликвидности отслеживаемого предмета
+	// Если фоновый воркер до предмета ещё не дошёл, метрики считаются прямо в запросе
+	GetItemLiquidity(ctx context.Context, userID, itemID string) (*domain.ItemLiquidity, error)
+}
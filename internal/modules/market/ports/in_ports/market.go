@@ -6,6 +6,32 @@ import (
 	"steam-observer/internal/modules/market/domain"
 )
 
+// CreateTrackedItemInput - данные для начала отслеживания предмета
+type CreateTrackedItemInput struct {
+	AppID          int      `json:"app_id"` // 0 → CS2 (730)
+	MarketHashName string   `json:"market_hash_name"`
+	Name           string   `json:"name"`
+	Notes          string   `json:"notes"`
+	Tags           []string `json:"tags"`
+}
+
+// UpdateTrackedItemInput - частичное обновление (PATCH)
+// nil поле = "не менять"
+// Tags заменяет теги целиком: [] снимает все
+type UpdateTrackedItemInput struct {
+	Name  *string   `json:"name"`
+	Notes *string   `json:"notes"`
+	Tags  *[]string `json:"tags"`
+}
+
 type MarketService interface {
-	ListTrackedItems(ctx context.Context, userID string) ([]domain.TrackedItem, error)
+	// ListTrackedItems - предметы пользователя; пустой фильтр - все
+	// Список из фильтра должен принадлежать пользователю (иначе ErrNotFound)
+	// Предметы приходят с посчитанной ликвидностью (Liquidity)
+	ListTrackedItems(ctx context.Context, userID string, filter domain.TrackedItemFilter) ([]domain.TrackedItem, error)
+	// GetTrackedItem - предмет пользователя с ликвидностью; чужой или несуществующий → ErrNotFound
+	GetTrackedItem(ctx context.Context, userID, itemID string) (*domain.TrackedItem, error)
+	CreateTrackedItem(ctx context.Context, userID string, input CreateTrackedItemInput) (*domain.TrackedItem, error)
+	UpdateTrackedItem(ctx context.Context, userID, itemID string, input UpdateTrackedItemInput) (*domain.TrackedItem, error)
+	DeleteTrackedItem(ctx context.Context, userID, itemID string) error
 }
@@ -0,0 +1,37 @@
+package in_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// OrderBookQuery - параметры метрик и графика глубины
+// Нулевой Percent → значение по умолчанию (DefaultDepthPercent / DefaultDepthChartPercent)
+// Нулевая Currency → валюта отображения из настроек пользователя
+type OrderBookQuery struct {
+	Percent  float64
+	Currency domain.Currency
+}
+
+// OrderBookHistoryQuery - метрики стакана за период
+// Нулевые From/To → последние сутки
+type OrderBookHistoryQuery struct {
+	From         time.Time
+	To           time.Time
+	DepthPercent float64
+	Currency     domain.Currency
+}
+
+type OrderBookService interface {
+	// GetOrderBookMetrics - лучшие цены, спред и глубина по последнему снимку стакана
+	// Если снимков ещё нет, стакан снимается из Steam прямо в запросе
+	GetOrderBookMetrics(ctx context.Context, userID, itemID string, query OrderBookQuery) (*domain.OrderBookMetrics, error)
+
+	// GetDepthChart - накопленные объёмы обеих сторон по последнему снимку
+	GetDepthChart(ctx context.Context, userID, itemID string, query OrderBookQuery) (*domain.DepthChart, error)
+
+	// GetOrderBookHistory - метрики каждого сохранённого снимка за период
+	GetOrderBookHistory(ctx context.Context, userID, itemID string, query OrderBookHistoryQuery) ([]domain.OrderBookMetrics, error)
+}
@@ -0,0 +1,31 @@
+package in_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// CreateLotInput - новая сделка
+// Цены в сотых долях валюты; Currency nil → валюта отображения; ExecutedAt nil → сейчас
+type CreateLotInput struct {
+	TrackedItemID string           `json:"tracked_item_id"`
+	Side          domain.LotSide   `json:"side"`
+	Quantity      int64            `json:"quantity"`
+	UnitPrice     int64            `json:"unit_price"`
+	Fee           int64            `json:"fee"`
+	Currency      *domain.Currency `json:"currency"`
+	ExecutedAt    *time.Time       `json:"executed_at"`
+	Notes         string           `json:"notes"`
+}
+
+type PortfolioService interface {
+	// ListLots - сделки пользователя; trackedItemID "" → по всем предметам
+	ListLots(ctx context.Context, userID, trackedItemID string) ([]domain.Lot, error)
+	CreateLot(ctx context.Context, userID string, input CreateLotInput) (*domain.Lot, error)
+	DeleteLot(ctx context.Context, userID, lotID string) error
+
+	// GetPortfolio - FIFO P&L по всем предметам со сделками в валюте отображения
+	GetPortfolio(ctx context.Context, userID string) (*domain.Portfolio, error)
+}
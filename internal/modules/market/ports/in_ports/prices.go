@@ -0,0 +1,23 @@
+package in_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// CandlesQuery - параметры запроса свечей
+// Нулевые From/To → значения по умолчанию (To = сейчас, From = To - interval.DefaultRange())
+// Нулевая Currency → валюта отображения из настроек пользователя
+type CandlesQuery struct {
+	Interval domain.CandleInterval
+	From     time.Time
+	To       time.Time
+	Currency domain.Currency
+}
+
+type PriceService interface {
+	// GetCandles - OHLC свечи по истории цен отслеживаемого предмета пользователя
+	GetCandles(ctx context.Context, userID, itemID string, query CandlesQuery) (*domain.CandleSeries, error)
+}
@@ -0,0 +1,29 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// StreamOptions - параметры подписки на поток событий
+type StreamOptions struct {
+	LastEventID uint64          // ID последнего полученного события (Last-Event-ID); 0 - только новые события
+	Currency    domain.Currency // Валюта цен; 0 - валюта отображения пользователя
+}
+
+// StreamSubscription - открытая подписка одного соединения
+type StreamSubscription interface {
+	// Events - события по возрастанию ID
+	// Канал закрывается, если клиент не успевает читать (переполнен буфер) или сервер останавливается
+	Events() <-chan domain.StreamEvent
+
+	// Close - отписка; повторный вызов безопасен
+	Close()
+}
+
+type StreamService interface {
+	// Subscribe - цены и алерты по предметам пользователя по мере их появления
+	// С LastEventID сначала приходят пропущенные события (или StreamResync, если их уже не восстановить)
+	Subscribe(ctx context.Context, userID string, opts StreamOptions) (StreamSubscription, error)
+}
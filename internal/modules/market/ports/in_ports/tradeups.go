@@ -0,0 +1,23 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// EvaluateTradeUpInput - контракт обмена для расчёта
+type EvaluateTradeUpInput struct {
+	Inputs   []domain.TradeUpItem `json:"inputs"`   // Ровно domain.TradeUpSize скинов
+	Venue    string               `json:"venue"`    // Площадка для цен; пусто - Steam
+	Currency *domain.Currency     `json:"currency"` // nil - валюта отображения пользователя
+}
+
+type TradeUpService interface {
+	// ListCollections - коллекции справочника со скинами по качествам
+	ListCollections(ctx context.Context) []domain.Collection
+
+	// EvaluateTradeUp - возможные результаты контракта, их вероятности и float,
+	// ожидаемая прибыль по текущим ценам площадки с учётом комиссий
+	EvaluateTradeUp(ctx context.Context, userID string, input EvaluateTradeUpInput) (*domain.TradeUp, error)
+}
@@ -0,0 +1,22 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ImportOptions - как поступать с импортируемыми записями
+type ImportOptions struct {
+	Mode   domain.ImportMode // Конфликт с существующей записью: skip | overwrite | merge
+	DryRun bool              // Только проверить и показать отчёт, ничего не записывая
+}
+
+type TransferService interface {
+	// Export - предметы, сделки и правила алертов пользователя
+	Export(ctx context.Context, userID string) (*domain.DataExport, error)
+
+	// Import - загружает записи; ошибка в одной записи не мешает остальным и попадает в отчёт
+	// Порядок: предметы, затем сделки и алерты - они ссылаются на предметы по ключу
+	Import(ctx context.Context, userID string, data domain.DataExport, opts ImportOptions) (*domain.ImportReport, error)
+}
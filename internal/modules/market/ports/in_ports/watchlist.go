@@ -0,0 +1,39 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// CreateWatchlistInput - данные нового списка
+type CreateWatchlistInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// UpdateWatchlistInput - частичное обновление (PATCH), nil = "не менять"
+type UpdateWatchlistInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type WatchlistService interface {
+	ListWatchlists(ctx context.Context, userID string) ([]domain.Watchlist, error)
+	CreateWatchlist(ctx context.Context, userID string, input CreateWatchlistInput) (*domain.Watchlist, error)
+	UpdateWatchlist(ctx context.Context, userID, watchlistID string, input UpdateWatchlistInput) (*domain.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, userID, watchlistID string) error
+
+	// AddItem / RemoveItem - состав списка; список и предмет должны принадлежать пользователю
+	AddItem(ctx context.Context, userID, watchlistID, itemID string) error
+	RemoveItem(ctx context.Context, userID, watchlistID, itemID string) error
+
+	// Share - открывает список по ссылке (новый токен при каждом вызове, старая ссылка перестаёт работать)
+	Share(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error)
+	// Unshare - закрывает ссылку
+	Unshare(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error)
+
+	// GetShared - опубликованный список по токену, без авторизации
+	// Цены - по последним снимкам в currency (0 → каноническая валюта)
+	GetShared(ctx context.Context, token string, currency domain.Currency) (*domain.PublicWatchlist, error)
+}
@@ -0,0 +1,13 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// AlertNotifier - доставка сработавшего алерта пользователю
+// Market не знает о каналах доставки: это забота модуля notifications
+type AlertNotifier interface {
+	NotifyAlert(ctx context.Context, fired *domain.FiredAlert) error
+}
@@ -0,0 +1,41 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// AlertRepository - хранилище правил алертов и истории срабатываний
+type AlertRepository interface {
+	// ListRulesByUser - все правила пользователя, новые сверху
+	ListRulesByUser(ctx context.Context, userID string) ([]domain.AlertRule, error)
+
+	// FindRuleByID - правило пользователя по ID (чужое → ErrNotFound)
+	FindRuleByID(ctx context.Context, userID, ruleID string) (*domain.AlertRule, error)
+
+	// ListEnabledRulesByItem - включённые правила всех пользователей на предмет
+	// Используется при оценке нового снимка цены
+	ListEnabledRulesByItem(ctx context.Context, key domain.ItemKey) ([]domain.AlertRule, error)
+
+	// CreateRule - сохраняет новое правило
+	CreateRule(ctx context.Context, rule *domain.AlertRule) error
+
+	// UpdateRule - сохраняет изменяемые пользователем поля правила (включая enabled)
+	// last_fired_at не перезаписывается, а читается из БД - его меняет только MarkFired
+	UpdateRule(ctx context.Context, rule *domain.AlertRule) error
+
+	// MarkFired - фиксирует срабатывание: last_fired_at = firedAt, при disable правило выключается
+	// Только если правило всё ещё включено и его cooldown истёк, иначе ErrNotFound
+	MarkFired(ctx context.Context, ruleID string, firedAt time.Time, disable bool) error
+
+	// DeleteRule - удаляет правило пользователя
+	DeleteRule(ctx context.Context, userID, ruleID string) error
+
+	// SaveFired - добавляет запись в историю срабатываний
+	SaveFired(ctx context.Context, fired *domain.FiredAlert) error
+
+	// ListFiredByUser - история срабатываний пользователя, новые сверху
+	ListFiredByUser(ctx context.Context, userID string, limit int) ([]domain.FiredAlert, error)
+}
@@ -0,0 +1,27 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// AnomalyFilter - выборка аномалий
+// Нулевые From/To - без ограничения; пустой Severities - любые уровни
+type AnomalyFilter struct {
+	From       time.Time
+	To         time.Time
+	Severities []domain.AnomalySeverity
+	Limit      int
+}
+
+// AnomalyRepository - хранилище найденных аномалий цен
+type AnomalyRepository interface {
+	// Save - сохраняет аномалии; уже сохранённые (тот же снимок и детектор) пропускаются
+	// Возвращает число новых
+	Save(ctx context.Context, anomalies []domain.Anomaly) (int, error)
+
+	// ListByKeys - аномалии предметов, новые сверху
+	ListByKeys(ctx context.Context, keys []domain.ItemKey, filter AnomalyFilter) ([]domain.Anomaly, error)
+}
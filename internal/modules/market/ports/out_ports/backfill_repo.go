@@ -0,0 +1,46 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// BackfillJobRepository - задачи загрузки истории цен и сохранение загруженных точек
+type BackfillJobRepository interface {
+	// Create - сохраняет новую задачу, заполняет job.ID
+	// Возвращает ErrAlreadyExists, если у предмета уже есть незавершённая задача
+	Create(ctx context.Context, job *domain.BackfillJob) error
+
+	// EnqueueMissing - pending задачи trigger=tracked для предметов, по которым задач ещё не было
+	// Возвращает число созданных задач
+	EnqueueMissing(ctx context.Context, keys []domain.ItemKey) (int, error)
+
+	// ClaimNext - переводит в running самую старую pending задачу с наступившим NextAttemptAt
+	// ErrNotFound если таких нет
+	ClaimNext(ctx context.Context, now time.Time) (*domain.BackfillJob, error)
+
+	// ResetRunning - running → pending для задач, прерванных остановкой процесса
+	ResetRunning(ctx context.Context) (int, error)
+
+	// SaveChunk - одной транзакцией сохраняет порцию истории и продвинутый курсор задачи
+	// (job.Advance уже вызван); повторное сохранение точек перезаписывает их
+	SaveChunk(ctx context.Context, job *domain.BackfillJob, currency domain.Currency, chunk []domain.PriceHistoryPoint) error
+
+	// Update - сохраняет статус, счётчики и ошибку задачи
+	Update(ctx context.Context, job *domain.BackfillJob) error
+
+	// FindLatest - последняя задача предмета; ErrNotFound если задач не было
+	FindLatest(ctx context.Context, key domain.ItemKey) (*domain.BackfillJob, error)
+
+	// List - задачи по убыванию времени создания
+	List(ctx context.Context, filter domain.BackfillJobFilter) ([]domain.BackfillJob, error)
+}
+
+// PriceHistoryRepository - загруженная история продаж Steam
+type PriceHistoryRepository interface {
+	// ListRange - точки предмета за [from, to) по возрастанию времени
+	// MedianPrice - цена точки, Volume - продажи за её период (час или день)
+	ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.PriceSnapshot, error)
+}
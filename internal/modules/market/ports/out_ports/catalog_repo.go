@@ -0,0 +1,27 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// CatalogRepository - каталог предметов маркета (общий для всех пользователей)
+type CatalogRepository interface {
+	// Upsert - создаёт или обновляет карточку
+	// Пустые icon_url / rarity не затирают уже известные значения
+	Upsert(ctx context.Context, item *domain.CatalogItem) error
+
+	// FindByNameFold - карточка по имени без учёта регистра; ErrNotFound если нет
+	FindByNameFold(ctx context.Context, appID int, marketHashName string) (*domain.CatalogItem, error)
+
+	// List - карточки по фильтру, по имени
+	List(ctx context.Context, filter domain.CatalogFilter) ([]domain.CatalogItem, error)
+
+	// Search - нечёткий поиск по market_hash_name (триграммы) с фильтрами query.Filter
+	// Сначала совпадения по префиксу, затем по подстроке, затем по убыванию схожести
+	Search(ctx context.Context, query domain.CatalogSearchQuery) ([]domain.CatalogSearchResult, error)
+
+	// ListMissingTracked - отслеживаемые предметы, которых ещё нет в каталоге
+	ListMissingTracked(ctx context.Context) ([]domain.ItemKey, error)
+}
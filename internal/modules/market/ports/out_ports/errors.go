@@ -0,0 +1,9 @@
+package out_ports
+
+import "errors"
+
+// ErrNotFound - запись не найдена (или принадлежит другому пользователю)
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists - нарушение уникальности (например, предмет уже отслеживается)
+var ErrAlreadyExists = errors.New("already exists")
@@ -0,0 +1,27 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// RateSource - источник курсов валют (встроенная таблица, файл, внешний API)
+type RateSource interface {
+	// Name - короткое имя источника для логов и exchange_rates.source
+	Name() string
+
+	// FetchRates - актуальные курсы; база может отличаться от канонической валюты,
+	// приведение делает вызывающий (ExchangeRates.Rebase)
+	FetchRates(ctx context.Context) (*domain.ExchangeRates, error)
+}
+
+// ExchangeRateRepository - последние известные курсы
+// Нужен чтобы после рестарта конвертация работала сразу, даже если источник недоступен
+type ExchangeRateRepository interface {
+	// Save - заменяет сохранённые курсы новыми
+	Save(ctx context.Context, rates *domain.ExchangeRates) error
+
+	// Load - сохранённые курсы; ErrNotFound если таблица пуста
+	Load(ctx context.Context) (*domain.ExchangeRates, error)
+}
@@ -0,0 +1,23 @@
+package out_ports
+
+import "steam-observer/internal/modules/market/domain"
+
+// GameData - справочник CS2: коллекции скинов по качествам и кейсы с таблицами выпадений
+// Загружается целиком при старте и дальше только читается, поэтому без context
+type GameData interface {
+	// Containers - все кейсы в порядке справочника
+	Containers() []domain.Container
+
+	// Container - кейс по ID; ErrNotFound если его нет в справочнике
+	Container(id string) (*domain.Container, error)
+
+	// Collections - все коллекции в порядке справочника
+	Collections() []domain.Collection
+
+	// Collection - коллекция по ID; ErrNotFound если её нет в справочнике
+	Collection(id string) (*domain.Collection, error)
+
+	// FindSkin - скин коллекции по имени без StatTrak и износа ("AK-47 | Redline")
+	// и его коллекция; ErrNotFound если скина нет ни в одной коллекции
+	FindSkin(name string) (*domain.SkinItem, *domain.Collection, error)
+}
@@ -0,0 +1,19 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// LiquidityRepository - последние посчитанные метрики ликвидности предметов
+type LiquidityRepository interface {
+	// Save - сохраняет метрики предмета, заменяя предыдущие
+	Save(ctx context.Context, liquidity *domain.ItemLiquidity) error
+
+	// Find - метрики предмета; ErrNotFound если ещё не посчитаны
+	Find(ctx context.Context, key domain.ItemKey) (*domain.ItemLiquidity, error)
+
+	// ListByKeys - метрики предметов (для непосчитанных записей нет)
+	ListByKeys(ctx context.Context, keys []domain.ItemKey) ([]domain.ItemLiquidity, error)
+}
@@ -0,0 +1,27 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// LotRepository - хранилище сделок портфеля
+// Как и TrackedItemRepository, чужие сделки для репозитория не существуют (ErrNotFound)
+type LotRepository interface {
+	// ListByUser - все сделки пользователя по времени сделки
+	ListByUser(ctx context.Context, userID string) ([]domain.Lot, error)
+
+	// ListByItem - сделки по одному отслеживаемому предмету по времени сделки
+	ListByItem(ctx context.Context, userID, trackedItemID string) ([]domain.Lot, error)
+
+	// FindByID - сделка пользователя по ID
+	FindByID(ctx context.Context, userID, lotID string) (*domain.Lot, error)
+
+	Create(ctx context.Context, lot *domain.Lot) error
+
+	// Update - сохраняет поля сделки, кроме предмета (ErrNotFound, если сделка чужая)
+	Update(ctx context.Context, lot *domain.Lot) error
+
+	Delete(ctx context.Context, userID, lotID string) error
+}
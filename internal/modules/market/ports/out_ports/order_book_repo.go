@@ -0,0 +1,26 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// OrderBookRepository - снимки стаканов и item_nameid предметов
+type OrderBookRepository interface {
+	// Save - сохраняет снимок стакана
+	Save(ctx context.Context, book *domain.OrderBook) error
+
+	// Latest - последний снимок предмета; ErrNotFound если снимков нет
+	Latest(ctx context.Context, key domain.ItemKey) (*domain.OrderBook, error)
+
+	// ListRange - снимки предмета за [from, to) по возрастанию времени
+	ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.OrderBook, error)
+
+	// FindItemNameID - сохранённый item_nameid; ErrNotFound если ещё не известен
+	FindItemNameID(ctx context.Context, key domain.ItemKey) (int64, error)
+
+	// SaveItemNameID - запоминает item_nameid предмета
+	SaveItemNameID(ctx context.Context, key domain.ItemKey, itemNameID int64) error
+}
@@ -0,0 +1,16 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// UserPreferencesRepository - хранилище настроек пользователя
+type UserPreferencesRepository interface {
+	// Get - настройки пользователя; ErrNotFound если он их ещё не менял
+	Get(ctx context.Context, userID string) (*domain.UserPreferences, error)
+
+	// Upsert - создаёт или перезаписывает настройки, заполняет UpdatedAt
+	Upsert(ctx context.Context, prefs *domain.UserPreferences) error
+}
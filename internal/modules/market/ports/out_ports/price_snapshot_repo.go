@@ -0,0 +1,20 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// PriceSnapshotRepository - хранилище снимков цен
+type PriceSnapshotRepository interface {
+	// Save - сохраняет снимок, заполняет snapshot.ID
+	Save(ctx context.Context, snapshot *domain.PriceSnapshot) error
+
+	// ListRange - снимки предмета за [from, to), отсортированные по времени по возрастанию
+	ListRange(ctx context.Context, key domain.ItemKey, from, to time.Time) ([]domain.PriceSnapshot, error)
+
+	// LatestByKeys - последний снимок по каждому ключу; ключей без снимков в map нет
+	LatestByKeys(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.PriceSnapshot, error)
+}
@@ -0,0 +1,21 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// PriceSource - площадка, с которой берутся цены предметов (Steam, сторонние маркеты)
+type PriceSource interface {
+	// Name - имя площадки в ответах API ("steam", "skinport", ...)
+	Name() string
+
+	// SellerReceives - сколько продавец получит на площадке, выставив лот по цене gross
+	// (в валюте котировок этой площадки)
+	SellerReceives(appID int, gross int64) int64
+
+	// FetchQuotes - текущие котировки по ключам
+	// Предметов, которых на площадке нет, в map нет; ошибка - только если площадка недоступна целиком
+	FetchQuotes(ctx context.Context, keys []domain.ItemKey) (map[domain.ItemKey]domain.VenueQuote, error)
+}
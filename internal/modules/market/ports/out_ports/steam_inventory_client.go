@@ -0,0 +1,18 @@
+package out_ports
+
+import (
+	"context"
+	"errors"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ErrInventoryPrivate - инвентарь скрыт настройками приватности (или аккаунта нет)
+var ErrInventoryPrivate = errors.New("steam: inventory is private or does not exist")
+
+// SteamInventoryClient - публичный инвентарь Steam
+// (GET /inventory/{steamid}/{appid}/{contextid})
+type SteamInventoryClient interface {
+	// GetInventory - все предметы инвентаря контекста (постранично, до лимита адаптера)
+	GetInventory(ctx context.Context, steamID string, appID int, contextID string) ([]domain.InventoryAsset, error)
+}
@@ -0,0 +1,38 @@
+package out_ports
+
+import (
+	"context"
+	"errors"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// ErrSteamNoData - Steam ответил success:false или пустым телом
+// Обычно означает неверный market_hash_name или отсутствие продаж
+var ErrSteamNoData = errors.New("steam: no data for item")
+
+// ErrSteamRateLimited - Steam ответил 429 Too Many Requests
+var ErrSteamRateLimited = errors.New("steam: rate limited")
+
+// SteamMarketClient - интерфейс для работы с API Steam Community Market
+type SteamMarketClient interface {
+	// GetPriceOverview - текущие lowest/median цены и объём продаж за 24ч
+	// (GET /market/priceoverview/)
+	GetPriceOverview(ctx context.Context, appID int, marketHashName string, currency domain.Currency) (*domain.PriceOverview, error)
+
+	// GetPriceHistory - вся история продаж предмета
+	// (GET /market/pricehistory/, требует cookie steamLoginSecure)
+	GetPriceHistory(ctx context.Context, appID int, marketHashName string, currency domain.Currency) ([]domain.PriceHistoryPoint, error)
+
+	// SearchItems - поиск предметов по тексту, популярные первыми
+	// (GET /market/search/render/?norender=1); цены в ответе не используются
+	SearchItems(ctx context.Context, appID int, query string, count int) ([]domain.CatalogItem, error)
+
+	// GetItemNameID - внутренний item_nameid предмета со страницы лота
+	// (GET /market/listings/{appid}/{market_hash_name}); ErrSteamNoData если его нет на странице
+	GetItemNameID(ctx context.Context, appID int, marketHashName string) (int64, error)
+
+	// GetOrderBook - верхушка стакана предмета в валюте currency
+	// (GET /market/itemordershistogram); ключ предмета в результате не заполнен
+	GetOrderBook(ctx context.Context, itemNameID int64, currency domain.Currency) (*domain.OrderBook, error)
+}
@@ -0,0 +1,38 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// TrackedItemRepository - хранилище отслеживаемых предметов
+//
+// Все методы, принимающие userID, работают только с предметами этого пользователя:
+// чужой предмет для репозитория "не существует" (ErrNotFound), а не "запрещён".
+type TrackedItemRepository interface {
+	// ListByUser - все предметы пользователя, новые сверху
+	ListByUser(ctx context.Context, userID string) ([]domain.TrackedItem, error)
+
+	// ListFiltered - предметы пользователя по фильтру (теги уже нормализованы), новые сверху
+	ListFiltered(ctx context.Context, userID string, filter domain.TrackedItemFilter) ([]domain.TrackedItem, error)
+
+	// FindByID - предмет пользователя по ID
+	// Возвращает ErrNotFound если предмета нет или он чужой
+	FindByID(ctx context.Context, userID, itemID string) (*domain.TrackedItem, error)
+
+	// Create - сохраняет новый предмет
+	// Возвращает ErrAlreadyExists если пользователь уже следит за (app_id, market_hash_name)
+	Create(ctx context.Context, item *domain.TrackedItem) error
+
+	// Update - сохраняет изменяемые поля (name, notes, tags)
+	// Возвращает ErrNotFound если предмета нет или он чужой
+	Update(ctx context.Context, item *domain.TrackedItem) error
+
+	// Delete - удаляет предмет пользователя
+	// Возвращает ErrNotFound если предмета нет или он чужой
+	Delete(ctx context.Context, userID, itemID string) error
+
+	// ListDistinctKeys - уникальные предметы, за которыми следит хотя бы один пользователь
+	ListDistinctKeys(ctx context.Context) ([]domain.ItemKey, error)
+}
@@ -0,0 +1,38 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/market/domain"
+)
+
+// WatchlistRepository - хранилище списков отслеживаемых предметов
+// Как и в TrackedItemRepository, чужой список для методов с userID "не существует" (ErrNotFound)
+type WatchlistRepository interface {
+	// ListByUser - списки пользователя по имени, с числом предметов
+	ListByUser(ctx context.Context, userID string) ([]domain.Watchlist, error)
+
+	// FindByID - список пользователя по ID
+	FindByID(ctx context.Context, userID, watchlistID string) (*domain.Watchlist, error)
+
+	// FindByShareToken - опубликованный список по токену ссылки (ErrNotFound, если ссылка закрыта)
+	FindByShareToken(ctx context.Context, token string) (*domain.Watchlist, error)
+
+	// Create - сохраняет новый список
+	// Возвращает ErrAlreadyExists, если у пользователя уже есть список с таким именем
+	Create(ctx context.Context, watchlist *domain.Watchlist) error
+
+	// Update - сохраняет name, description, share_token
+	// Возвращает ErrNotFound / ErrAlreadyExists (имя занято другим списком)
+	Update(ctx context.Context, watchlist *domain.Watchlist) error
+
+	// Delete - удаляет список (предметы остаются отслеживаемыми)
+	Delete(ctx context.Context, userID, watchlistID string) error
+
+	// AddItem - добавляет предмет в список; повторное добавление - не ошибка
+	// Принадлежность списка и предмета одному пользователю проверяет вызывающий
+	AddItem(ctx context.Context, watchlistID, trackedItemID string) error
+
+	// RemoveItem - убирает предмет из списка (ErrNotFound, если его там нет)
+	RemoveItem(ctx context.Context, watchlistID, trackedItemID string) error
+}
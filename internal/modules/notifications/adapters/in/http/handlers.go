@@ -0,0 +1,196 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/in_ports"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+	mw "steam-observer/internal/shared/http/middleware"
+)
+
+type NotificationHandler struct {
+	service in_ports.NotificationService
+}
+
+func NewNotificationHandler(service in_ports.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// verifyRequest - тело POST /notifications/channels/{id}/verify
+type verifyRequest struct {
+	Code string `json:"code"`
+}
+
+// ListChannels - GET /notifications/channels
+func (h *NotificationHandler) ListChannels(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	channels, err := h.service.ListChannels(r.Context(), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list channels")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, channels)
+}
+
+// RegisterChannel - POST /notifications/channels
+func (h *NotificationHandler) RegisterChannel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var input in_ports.RegisterChannelInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	channel, err := h.service.RegisterChannel(r.Context(), userID, input)
+	if err != nil {
+		writeServiceError(w, err, "failed to register channel")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, channel)
+}
+
+// VerifyChannel - POST /notifications/channels/{id}/verify
+func (h *NotificationHandler) VerifyChannel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req verifyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	channel, err := h.service.VerifyChannel(r.Context(), userID, r.PathValue("id"), req.Code)
+	if err != nil {
+		writeServiceError(w, err, "failed to verify channel")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, channel)
+}
+
+// ResendVerification - POST /notifications/channels/{id}/resend
+func (h *NotificationHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ResendVerification(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to resend verification code")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DeleteChannel - DELETE /notifications/channels/{id}
+func (h *NotificationHandler) DeleteChannel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteChannel(r.Context(), userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err, "failed to delete channel")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries - GET /notifications/deliveries?limit=50
+func (h *NotificationHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 0 // 0 → лимит по умолчанию в сервисе
+	if s := r.URL.Query().Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), userID, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list deliveries")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+// ========================================
+// Helpers
+// ========================================
+
+// maxBodyBytes - ограничение размера тела запроса
+const maxBodyBytes = 64 << 10 // 64 KiB
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+// writeServiceError - ErrValidation → 400, ErrRateLimited → 429, ErrNotFound → 404, ErrAlreadyExists → 409, прочее → 500
+func writeServiceError(w http.ResponseWriter, err error, fallbackMsg string) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrRateLimited):
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, out_ports.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, out_ports.ErrAlreadyExists):
+		writeError(w, http.StatusConflict, "already exists")
+	default:
+		writeError(w, http.StatusInternalServerError, fallbackMsg)
+	}
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, ok := mw.UserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing user in context")
+		return "", false
+	}
+	return userID, true
+}
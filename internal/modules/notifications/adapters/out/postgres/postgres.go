@@ -0,0 +1,408 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+)
+
+// pgUniqueViolation - код ошибки PostgreSQL для нарушения UNIQUE constraint
+const pgUniqueViolation = "23505"
+
+// ========================================
+// Channels
+// ========================================
+
+// channelRepository - PostgreSQL реализация ChannelRepository
+type channelRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewChannelRepository - создаёт репозиторий каналов доставки
+func NewChannelRepository(pool *pgxpool.Pool) out_ports.ChannelRepository {
+	return &channelRepository{pool: pool}
+}
+
+const channelColumns = `id, user_id, type, target, verified, verification_code,
+               verification_expires_at, verification_attempts, verification_sent_at,
+               verification_resends, verified_at, created_at`
+
+// ListByUser - все каналы пользователя
+func (r *channelRepository) ListByUser(ctx context.Context, userID string) ([]domain.Channel, error) {
+	query := `
+        SELECT ` + channelColumns + `
+        FROM public.notification_channels
+        WHERE user_id = $1
+        ORDER BY created_at ASC
+    `
+	return r.queryChannels(ctx, query, userID)
+}
+
+// ListVerifiedByUser - подтверждённые каналы пользователя
+func (r *channelRepository) ListVerifiedByUser(ctx context.Context, userID string) ([]domain.Channel, error) {
+	query := `
+        SELECT ` + channelColumns + `
+        FROM public.notification_channels
+        WHERE user_id = $1 AND verified
+        ORDER BY created_at ASC
+    `
+	return r.queryChannels(ctx, query, userID)
+}
+
+// FindByID - канал по ID (userID = "" - без проверки владельца)
+func (r *channelRepository) FindByID(ctx context.Context, userID, channelID string) (*domain.Channel, error) {
+	query := `
+        SELECT ` + channelColumns + `
+        FROM public.notification_channels
+        WHERE id = $1 AND ($2 = '' OR user_id = $2)
+    `
+
+	channel, err := scanChannel(r.pool.QueryRow(ctx, query, channelID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, out_ports.ErrNotFound
+		}
+		return nil, fmt.Errorf("query channel by id: %w", err)
+	}
+
+	return channel, nil
+}
+
+// Create - сохраняет канал
+func (r *channelRepository) Create(ctx context.Context, channel *domain.Channel) error {
+	if err := channel.Validate(); err != nil {
+		return fmt.Errorf("invalid channel: %w", err)
+	}
+
+	query := `
+        INSERT INTO public.notification_channels (` + channelColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		channel.ID,
+		channel.UserID,
+		string(channel.Type),
+		channel.Target,
+		channel.Verified,
+		channel.VerificationCode,
+		channel.VerificationExpiresAt.UTC(),
+		channel.VerificationAttempts,
+		channel.VerificationSentAt.UTC(),
+		channel.VerificationResends,
+		channel.VerifiedAt,
+		channel.CreatedAt.UTC(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return out_ports.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert channel: %w", err)
+	}
+
+	return nil
+}
+
+// Update - сохраняет состояние подтверждения
+func (r *channelRepository) Update(ctx context.Context, channel *domain.Channel) error {
+	query := `
+        UPDATE public.notification_channels
+        SET verified = $3, verification_code = $4, verification_expires_at = $5, verification_attempts = $6,
+            verification_sent_at = $7, verification_resends = $8, verified_at = $9
+        WHERE id = $1 AND user_id = $2
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query,
+		channel.ID,
+		channel.UserID,
+		channel.Verified,
+		channel.VerificationCode,
+		channel.VerificationExpiresAt.UTC(),
+		channel.VerificationAttempts,
+		channel.VerificationSentAt.UTC(),
+		channel.VerificationResends,
+		channel.VerifiedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update channel: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateVerification - Update с оптимистичной блокировкой по счётчику попыток:
+// параллельные попытки не могут затереть инкременты друг друга и обойти лимит
+func (r *channelRepository) UpdateVerification(ctx context.Context, channel *domain.Channel, prevAttempts int) error {
+	query := `
+        UPDATE public.notification_channels
+        SET verified = $3, verification_code = $4, verification_expires_at = $5, verification_attempts = $6,
+            verified_at = $7
+        WHERE id = $1 AND user_id = $2 AND verification_attempts = $8
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query,
+		channel.ID,
+		channel.UserID,
+		channel.Verified,
+		channel.VerificationCode,
+		channel.VerificationExpiresAt.UTC(),
+		channel.VerificationAttempts,
+		channel.VerifiedAt,
+		prevAttempts,
+	)
+	if err != nil {
+		return fmt.Errorf("update channel verification: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		// Канал удалён или счётчик уже изменила параллельная попытка
+		return out_ports.ErrConflict
+	}
+
+	return nil
+}
+
+// UpdateResend - Update с оптимистичной блокировкой по времени последней отправки:
+// параллельные запросы не могут обойти паузу между отправками
+func (r *channelRepository) UpdateResend(ctx context.Context, channel *domain.Channel, prevSentAt time.Time) error {
+	query := `
+        UPDATE public.notification_channels
+        SET verification_code = $3, verification_expires_at = $4, verification_attempts = $5,
+            verification_sent_at = $6, verification_resends = $7
+        WHERE id = $1 AND user_id = $2 AND NOT verified AND verification_sent_at = $8
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query,
+		channel.ID,
+		channel.UserID,
+		channel.VerificationCode,
+		channel.VerificationExpiresAt.UTC(),
+		channel.VerificationAttempts,
+		channel.VerificationSentAt.UTC(),
+		channel.VerificationResends,
+		prevSentAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("update channel resend: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		// Канал удалён, подтверждён или код уже отправил параллельный запрос
+		return out_ports.ErrConflict
+	}
+
+	return nil
+}
+
+// Delete - удаляет канал пользователя (доставки удалятся каскадно)
+func (r *channelRepository) Delete(ctx context.Context, userID, channelID string) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`DELETE FROM public.notification_channels WHERE id = $1 AND user_id = $2`,
+		channelID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete channel: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// queryChannels - общий Query + Scan для списков каналов
+func (r *channelRepository) queryChannels(ctx context.Context, query string, args ...any) ([]domain.Channel, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query channels: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []domain.Channel{}
+	for rows.Next() {
+		channel, err := scanChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan channel: %w", err)
+		}
+		channels = append(channels, *channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// scanChannel - общий Scan для pgx.Row и pgx.Rows (порядок = channelColumns)
+func scanChannel(row pgx.Row) (*domain.Channel, error) {
+	var c domain.Channel
+	var channelType string
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&channelType,
+		&c.Target,
+		&c.Verified,
+		&c.VerificationCode,
+		&c.VerificationExpiresAt,
+		&c.VerificationAttempts,
+		&c.VerificationSentAt,
+		&c.VerificationResends,
+		&c.VerifiedAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Type = domain.ChannelType(channelType)
+	return &c, nil
+}
+
+// ========================================
+// Deliveries
+// ========================================
+
+// deliveryRepository - PostgreSQL реализация DeliveryRepository
+type deliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeliveryRepository - создаёт журнал доставок
+func NewDeliveryRepository(pool *pgxpool.Pool) out_ports.DeliveryRepository {
+	return &deliveryRepository{pool: pool}
+}
+
+const deliveryColumns = `id, user_id, channel_id, subject, body, status, attempts, last_error,
+               created_at, updated_at, delivered_at`
+
+// Create - сохраняет новую доставку
+func (r *deliveryRepository) Create(ctx context.Context, d *domain.Delivery) error {
+	query := `
+        INSERT INTO public.notification_deliveries (` + deliveryColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		d.ID,
+		d.UserID,
+		d.ChannelID,
+		d.Subject,
+		d.Body,
+		string(d.Status),
+		d.Attempts,
+		d.LastError,
+		d.CreatedAt.UTC(),
+		d.UpdatedAt.UTC(),
+		d.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert delivery: %w", err)
+	}
+
+	return nil
+}
+
+// Update - сохраняет статус, попытки и ошибку
+func (r *deliveryRepository) Update(ctx context.Context, d *domain.Delivery) error {
+	query := `
+        UPDATE public.notification_deliveries
+        SET status = $2, attempts = $3, last_error = $4, updated_at = $5, delivered_at = $6
+        WHERE id = $1
+    `
+
+	commandTag, err := r.pool.Exec(ctx, query,
+		d.ID,
+		string(d.Status),
+		d.Attempts,
+		d.LastError,
+		d.UpdatedAt.UTC(),
+		d.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update delivery: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return out_ports.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListByUser - доставки пользователя, новые сверху
+func (r *deliveryRepository) ListByUser(ctx context.Context, userID string, limit int) ([]domain.Delivery, error) {
+	query := `
+        SELECT ` + deliveryColumns + `
+        FROM public.notification_deliveries
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+	return r.queryDeliveries(ctx, query, userID, limit)
+}
+
+// ListPending - незавершённые доставки, старые первыми
+func (r *deliveryRepository) ListPending(ctx context.Context, limit int) ([]domain.Delivery, error) {
+	query := `
+        SELECT ` + deliveryColumns + `
+        FROM public.notification_deliveries
+        WHERE status = 'pending'
+        ORDER BY created_at ASC
+        LIMIT $1
+    `
+	return r.queryDeliveries(ctx, query, limit)
+}
+
+// queryDeliveries - общий Query + Scan для списков доставок
+func (r *deliveryRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]domain.Delivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.Delivery{}
+	for rows.Next() {
+		var d domain.Delivery
+		var status string
+		err := rows.Scan(
+			&d.ID,
+			&d.UserID,
+			&d.ChannelID,
+			&d.Subject,
+			&d.Body,
+			&status,
+			&d.Attempts,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+			&d.DeliveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan delivery: %w", err)
+		}
+		d.Status = domain.DeliveryStatus(status)
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,113 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+	"steam-observer/internal/shared/config"
+)
+
+type notifier struct {
+	cfg     config.SMTPConfig
+	timeout time.Duration
+}
+
+// NewNotifier - доставка уведомлений по email через SMTP
+// cfg.Host/Port позволяют указать локальный relay (mailhog и т.п.)
+func NewNotifier(cfg config.SMTPConfig, timeout time.Duration) out_ports.Notifier {
+	return &notifier{cfg: cfg, timeout: timeout}
+}
+
+func (n *notifier) Type() domain.ChannelType {
+	return domain.ChannelEmail
+}
+
+// Send - отправляет письмо на target
+//
+// smtp.SendMail не принимает context, поэтому соединение открываем сами:
+// так работают и таймаут, и отмена при остановке сервера.
+func (n *notifier) Send(ctx context.Context, target string, msg domain.Message) error {
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+	defer conn.Close()
+
+	// Deadline на весь SMTP диалог
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	// STARTTLS если сервер поддерживает (локальные заглушки обычно нет)
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if n.cfg.Username != "" {
+		auth := smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	if err := client.Rcpt(target); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(buildMessage(n.cfg.From, target, msg)); err != nil {
+		return fmt.Errorf("smtp write body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp close body: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage - RFC 5322 письмо в plain text UTF-8
+func buildMessage(from, to string, msg domain.Message) []byte {
+	// Переводы строк в заголовке позволили бы внедрить свои заголовки
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(msg.Subject)
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
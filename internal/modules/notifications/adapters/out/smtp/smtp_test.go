@@ -0,0 +1,134 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/shared/config"
+)
+
+// smtpSession - что заглушка получила за один SMTP диалог
+type smtpSession struct {
+	from, to string
+	data     string
+}
+
+// startSMTPServer - минимальный SMTP сервер на 127.0.0.1 без STARTTLS и AUTH
+// rcptReply - ответ на RCPT TO (например, "550 no such user")
+func startSMTPServer(t *testing.T, rcptReply string) (config.SMTPConfig, <-chan smtpSession) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var s smtpSession
+		reply("220 localhost ESMTP test")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch upper := strings.ToUpper(cmd); {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				s.to = strings.Trim(cmd[len("RCPT TO:"):], "<> ")
+				reply(rcptReply)
+			case upper == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case upper == "QUIT":
+				reply("221 Bye")
+				sessions <- s
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	return config.SMTPConfig{Host: host, Port: portNum, From: "observer@example.com"}, sessions
+}
+
+func TestSend(t *testing.T) {
+	cfg, sessions := startSMTPServer(t, "250 OK")
+
+	msg := domain.Message{Subject: "Цена упала", Body: "AK-47 | Redline\nниже $10"}
+	if err := NewNotifier(cfg, 5*time.Second).Send(context.Background(), "user@example.com", msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	s := <-sessions
+	if s.from != "observer@example.com" || s.to != "user@example.com" {
+		t.Errorf("envelope from=%q to=%q", s.from, s.to)
+	}
+	for _, want := range []string{
+		"To: user@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\nAK-47 | Redline\r\nниже $10\r\n",
+	} {
+		if !strings.Contains(s.data, want) {
+			t.Errorf("message does not contain %q:\n%s", want, s.data)
+		}
+	}
+}
+
+func TestSendRejectedRecipient(t *testing.T) {
+	cfg, _ := startSMTPServer(t, "550 no such user")
+
+	err := NewNotifier(cfg, 5*time.Second).Send(context.Background(), "nobody@example.com", domain.Message{Body: "x"})
+	if err == nil || !strings.Contains(err.Error(), "rcpt to") {
+		t.Errorf("error = %v, want a rcpt to error", err)
+	}
+}
+
+func TestBuildMessageStripsHeaderInjection(t *testing.T) {
+	msg := buildMessage("from@example.com", "to@example.com", domain.Message{
+		Subject: "Alert\r\nBcc: victim@example.com",
+		Body:    "body",
+	})
+
+	headers, _, _ := strings.Cut(string(msg), "\r\n\r\n")
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", headers)
+	}
+}
@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+	"steam-observer/internal/shared/config"
+)
+
+type notifier struct {
+	cfg        config.TelegramConfig
+	httpClient *http.Client
+}
+
+// NewNotifier - доставка уведомлений через Telegram Bot API (sendMessage)
+// cfg.BaseURL позволяет направить запросы на локальную заглушку вместо api.telegram.org
+func NewNotifier(cfg config.TelegramConfig, timeout time.Duration) out_ports.Notifier {
+	return &notifier{
+		cfg: cfg,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// sendMessageRequest - тело POST /bot<token>/sendMessage
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// apiResponse - общий формат ответа Bot API
+// При ошибке ok=false, а description объясняет причину ("chat not found")
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+func (n *notifier) Type() domain.ChannelType {
+	return domain.ChannelTelegram
+}
+
+// Send - отправляет сообщение в чат target (chat_id)
+func (n *notifier) Send(ctx context.Context, target string, msg domain.Message) error {
+	if n.cfg.BotToken == "" {
+		return errors.New("telegram bot token is not configured")
+	}
+
+	text := msg.Body
+	if msg.Subject != "" {
+		text = msg.Subject + "\n\n" + msg.Body
+	}
+
+	body, err := json.Marshal(sendMessageRequest{ChatID: target, Text: text})
+	if err != nil {
+		return fmt.Errorf("encode telegram request: %w", err)
+	}
+
+	endpoint := n.cfg.BaseURL + "/bot" + n.cfg.BotToken + "/sendMessage"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		// *url.Error содержит полный URL вместе с токеном бота - не пишем его в лог/БД
+		return errors.New("telegram send message: request failed")
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("decode telegram response (status: %d): %w", resp.StatusCode, err)
+	}
+
+	if !apiResp.OK {
+		return fmt.Errorf("telegram api error: %s (status: %d)", apiResp.Description, resp.StatusCode)
+	}
+
+	return nil
+}
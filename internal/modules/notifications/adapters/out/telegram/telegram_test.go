@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/shared/config"
+)
+
+const testToken = "123456:secret-token"
+
+var testMessage = domain.Message{Subject: "Price alert", Body: "AK-47 | Redline dropped below $10"}
+
+func TestSend(t *testing.T) {
+	var got sendMessageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot"+testToken+"/sendMessage" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer server.Close()
+
+	n := NewNotifier(config.TelegramConfig{BaseURL: server.URL, BotToken: testToken}, 5*time.Second)
+	if err := n.Send(context.Background(), "-100200300", testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.ChatID != "-100200300" || got.Text != testMessage.Subject+"\n\n"+testMessage.Body {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestSendAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	n := NewNotifier(config.TelegramConfig{BaseURL: server.URL, BotToken: testToken}, 5*time.Second)
+	err := n.Send(context.Background(), "42", testMessage)
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("error = %v, want the API description", err)
+	}
+}
+
+func TestSendWithoutToken(t *testing.T) {
+	n := NewNotifier(config.TelegramConfig{BaseURL: "http://127.0.0.1:1"}, time.Second)
+	if err := n.Send(context.Background(), "42", testMessage); err == nil {
+		t.Error("Send without a bot token: want an error")
+	}
+}
+
+func TestSendDoesNotLeakToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close() // Соединение будет отклонено
+
+	n := NewNotifier(config.TelegramConfig{BaseURL: server.URL, BotToken: testToken}, time.Second)
+	err := n.Send(context.Background(), "42", testMessage)
+	if err == nil {
+		t.Fatal("Send to a closed server: want an error")
+	}
+	if strings.Contains(err.Error(), testToken) {
+		t.Errorf("error %q contains the bot token", err)
+	}
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+)
+
+type notifier struct {
+	httpClient *http.Client
+}
+
+// errRedirect - редиректы не выполняем: они позволили бы увести запрос на внутренний адрес
+var errRedirect = errors.New("webhook redirects are not allowed")
+
+// NewNotifier - доставка уведомлений HTTP POST запросом на URL пользователя
+//
+// URL задаёт пользователь, поэтому адрес проверяется уже после DNS резолва, в момент
+// соединения: loopback, частные, link-local и unspecified адреса запрещены (защита от SSRF,
+// в том числе через DNS rebinding). allowPrivate снимает запрет для локальной заглушки.
+func NewNotifier(timeout time.Duration, allowPrivate bool) out_ports.Notifier {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = rejectPrivate
+	}
+
+	return &notifier{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				// Без прокси из окружения: иначе проверялся бы адрес прокси, а не цели
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return errRedirect
+			},
+		},
+	}
+}
+
+// rejectPrivate - net.Dialer.Control: address уже содержит IP, к которому идёт соединение
+func rejectPrivate(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parse webhook address %q: %w", address, err)
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("webhook target address %s is not allowed", ip)
+	}
+
+	return nil
+}
+
+// payload - тело webhook запроса
+type payload struct {
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func (n *notifier) Type() domain.ChannelType {
+	return domain.ChannelWebhook
+}
+
+// Send - POST target с JSON; любой 2xx считается успешной доставкой
+func (n *notifier) Send(ctx context.Context, target string, msg domain.Message) error {
+	body, err := json.Marshal(payload{
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "steam-observer-webhook/1.0")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook error: %s (status: %d)", respBody, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+)
+
+var testMessage = domain.Message{Subject: "Price alert", Body: "AK-47 | Redline dropped below $10"}
+
+func TestSend(t *testing.T) {
+	var got payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Заглушка слушает 127.0.0.1 - нужен allowPrivate
+	n := NewNotifier(5*time.Second, true)
+	if err := n.Send(context.Background(), server.URL+"/hook", testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Subject != testMessage.Subject || got.Body != testMessage.Body || got.SentAt.IsZero() {
+		t.Errorf("payload = %+v, want subject, body and sent_at of %+v", got, testMessage)
+	}
+}
+
+func TestSendErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewNotifier(5*time.Second, true).Send(context.Background(), server.URL, testMessage)
+	if err == nil || !strings.Contains(err.Error(), "status: 500") {
+		t.Errorf("error = %v, want a webhook error with status 500", err)
+	}
+}
+
+func TestSendRefusesRedirects(t *testing.T) {
+	var redirected atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/internal", func(http.ResponseWriter, *http.Request) {
+		redirected.Store(true)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	if err := NewNotifier(5*time.Second, true).Send(context.Background(), server.URL+"/hook", testMessage); err == nil {
+		t.Error("Send: want an error for a redirect")
+	}
+	if redirected.Load() {
+		t.Error("redirect was followed")
+	}
+}
+
+func TestSendRejectsPrivateTargets(t *testing.T) {
+	var called atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called.Store(true)
+	}))
+	defer server.Close()
+
+	n := NewNotifier(5*time.Second, false)
+	for _, target := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+		err := n.Send(context.Background(), target, testMessage)
+		if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Errorf("Send(%s): error = %v, want a blocked address", target, err)
+		}
+	}
+	if called.Load() {
+		t.Error("request reached a loopback server")
+	}
+}
+
+func TestRejectPrivate(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1:80",
+		"10.1.2.3:443",
+		"172.16.0.1:80",
+		"192.168.1.10:8080",
+		"169.254.169.254:80",
+		"0.0.0.0:80",
+		"224.0.0.1:80",
+		"[::1]:80",
+		"[::]:80",
+		"[fe80::1]:80",
+		"[fd00::1]:80",
+		"[::ffff:127.0.0.1]:80",
+		"[::ffff:169.254.169.254]:80",
+	}
+	for _, addr := range blocked {
+		if err := rejectPrivate("tcp", addr, nil); err == nil {
+			t.Errorf("rejectPrivate(%s): want an error", addr)
+		}
+	}
+
+	allowed := []string{"8.8.8.8:443", "93.184.216.34:80", "[2606:4700:4700::1111]:443"}
+	for _, addr := range allowed {
+		if err := rejectPrivate("tcp", addr, nil); err != nil {
+			t.Errorf("rejectPrivate(%s): %v", addr, err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+	"steam-observer/internal/modules/notifications/ports/in_ports"
+	"steam-observer/internal/modules/notifications/ports/out_ports"
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// Лимиты журнала доставок
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+	resumePendingLimit     = 1000
+)
+
+// NotificationService - каналы пользователя + фоновая доставка с повторами
+//
+// Доставка асинхронная: NotifyUser только записывает pending доставки и запускает
+// для каждой горутину, которая делает попытки с экспоненциальной паузой.
+// Stop дожидается этих горутин; недоставленное остаётся pending в БД
+// и подхватывается при следующем Start.
+type NotificationService interface {
+	in_ports.NotificationService
+	Start()
+	Stop()
+}
+
+type notificationServiceImpl struct {
+	cfg        config.NotificationsConfig
+	channels   out_ports.ChannelRepository
+	deliveries out_ports.DeliveryRepository
+	notifiers  map[domain.ChannelType]out_ports.Notifier
+	logger     logger.Logger
+
+	// ctx живёт всё время работы сервиса: доставки не должны отменяться
+	// вместе с HTTP запросом или раундом poller'а, который их породил
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewNotificationService(
+	cfg config.NotificationsConfig,
+	channels out_ports.ChannelRepository,
+	deliveries out_ports.DeliveryRepository,
+	notifiers []out_ports.Notifier,
+	log logger.Logger,
+) NotificationService {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	byType := make(map[domain.ChannelType]out_ports.Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byType[n.Type()] = n
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &notificationServiceImpl{
+		cfg:        cfg,
+		channels:   channels,
+		deliveries: deliveries,
+		notifiers:  byType,
+		logger:     log,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start - возобновляет доставки, оставшиеся pending после прошлого запуска
+func (s *notificationServiceImpl) Start() {
+	pending, err := s.deliveries.ListPending(s.ctx, resumePendingLimit)
+	if err != nil {
+		s.logger.Errorf("list pending deliveries: %v", err)
+		return
+	}
+
+	for i := range pending {
+		d := &pending[i]
+		channel, err := s.channels.FindByID(s.ctx, "", d.ChannelID)
+		if err != nil {
+			s.logger.Warnf("resume delivery %s: find channel: %v", d.ID, err)
+			continue
+		}
+		s.dispatch(d, channel)
+	}
+
+	if len(pending) > 0 {
+		s.logger.Infof("resumed %d pending deliveries", len(pending))
+	}
+}
+
+// Stop - прерывает ожидание повторов и ждёт завершения текущих попыток
+func (s *notificationServiceImpl) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.logger.Info("notification dispatcher stopped")
+}
+
+func (s *notificationServiceImpl) ListChannels(ctx context.Context, userID string) ([]domain.Channel, error) {
+	channels, err := s.channels.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list channels: %w", err)
+	}
+	return channels, nil
+}
+
+func (s *notificationServiceImpl) RegisterChannel(ctx context.Context, userID string, input in_ports.RegisterChannelInput) (*domain.Channel, error) {
+	channel, err := domain.NewChannel(userID, input.Type, input.Target)
+	if err != nil {
+		return nil, err
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.channels.Create(ctx, channel); err != nil {
+		return nil, fmt.Errorf("create channel: %w", err)
+	}
+
+	s.logger.Infof("channel registered, id=%s, user_id=%s, type=%s", channel.ID, userID, channel.Type)
+
+	// Код подтверждения уходит в сам канал - это и есть проверка владения
+	if err := s.enqueue(ctx, channel, channel.VerificationMessage()); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (s *notificationServiceImpl) VerifyChannel(ctx context.Context, userID, channelID, code string) (*domain.Channel, error) {
+	channel, err := s.channels.FindByID(ctx, userID, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("find channel: %w", err)
+	}
+
+	// Счётчик неудачных попыток сохраняем и при неверном коде
+	prevAttempts := channel.VerificationAttempts
+	verifyErr := channel.Verify(code, time.Now().UTC())
+
+	if err := s.channels.UpdateVerification(ctx, channel, prevAttempts); err != nil {
+		if errors.Is(err, out_ports.ErrConflict) {
+			// Параллельная попытка уже изменила канал - результат этой не засчитываем и не раскрываем
+			return nil, domain.ErrInvalidVerificationCode
+		}
+		return nil, fmt.Errorf("update channel: %w", err)
+	}
+	if verifyErr != nil {
+		if errors.Is(verifyErr, domain.ErrVerificationAttemptsExceeded) {
+			s.logger.Warnf("channel verification locked after failed attempts, id=%s, user_id=%s", channel.ID, userID)
+		}
+		return nil, verifyErr
+	}
+
+	s.logger.Infof("channel verified, id=%s, user_id=%s", channel.ID, userID)
+
+	return channel, nil
+}
+
+func (s *notificationServiceImpl) ResendVerification(ctx context.Context, userID, channelID string) error {
+	channel, err := s.channels.FindByID(ctx, userID, channelID)
+	if err != nil {
+		return fmt.Errorf("find channel: %w", err)
+	}
+
+	// Пауза и лимит отправок - защита от рассылки на чужой адрес и от сброса лимита попыток
+	prevSentAt := channel.VerificationSentAt
+	if err := channel.RegenerateCode(time.Now().UTC()); err != nil {
+		return err
+	}
+
+	if err := s.channels.UpdateResend(ctx, channel, prevSentAt); err != nil {
+		if errors.Is(err, out_ports.ErrConflict) {
+			// Параллельный запрос уже отправил новый код
+			return domain.ErrVerificationResendTooSoon
+		}
+		return fmt.Errorf("update channel: %w", err)
+	}
+
+	return s.enqueue(ctx, channel, channel.VerificationMessage())
+}
+
+func (s *notificationServiceImpl) DeleteChannel(ctx context.Context, userID, channelID string) error {
+	if err := s.channels.Delete(ctx, userID, channelID); err != nil {
+		return fmt.Errorf("delete channel: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationServiceImpl) ListDeliveries(ctx context.Context, userID string, limit int) ([]domain.Delivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	limit = min(limit, maxDeliveriesLimit)
+
+	deliveries, err := s.deliveries.ListByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *notificationServiceImpl) NotifyUser(ctx context.Context, userID string, msg domain.Message) error {
+	channels, err := s.channels.ListVerifiedByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list verified channels: %w", err)
+	}
+
+	// Ошибка одного канала не мешает остальным
+	var firstErr error
+	for i := range channels {
+		if err := s.enqueue(ctx, &channels[i], msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// enqueue - записывает pending доставку и запускает её отправку в фоне
+func (s *notificationServiceImpl) enqueue(ctx context.Context, channel *domain.Channel, msg domain.Message) error {
+	delivery := domain.NewDelivery(channel, msg)
+
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		return fmt.Errorf("create delivery: %w", err)
+	}
+
+	s.dispatch(delivery, channel)
+	return nil
+}
+
+// dispatch - запускает горутину доставки (учитывается в wg для Stop)
+func (s *notificationServiceImpl) dispatch(delivery *domain.Delivery, channel *domain.Channel) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.deliver(delivery, channel)
+	}()
+}
+
+// deliver - попытки доставки с экспоненциальной паузой до sent/failed или остановки сервиса
+func (s *notificationServiceImpl) deliver(delivery *domain.Delivery, channel *domain.Channel) {
+	notifier, ok := s.notifiers[channel.Type]
+	if !ok {
+		// Повтор не поможет - сразу failed
+		delivery.RecordFailure(fmt.Errorf("no notifier for channel type %q", channel.Type), 1, time.Now().UTC())
+		s.saveDelivery(delivery)
+		return
+	}
+
+	for delivery.Status == domain.DeliveryPending {
+		if delivery.Attempts > 0 {
+			wait := domain.RetryBackoff(delivery.Attempts, s.cfg.RetryBaseDelay, s.cfg.RetryMaxDelay)
+			select {
+			case <-time.After(wait):
+			case <-s.ctx.Done():
+				// Остаётся pending - продолжим после рестарта
+				return
+			}
+		}
+
+		err := notifier.Send(s.ctx, channel.Target, delivery.Message())
+		now := time.Now().UTC()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return // остановка сервиса, а не сбой канала - попытку не засчитываем
+			}
+			delivery.RecordFailure(err, s.cfg.MaxAttempts, now)
+			s.logger.Warnf("delivery %s attempt %d via %s failed: %v", delivery.ID, delivery.Attempts, channel.Type, err)
+		} else {
+			delivery.RecordSuccess(now)
+		}
+
+		s.saveDelivery(delivery)
+	}
+
+	if delivery.Status == domain.DeliveryFailed {
+		s.logger.Errorf("delivery %s via %s failed after %d attempts: %s",
+			delivery.ID, channel.Type, delivery.Attempts, delivery.LastError)
+	}
+}
+
+// saveDelivery - сохраняет статус доставки
+// Используем независимый контекст: статус нужно записать даже во время остановки
+func (s *notificationServiceImpl) saveDelivery(delivery *domain.Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		s.logger.Errorf("save delivery %s status: %v", delivery.ID, err)
+	}
+}
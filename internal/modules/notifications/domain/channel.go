@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrValidation - базовая ошибка нарушения инвариантов домена notifications
+var ErrValidation = errors.New("validation error")
+
+// ErrInvalidVerificationCode - неверный или просроченный код подтверждения
+var ErrInvalidVerificationCode = fmt.Errorf("%w: invalid or expired verification code", ErrValidation)
+
+// ErrVerificationAttemptsExceeded - код сброшен после MaxVerificationAttempts неудачных попыток
+var ErrVerificationAttemptsExceeded = fmt.Errorf("%w: too many failed attempts, request a new verification code", ErrValidation)
+
+// ErrRateLimited - действие повторяется слишком часто
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrVerificationResendTooSoon - повторная отправка кода раньше VerificationResendCooldown
+var ErrVerificationResendTooSoon = fmt.Errorf("%w: verification code was sent recently, try again later", ErrRateLimited)
+
+// ErrVerificationResendLimit - исчерпан лимит повторных отправок кода
+var ErrVerificationResendLimit = fmt.Errorf("%w: too many verification codes sent, try again in an hour", ErrRateLimited)
+
+// VerificationCodeTTL - сколько живёт код подтверждения канала
+const VerificationCodeTTL = time.Hour
+
+// MaxVerificationAttempts - неудачных попыток на один код; без лимита 6 цифр перебираются за час
+const MaxVerificationAttempts = 5
+
+// Ограничения повторной отправки кода: каждая отправка - сообщение на чужой
+// (пока не подтверждённый) адрес и новые MaxVerificationAttempts попыток
+const (
+	VerificationResendCooldown = time.Minute // Минимальная пауза между отправками
+	VerificationResendWindow   = time.Hour   // Счётчик отправок обнуляется после часа без отправок
+	MaxVerificationResends     = 5           // Повторных отправок в окне
+)
+
+// ChannelType - способ доставки уведомлений
+type ChannelType string
+
+const (
+	ChannelWebhook  ChannelType = "webhook"  // Target - URL, на который шлём POST с JSON
+	ChannelTelegram ChannelType = "telegram" // Target - chat_id для Bot API sendMessage
+	ChannelEmail    ChannelType = "email"    // Target - адрес электронной почты
+)
+
+// Channel - канал доставки пользователя
+//
+// Жизненный цикл:
+//  1. Пользователь регистрирует канал → генерируем код и отправляем его В ЭТОТ канал
+//  2. Пользователь присылает код обратно → канал Verified
+//  3. Уведомления уходят только в подтверждённые каналы
+//
+// Подтверждение доказывает, что пользователь контролирует адрес,
+// и защищает от рассылки алертов на чужой email/webhook.
+type Channel struct {
+	ID                    string      `json:"id"`
+	UserID                string      `json:"user_id"`
+	Type                  ChannelType `json:"type"`
+	Target                string      `json:"target"`
+	Verified              bool        `json:"verified"`
+	VerificationCode      string      `json:"-"` // Никогда не отдаём наружу
+	VerificationExpiresAt time.Time   `json:"-"`
+	VerificationAttempts  int         `json:"-"` // Неудачных попыток с текущим кодом
+	VerificationSentAt    time.Time   `json:"-"` // Последняя отправка кода
+	VerificationResends   int         `json:"-"` // Повторных отправок в текущем окне
+	VerifiedAt            *time.Time  `json:"verified_at"`
+	CreatedAt             time.Time   `json:"created_at"`
+}
+
+// NewChannel - фабричный метод нового (неподтверждённого) канала
+func NewChannel(userID string, channelType ChannelType, target string) (*Channel, error) {
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, fmt.Errorf("generate verification code: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	return &Channel{
+		ID:                    uuid.New().String(),
+		UserID:                userID,
+		Type:                  channelType,
+		Target:                strings.TrimSpace(target),
+		VerificationCode:      code,
+		VerificationExpiresAt: now.Add(VerificationCodeTTL),
+		VerificationSentAt:    now,
+		CreatedAt:             now,
+	}, nil
+}
+
+// Validate - проверка инвариантов канала (формат Target зависит от Type)
+func (c *Channel) Validate() error {
+	if c.UserID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrValidation)
+	}
+	if c.Target == "" {
+		return fmt.Errorf("%w: target is required", ErrValidation)
+	}
+
+	switch c.Type {
+	case ChannelWebhook:
+		u, err := url.Parse(c.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: webhook target must be an http(s) URL", ErrValidation)
+		}
+	case ChannelTelegram:
+		if strings.ContainsAny(c.Target, " /?") {
+			return fmt.Errorf("%w: telegram target must be a chat_id", ErrValidation)
+		}
+	case ChannelEmail:
+		addr, err := mail.ParseAddress(c.Target)
+		if err != nil || addr.Address != c.Target {
+			return fmt.Errorf("%w: email target must be a plain email address", ErrValidation)
+		}
+	default:
+		return fmt.Errorf("%w: unknown channel type %q", ErrValidation, c.Type)
+	}
+
+	return nil
+}
+
+// Verify - подтверждает канал кодом
+// Неудачная попытка меняет канал (счётчик попыток), поэтому его нужно сохранить и при ошибке.
+// После MaxVerificationAttempts неудач код сбрасывается - нужен новый через RegenerateCode
+func (c *Channel) Verify(code string, now time.Time) error {
+	if c.Verified {
+		return nil
+	}
+	if c.VerificationCode == "" {
+		if c.VerificationAttempts >= MaxVerificationAttempts {
+			return ErrVerificationAttemptsExceeded
+		}
+		return ErrInvalidVerificationCode
+	}
+
+	match := subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(c.VerificationCode)) == 1
+	if !match || now.After(c.VerificationExpiresAt) {
+		c.VerificationAttempts++
+		if c.VerificationAttempts >= MaxVerificationAttempts {
+			c.VerificationCode = ""
+			return ErrVerificationAttemptsExceeded
+		}
+		return ErrInvalidVerificationCode
+	}
+
+	c.Verified = true
+	c.VerifiedAt = &now
+	c.VerificationCode = ""
+	c.VerificationAttempts = 0
+	return nil
+}
+
+// RegenerateCode - выдаёт новый код подтверждения взамен старого
+// Не чаще раза в VerificationResendCooldown и не больше MaxVerificationResends
+// раз подряд, пока между отправками не пройдёт VerificationResendWindow
+func (c *Channel) RegenerateCode(now time.Time) error {
+	if c.Verified {
+		return fmt.Errorf("%w: channel is already verified", ErrValidation)
+	}
+
+	sinceSent := now.Sub(c.VerificationSentAt)
+	if sinceSent < VerificationResendCooldown {
+		return ErrVerificationResendTooSoon
+	}
+	if sinceSent >= VerificationResendWindow {
+		c.VerificationResends = 0
+	}
+	if c.VerificationResends >= MaxVerificationResends {
+		return ErrVerificationResendLimit
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return fmt.Errorf("generate verification code: %w", err)
+	}
+
+	c.VerificationCode = code
+	c.VerificationExpiresAt = now.Add(VerificationCodeTTL)
+	c.VerificationAttempts = 0
+	c.VerificationSentAt = now
+	c.VerificationResends++
+	return nil
+}
+
+// VerificationMessage - сообщение с кодом подтверждения
+func (c *Channel) VerificationMessage() Message {
+	return Message{
+		Subject: "Steam Observer: confirm notification channel",
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %s.",
+			c.VerificationCode, VerificationCodeTTL),
+	}
+}
+
+// Message - содержимое уведомления, не зависящее от канала
+type Message struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// generateVerificationCode - 6 цифр из crypto/rand
+func generateVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestChannel(t *testing.T) *Channel {
+	t.Helper()
+
+	c, err := NewChannel("user-1", ChannelWebhook, "https://example.com/hook")
+	if err != nil {
+		t.Fatalf("NewChannel: %v", err)
+	}
+	return c
+}
+
+// wrongCode - код, гарантированно не совпадающий с кодом канала
+func wrongCode(c *Channel) string {
+	if c.VerificationCode == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
+
+func TestChannelVerify(t *testing.T) {
+	c := newTestChannel(t)
+	now := c.CreatedAt.Add(time.Minute)
+
+	if err := c.Verify(wrongCode(c), now); !errors.Is(err, ErrInvalidVerificationCode) {
+		t.Fatalf("wrong code: error = %v, want ErrInvalidVerificationCode", err)
+	}
+	if c.VerificationAttempts != 1 {
+		t.Errorf("attempts = %d, want 1", c.VerificationAttempts)
+	}
+
+	if err := c.Verify(" "+c.VerificationCode+" ", now); err != nil {
+		t.Fatalf("correct code: %v", err)
+	}
+	if !c.Verified || c.VerifiedAt == nil || c.VerificationCode != "" || c.VerificationAttempts != 0 {
+		t.Errorf("channel after verify = %+v", c)
+	}
+}
+
+func TestChannelVerifyExpired(t *testing.T) {
+	c := newTestChannel(t)
+
+	err := c.Verify(c.VerificationCode, c.VerificationExpiresAt.Add(time.Second))
+	if !errors.Is(err, ErrInvalidVerificationCode) || c.Verified {
+		t.Errorf("expired code: error = %v, verified = %v", err, c.Verified)
+	}
+}
+
+func TestChannelVerifyAttemptsLimit(t *testing.T) {
+	c := newTestChannel(t)
+	now := c.CreatedAt.Add(time.Minute)
+	code := c.VerificationCode
+
+	for i := 1; i < MaxVerificationAttempts; i++ {
+		if err := c.Verify(wrongCode(c), now); !errors.Is(err, ErrInvalidVerificationCode) {
+			t.Fatalf("attempt %d: error = %v, want ErrInvalidVerificationCode", i, err)
+		}
+	}
+	if err := c.Verify(wrongCode(c), now); !errors.Is(err, ErrVerificationAttemptsExceeded) {
+		t.Fatalf("last attempt: error = %v, want ErrVerificationAttemptsExceeded", err)
+	}
+	if c.VerificationCode != "" {
+		t.Error("code was not cleared after the limit")
+	}
+
+	// Даже верный код больше не принимается
+	if err := c.Verify(code, now); !errors.Is(err, ErrVerificationAttemptsExceeded) || c.Verified {
+		t.Errorf("old code after lockout: error = %v, verified = %v", err, c.Verified)
+	}
+
+	if err := c.RegenerateCode(now); err != nil {
+		t.Fatalf("RegenerateCode: %v", err)
+	}
+	if c.VerificationAttempts != 0 {
+		t.Errorf("attempts after RegenerateCode = %d, want 0", c.VerificationAttempts)
+	}
+	if err := c.Verify(c.VerificationCode, now); err != nil || !c.Verified {
+		t.Errorf("new code: error = %v, verified = %v", err, c.Verified)
+	}
+}
+
+func TestChannelRegenerateCodeLimits(t *testing.T) {
+	c := newTestChannel(t)
+	now := c.CreatedAt
+
+	// Код только что отправлен при регистрации
+	if err := c.RegenerateCode(now.Add(30 * time.Second)); !errors.Is(err, ErrVerificationResendTooSoon) {
+		t.Fatalf("resend within cooldown: error = %v, want ErrVerificationResendTooSoon", err)
+	}
+
+	for i := range MaxVerificationResends {
+		now = now.Add(VerificationResendCooldown)
+		if err := c.RegenerateCode(now); err != nil {
+			t.Fatalf("resend %d: %v", i+1, err)
+		}
+	}
+	if c.VerificationResends != MaxVerificationResends || !c.VerificationSentAt.Equal(now) {
+		t.Errorf("resends = %d, sent at %v; want %d, %v", c.VerificationResends, c.VerificationSentAt, MaxVerificationResends, now)
+	}
+
+	code := c.VerificationCode
+	if err := c.RegenerateCode(now.Add(VerificationResendCooldown)); !errors.Is(err, ErrVerificationResendLimit) {
+		t.Fatalf("resend over the limit: error = %v, want ErrVerificationResendLimit", err)
+	}
+	if c.VerificationCode != code {
+		t.Error("refused resend replaced the code")
+	}
+	if errors.Is(ErrVerificationResendLimit, ErrValidation) || !errors.Is(ErrVerificationResendLimit, ErrRateLimited) {
+		t.Error("resend limit must be reported as ErrRateLimited")
+	}
+
+	// Час без отправок обнуляет счётчик
+	if err := c.RegenerateCode(now.Add(VerificationResendWindow)); err != nil || c.VerificationResends != 1 {
+		t.Errorf("resend after the window: error = %v, resends = %d", err, c.VerificationResends)
+	}
+}
+
+func TestChannelValidate(t *testing.T) {
+	tests := []struct {
+		channelType ChannelType
+		target      string
+		valid       bool
+	}{
+		{ChannelWebhook, "https://example.com/hook", true},
+		{ChannelWebhook, "ftp://example.com/hook", false},
+		{ChannelWebhook, "https://", false},
+		{ChannelTelegram, "-100200300", true},
+		{ChannelTelegram, "https://t.me/chat", false},
+		{ChannelEmail, "user@example.com", true},
+		{ChannelEmail, "User <user@example.com>", false},
+		{ChannelType("sms"), "+10000000000", false},
+	}
+
+	for _, tt := range tests {
+		c := &Channel{UserID: "user-1", Type: tt.channelType, Target: tt.target}
+		err := c.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%s %q: %v", tt.channelType, tt.target, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrValidation) {
+			t.Errorf("%s %q: error = %v, want ErrValidation", tt.channelType, tt.target, err)
+		}
+	}
+}
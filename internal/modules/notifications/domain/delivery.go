@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryStatus - состояние доставки уведомления
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending" // Ждёт (первой или повторной) попытки
+	DeliverySent    DeliveryStatus = "sent"    // Канал подтвердил приём
+	DeliveryFailed  DeliveryStatus = "failed"  // Попытки исчерпаны
+)
+
+// Delivery - попытка доставить сообщение в конкретный канал
+type Delivery struct {
+	ID          string         `json:"id"`
+	UserID      string         `json:"user_id"`
+	ChannelID   string         `json:"channel_id"`
+	Subject     string         `json:"subject"`
+	Body        string         `json:"body"`
+	Status      DeliveryStatus `json:"status"`
+	Attempts    int            `json:"attempts"`
+	LastError   string         `json:"last_error,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeliveredAt *time.Time     `json:"delivered_at"`
+}
+
+// NewDelivery - новая доставка в статусе pending
+func NewDelivery(channel *Channel, msg Message) *Delivery {
+	now := time.Now().UTC()
+
+	return &Delivery{
+		ID:        uuid.New().String(),
+		UserID:    channel.UserID,
+		ChannelID: channel.ID,
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		Status:    DeliveryPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Message - содержимое доставки
+func (d *Delivery) Message() Message {
+	return Message{Subject: d.Subject, Body: d.Body}
+}
+
+// RecordSuccess - канал принял сообщение
+func (d *Delivery) RecordSuccess(now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySent
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// RecordFailure - попытка не удалась; после maxAttempts доставка становится failed
+func (d *Delivery) RecordFailure(err error, maxAttempts int, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.UpdatedAt = now
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryFailed
+	}
+}
+
+// RetryBackoff - пауза перед попыткой attempt (1-based): base * 2^(attempt-1) ± 20%, не больше maxDelay
+//
+// Jitter нужен, чтобы после сбоя канала повторы разных доставок не приходили одной пачкой
+func RetryBackoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	// ±20%: rand.Float64() ∈ [0, 1) → множитель ∈ [0.8, 1.2)
+	return time.Duration(float64(delay) * (0.8 + 0.4*rand.Float64()))
+}
@@ -0,0 +1,34 @@
+package in_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/notifications/domain"
+)
+
+// RegisterChannelInput - данные нового канала
+type RegisterChannelInput struct {
+	Type   domain.ChannelType `json:"type"`
+	Target string             `json:"target"`
+}
+
+type NotificationService interface {
+	ListChannels(ctx context.Context, userID string) ([]domain.Channel, error)
+
+	// RegisterChannel - создаёт канал и отправляет в него код подтверждения
+	RegisterChannel(ctx context.Context, userID string, input RegisterChannelInput) (*domain.Channel, error)
+
+	// VerifyChannel - подтверждает канал кодом, пришедшим в этот канал
+	VerifyChannel(ctx context.Context, userID, channelID, code string) (*domain.Channel, error)
+
+	// ResendVerification - новый код подтверждения (старый истёк или потерялся)
+	ResendVerification(ctx context.Context, userID, channelID string) error
+
+	DeleteChannel(ctx context.Context, userID, channelID string) error
+
+	ListDeliveries(ctx context.Context, userID string, limit int) ([]domain.Delivery, error)
+
+	// NotifyUser - ставит сообщение в доставку во все подтверждённые каналы пользователя
+	// Не ждёт фактической доставки: отправка и повторы идут в фоне
+	NotifyUser(ctx context.Context, userID string, msg domain.Message) error
+}
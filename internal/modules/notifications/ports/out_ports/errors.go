@@ -0,0 +1,12 @@
+package out_ports
+
+import "errors"
+
+// ErrNotFound - запись не найдена (или принадлежит другому пользователю)
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists - канал с таким type+target у пользователя уже есть
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrConflict - запись изменена параллельным запросом
+var ErrConflict = errors.New("conflict")
@@ -0,0 +1,20 @@
+package out_ports
+
+import (
+	"context"
+
+	"steam-observer/internal/modules/notifications/domain"
+)
+
+// Notifier - адаптер доставки сообщений одного типа каналов
+//
+// Реализации: webhook (HTTP POST), telegram (Bot API sendMessage), email (SMTP).
+// Send должен вернуть ошибку, если канал не подтвердил приём, - по ней сервис решит,
+// повторять ли попытку.
+type Notifier interface {
+	// Type - тип каналов, которые обслуживает адаптер
+	Type() domain.ChannelType
+
+	// Send - доставляет сообщение на target (URL, chat_id, email - в зависимости от Type)
+	Send(ctx context.Context, target string, msg domain.Message) error
+}
@@ -0,0 +1,53 @@
+package out_ports
+
+import (
+	"context"
+	"time"
+
+	"steam-observer/internal/modules/notifications/domain"
+)
+
+// ChannelRepository - хранилище каналов доставки
+type ChannelRepository interface {
+	// ListByUser - все каналы пользователя
+	ListByUser(ctx context.Context, userID string) ([]domain.Channel, error)
+
+	// ListVerifiedByUser - подтверждённые каналы пользователя (получатели уведомлений)
+	ListVerifiedByUser(ctx context.Context, userID string) ([]domain.Channel, error)
+
+	// FindByID - канал пользователя по ID; userID = "" - без проверки владельца
+	// (нужно воркеру доставки, который работает вне запроса пользователя)
+	FindByID(ctx context.Context, userID, channelID string) (*domain.Channel, error)
+
+	// Create - сохраняет канал; ErrAlreadyExists если такой type+target уже есть
+	Create(ctx context.Context, channel *domain.Channel) error
+
+	// Update - сохраняет состояние подтверждения и код
+	Update(ctx context.Context, channel *domain.Channel) error
+
+	// UpdateVerification - как Update, но только если счётчик неудачных попыток в БД
+	// всё ещё равен prevAttempts; иначе ErrConflict (параллельная попытка подтверждения)
+	UpdateVerification(ctx context.Context, channel *domain.Channel, prevAttempts int) error
+
+	// UpdateResend - как Update, но только если время последней отправки кода в БД
+	// всё ещё равно prevSentAt; иначе ErrConflict (параллельная повторная отправка)
+	UpdateResend(ctx context.Context, channel *domain.Channel, prevSentAt time.Time) error
+
+	// Delete - удаляет канал пользователя
+	Delete(ctx context.Context, userID, channelID string) error
+}
+
+// DeliveryRepository - журнал доставок
+type DeliveryRepository interface {
+	// Create - сохраняет новую доставку
+	Create(ctx context.Context, delivery *domain.Delivery) error
+
+	// Update - сохраняет статус, попытки и ошибку
+	Update(ctx context.Context, delivery *domain.Delivery) error
+
+	// ListByUser - доставки пользователя, новые сверху
+	ListByUser(ctx context.Context, userID string, limit int) ([]domain.Delivery, error)
+
+	// ListPending - незавершённые доставки (для возобновления после рестарта)
+	ListPending(ctx context.Context, limit int) ([]domain.Delivery, error)
+}
@@ -21,6 +21,137 @@ type JWTConfig struct {
 	TTL    time.Duration
 }
 
+// RateLimitConfig - бюджет запросов и повторы исходящего HTTP клиента
+type RateLimitConfig struct {
+	RequestsPerMinute float64       // Средний темп запросов (token bucket); <= 0 - без ограничения
+	Burst             int           // Сколько запросов можно сделать подряд после простоя
+	MaxRetries        int           // Повторов после 429 / 5xx / сетевой ошибки
+	BackoffBase       time.Duration // Пауза перед первым повтором, дальше - экспоненциально (+ jitter)
+	BackoffMax        time.Duration // Потолок паузы; Retry-After длиннее - без повтора, сразу ошибка
+}
+
+// SteamConfig - настройки клиента Steam Community Market
+type SteamConfig struct {
+	BaseURL     string        // https://steamcommunity.com (в тестах - адрес httptest сервера)
+	LoginSecure string        // Cookie steamLoginSecure, без неё pricehistory не отвечает
+	Timeout     time.Duration // Таймаут одного HTTP запроса
+	RateLimit   RateLimitConfig
+}
+
+// PollerConfig - настройки фонового опроса цен отслеживаемых предметов
+type PollerConfig struct {
+	Enabled     bool
+	Interval    time.Duration // Пауза между раундами опроса
+	Concurrency int           // Сколько запросов к Steam выполняется одновременно
+	Jitter      time.Duration // Случайная задержка перед каждым запросом [0, Jitter)
+	Currency    int           // ID валюты Steam, в которой запрашиваются цены (1 = USD); перед сохранением цены переводятся в CurrencyConfig.Canonical
+}
+
+// OrderBookConfig - настройки фонового снятия стаканов
+// Гистограмма ордеров у Steam ограничена строже priceoverview, поэтому опрос последовательный и редкий
+type OrderBookConfig struct {
+	Enabled  bool
+	Interval time.Duration // Пауза между раундами
+	Jitter   time.Duration // Случайная задержка перед каждым предметом [0, Jitter)
+}
+
+// MarketplaceConfig - сторонняя площадка, с которой сравниваются цены Steam
+type MarketplaceConfig struct {
+	Enabled      bool
+	BaseURL      string // Адрес API площадки (в тестах - локальная заглушка)
+	Currency     string // ISO код валюты, в которой запрашиваются цены
+	SellerFeeBps int64  // Комиссия площадки с продавца, в сотых долях процента (800 = 8%)
+}
+
+// MarketplacesConfig - сторонние площадки для сравнения цен и поиска арбитража
+type MarketplacesConfig struct {
+	Skinport   MarketplaceConfig
+	CSGOMarket MarketplaceConfig
+	Timeout    time.Duration   // Таймаут запроса; прайс-листы площадок весят мегабайты
+	RateLimit  RateLimitConfig // У каждой площадки свой бюджет с этими параметрами
+	PriceTTL   time.Duration   // Сколько живут котировки площадки в кэше; 0 - без кэша
+}
+
+// CurrencyConfig - каноническая валюта хранения цен и источник курсов
+type CurrencyConfig struct {
+	Canonical       string        // ISO код валюты, в которой хранятся снимки цен (USD)
+	RatesSource     string        // static (встроенная таблица) | file
+	RatesFile       string        // Путь к JSON с курсами для RatesSource = file
+	RefreshInterval time.Duration // Как часто перечитывать курсы из источника
+}
+
+// TelegramConfig - настройки Telegram Bot API
+type TelegramConfig struct {
+	BaseURL  string // https://api.telegram.org (в тестах - локальная заглушка)
+	BotToken string
+}
+
+// SMTPConfig - настройки отправки email
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Пустой - без AUTH (локальный relay / mailhog)
+	Password string
+	From     string
+}
+
+// NotificationsConfig - настройки доставки уведомлений
+type NotificationsConfig struct {
+	Telegram            TelegramConfig
+	SMTP                SMTPConfig
+	WebhookTimeout      time.Duration
+	WebhookAllowPrivate bool          // Разрешить webhook на loopback/частные адреса (только для локальной заглушки)
+	MaxAttempts         int           // Попыток доставки, после которых доставка failed
+	RetryBaseDelay      time.Duration // Пауза перед первым повтором, дальше - экспоненциально
+	RetryMaxDelay       time.Duration
+}
+
+// StreamConfig - поток событий GET /market/stream (SSE)
+type StreamConfig struct {
+	Heartbeat             time.Duration // Пауза между keep-alive комментариями, чтобы прокси не закрывали простаивающее соединение
+	ClientBuffer          int           // Сколько событий ждут отправки одному клиенту; переполнение - клиент отключается и переподключается с Last-Event-ID
+	ReplayBuffer          int           // Сколько последних событий хранится для переподключения по Last-Event-ID
+	MaxConnectionsPerUser int
+	ItemsRefresh          time.Duration // Как часто соединение перечитывает список предметов пользователя
+}
+
+// AnomalyConfig - поиск аномалий в истории цен (пороги - см. domain.AnomalyParams)
+type AnomalyConfig struct {
+	Enabled      bool          // Проверять каждый новый снимок poller'а
+	Window       time.Duration // История до снимка, с которой он сравнивается
+	MinPoints    int
+	ZThreshold   float64
+	MADThreshold float64
+	VolumeFactor float64
+	MinVolume    int
+	ScanRange    time.Duration // Сколько истории проходит ручной пересчёт по предмету
+}
+
+// LiquidityConfig - фоновый пересчёт метрик ликвидности предметов
+type LiquidityConfig struct {
+	Enabled         bool
+	Interval        time.Duration // Пауза между раундами
+	MaxAge          time.Duration // Метрики моложе не пересчитываются
+	Jitter          time.Duration // Случайная задержка перед каждым предметом [0, Jitter)
+	UsePriceHistory bool          // Брать продажи из pricehistory Steam (нужна STEAM_LOGIN_SECURE), иначе из снимков цен
+}
+
+// BackfillConfig - загрузка всей истории цен Steam для впервые отслеживаемых предметов
+type BackfillConfig struct {
+	Enabled        bool          // pricehistory отвечает только с STEAM_LOGIN_SECURE, поэтому по умолчанию включено только с ней
+	Interval       time.Duration // Пауза между проверками очереди задач
+	ChunkSize      int           // Точек истории в одной транзакции (шаг сохранения курсора)
+	Jitter         time.Duration // Случайная задержка перед каждой задачей [0, Jitter)
+	MaxAttempts    int           // После стольких неудачных попыток задача failed
+	RetryBaseDelay time.Duration // Пауза перед первым повтором, дальше - экспоненциально
+	RetryMaxDelay  time.Duration
+}
+
+// GameDataConfig - справочник коллекций и кейсов CS2
+type GameDataConfig struct {
+	File string // JSON в формате встроенного справочника; пустой - встроенный
+}
+
 type Config struct {
 	HTTPAddr    string
 	FrontendURL string
@@ -28,22 +159,23 @@ type Config struct {
 	Database    string
 	JWT         JWTConfig
 	CORSOrigins []string
+	AdminIDs    []string // ID пользователей с доступом к /admin
+	Steam       SteamConfig
+	Poller      PollerConfig
+	OrderBooks  OrderBookConfig
+	Markets     MarketplacesConfig
+	Currency    CurrencyConfig
+	Notify      NotificationsConfig
+	Stream      StreamConfig
+	Anomalies   AnomalyConfig
+	Liquidity   LiquidityConfig
+	GameData    GameDataConfig
+	Backfill    BackfillConfig
 }
 
 func Load() *Config {
 	_ = godotenv.Load()
 
-	// Парсим CORS_ORIGINS (разделяются запятыми)
-	corsOriginsStr := os.Getenv("CORS_ORIGINS")
-	corsOrigins := []string{}
-	if corsOriginsStr != "" {
-		corsOrigins = strings.Split(corsOriginsStr, ",")
-		// Убираем пробелы
-		for i, origin := range corsOrigins {
-			corsOrigins[i] = strings.TrimSpace(origin)
-		}
-	}
-
 	return &Config{
 		HTTPAddr:    getEnv("HTTP_ADDR", ":8080"),
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
@@ -57,7 +189,115 @@ func Load() *Config {
 			Secret: os.Getenv("JWT_SECRET"),
 			TTL:    time.Duration(getEnvAsInt("JWT_TTL_SECONDS", 3600)) * time.Second,
 		},
-		CORSOrigins: corsOrigins,
+		CORSOrigins: getEnvAsList("CORS_ORIGINS"),
+		AdminIDs:    getEnvAsList("ADMIN_USER_IDS"),
+		Steam: SteamConfig{
+			BaseURL:     strings.TrimRight(getEnv("STEAM_MARKET_BASE_URL", "https://steamcommunity.com"), "/"),
+			LoginSecure: os.Getenv("STEAM_LOGIN_SECURE"),
+			Timeout:     time.Duration(getEnvAsInt("STEAM_HTTP_TIMEOUT_SECONDS", 10)) * time.Second,
+			RateLimit: RateLimitConfig{
+				RequestsPerMinute: float64(getEnvAsInt("STEAM_RATE_LIMIT_PER_MINUTE", 20)),
+				Burst:             getEnvAsInt("STEAM_RATE_LIMIT_BURST", 5),
+				MaxRetries:        getEnvAsInt("STEAM_MAX_RETRIES", 3),
+				BackoffBase:       time.Duration(getEnvAsInt("STEAM_BACKOFF_BASE_MS", 2000)) * time.Millisecond,
+				BackoffMax:        time.Duration(getEnvAsInt("STEAM_BACKOFF_MAX_SECONDS", 60)) * time.Second,
+			},
+		},
+		Poller: PollerConfig{
+			Enabled:     getEnvAsBool("PRICE_POLLER_ENABLED", true),
+			Interval:    time.Duration(getEnvAsInt("PRICE_POLLER_INTERVAL_SECONDS", 900)) * time.Second,
+			Concurrency: getEnvAsInt("PRICE_POLLER_CONCURRENCY", 2),
+			Jitter:      time.Duration(getEnvAsInt("PRICE_POLLER_JITTER_MS", 1500)) * time.Millisecond,
+			Currency:    getEnvAsInt("PRICE_POLLER_CURRENCY", 1),
+		},
+		OrderBooks: OrderBookConfig{
+			Enabled:  getEnvAsBool("ORDER_BOOK_POLLER_ENABLED", true),
+			Interval: time.Duration(getEnvAsInt("ORDER_BOOK_POLLER_INTERVAL_SECONDS", 3600)) * time.Second,
+			Jitter:   time.Duration(getEnvAsInt("ORDER_BOOK_POLLER_JITTER_MS", 3000)) * time.Millisecond,
+		},
+		Markets: MarketplacesConfig{
+			Skinport: MarketplaceConfig{
+				Enabled:      getEnvAsBool("SKINPORT_ENABLED", true),
+				BaseURL:      strings.TrimRight(getEnv("SKINPORT_BASE_URL", "https://api.skinport.com"), "/"),
+				Currency:     getEnv("SKINPORT_CURRENCY", "USD"),
+				SellerFeeBps: int64(getEnvAsInt("SKINPORT_SELLER_FEE_BPS", 800)),
+			},
+			CSGOMarket: MarketplaceConfig{
+				Enabled:      getEnvAsBool("CSGOMARKET_ENABLED", true),
+				BaseURL:      strings.TrimRight(getEnv("CSGOMARKET_BASE_URL", "https://market.csgo.com"), "/"),
+				Currency:     getEnv("CSGOMARKET_CURRENCY", "USD"),
+				SellerFeeBps: int64(getEnvAsInt("CSGOMARKET_SELLER_FEE_BPS", 500)),
+			},
+			Timeout: time.Duration(getEnvAsInt("MARKETPLACE_HTTP_TIMEOUT_SECONDS", 30)) * time.Second,
+			RateLimit: RateLimitConfig{
+				RequestsPerMinute: float64(getEnvAsInt("MARKETPLACE_RATE_LIMIT_PER_MINUTE", 6)),
+				Burst:             getEnvAsInt("MARKETPLACE_RATE_LIMIT_BURST", 2),
+				MaxRetries:        getEnvAsInt("MARKETPLACE_MAX_RETRIES", 2),
+				BackoffBase:       time.Duration(getEnvAsInt("MARKETPLACE_BACKOFF_BASE_MS", 2000)) * time.Millisecond,
+				BackoffMax:        time.Duration(getEnvAsInt("MARKETPLACE_BACKOFF_MAX_SECONDS", 60)) * time.Second,
+			},
+			PriceTTL: time.Duration(getEnvAsInt("MARKETPLACE_PRICE_CACHE_TTL_SECONDS", 300)) * time.Second,
+		},
+		Currency: CurrencyConfig{
+			Canonical:       getEnv("CANONICAL_CURRENCY", "USD"),
+			RatesSource:     getEnv("EXCHANGE_RATES_SOURCE", "static"),
+			RatesFile:       getEnv("EXCHANGE_RATES_FILE", "exchange_rates.json"),
+			RefreshInterval: time.Duration(getEnvAsInt("EXCHANGE_RATES_REFRESH_SECONDS", 3600)) * time.Second,
+		},
+		Notify: NotificationsConfig{
+			Telegram: TelegramConfig{
+				BaseURL:  strings.TrimRight(getEnv("TELEGRAM_API_BASE_URL", "https://api.telegram.org"), "/"),
+				BotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
+			},
+			SMTP: SMTPConfig{
+				Host:     getEnv("SMTP_HOST", "localhost"),
+				Port:     getEnvAsInt("SMTP_PORT", 587),
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     getEnv("SMTP_FROM", "steam-observer@localhost"),
+			},
+			WebhookTimeout:      time.Duration(getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+			WebhookAllowPrivate: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+			MaxAttempts:         getEnvAsInt("NOTIFY_MAX_ATTEMPTS", 5),
+			RetryBaseDelay:      time.Duration(getEnvAsInt("NOTIFY_RETRY_BASE_SECONDS", 5)) * time.Second,
+			RetryMaxDelay:       time.Duration(getEnvAsInt("NOTIFY_RETRY_MAX_SECONDS", 600)) * time.Second,
+		},
+		Stream: StreamConfig{
+			Heartbeat:             time.Duration(getEnvAsInt("STREAM_HEARTBEAT_SECONDS", 15)) * time.Second,
+			ClientBuffer:          getEnvAsInt("STREAM_CLIENT_BUFFER", 64),
+			ReplayBuffer:          getEnvAsInt("STREAM_REPLAY_BUFFER", 1024),
+			MaxConnectionsPerUser: getEnvAsInt("STREAM_MAX_CONNECTIONS_PER_USER", 5),
+			ItemsRefresh:          time.Duration(getEnvAsInt("STREAM_ITEMS_REFRESH_SECONDS", 60)) * time.Second,
+		},
+		Anomalies: AnomalyConfig{
+			Enabled:      getEnvAsBool("ANOMALY_DETECTION_ENABLED", true),
+			Window:       time.Duration(getEnvAsInt("ANOMALY_WINDOW_HOURS", 168)) * time.Hour,
+			MinPoints:    getEnvAsInt("ANOMALY_MIN_POINTS", 12),
+			ZThreshold:   getEnvAsFloat("ANOMALY_ZSCORE_THRESHOLD", 3),
+			MADThreshold: getEnvAsFloat("ANOMALY_MAD_THRESHOLD", 3.5),
+			VolumeFactor: getEnvAsFloat("ANOMALY_VOLUME_FACTOR", 5),
+			MinVolume:    getEnvAsInt("ANOMALY_MIN_VOLUME", 10),
+			ScanRange:    time.Duration(getEnvAsInt("ANOMALY_SCAN_DAYS", 90)) * 24 * time.Hour,
+		},
+		Liquidity: LiquidityConfig{
+			Enabled:         getEnvAsBool("LIQUIDITY_POLLER_ENABLED", true),
+			Interval:        time.Duration(getEnvAsInt("LIQUIDITY_POLLER_INTERVAL_SECONDS", 3600)) * time.Second,
+			MaxAge:          time.Duration(getEnvAsInt("LIQUIDITY_MAX_AGE_HOURS", 12)) * time.Hour,
+			Jitter:          time.Duration(getEnvAsInt("LIQUIDITY_POLLER_JITTER_MS", 3000)) * time.Millisecond,
+			UsePriceHistory: getEnvAsBool("LIQUIDITY_USE_PRICE_HISTORY", os.Getenv("STEAM_LOGIN_SECURE") != ""),
+		},
+		GameData: GameDataConfig{
+			File: os.Getenv("GAME_DATA_FILE"),
+		},
+		Backfill: BackfillConfig{
+			Enabled:        getEnvAsBool("BACKFILL_ENABLED", os.Getenv("STEAM_LOGIN_SECURE") != ""),
+			Interval:       time.Duration(getEnvAsInt("BACKFILL_INTERVAL_SECONDS", 60)) * time.Second,
+			ChunkSize:      getEnvAsInt("BACKFILL_CHUNK_SIZE", 500),
+			Jitter:         time.Duration(getEnvAsInt("BACKFILL_JITTER_MS", 3000)) * time.Millisecond,
+			MaxAttempts:    getEnvAsInt("BACKFILL_MAX_ATTEMPTS", 5),
+			RetryBaseDelay: time.Duration(getEnvAsInt("BACKFILL_RETRY_BASE_SECONDS", 300)) * time.Second,
+			RetryMaxDelay:  time.Duration(getEnvAsInt("BACKFILL_RETRY_MAX_SECONDS", 6*3600)) * time.Second,
+		},
 	}
 }
 
@@ -68,6 +308,27 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList - значения через запятую без пробелов по краям; пустые пропускаются
+func getEnvAsList(name string) []string {
+	list := []string{}
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func getEnvAsBool(name string, defaultVal bool) bool {
+	switch strings.ToLower(os.Getenv(name)) {
+	case "1", "true", "yes", "on":
+		return true
+	case "0", "false", "no", "off":
+		return false
+	}
+	return defaultVal
+}
+
 func getEnvAsInt(name string, defaultVal int) int {
 	if valueStr := os.Getenv(name); valueStr != "" {
 		var value int
@@ -78,3 +339,14 @@ func getEnvAsInt(name string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvAsFloat(name string, defaultVal float64) float64 {
+	if valueStr := os.Getenv(name); valueStr != "" {
+		var value float64
+		_, err := fmt.Sscanf(valueStr, "%g", &value)
+		if err == nil {
+			return value
+		}
+	}
+	return defaultVal
+}
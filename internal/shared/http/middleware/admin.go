@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"steam-observer/internal/shared/logger"
+)
+
+// Admin - пропускает только пользователей из adminIDs (ADMIN_USER_IDS)
+// Ставится после Auth: userID берётся из контекста запроса
+func Admin(adminIDs []string, log logger.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := UserIDFromContext(r.Context())
+			if !ok || !slices.Contains(adminIDs, userID) {
+				log.Warnf("admin access denied, user_id=%s, path=%s", userID, r.URL.Path)
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":"admin access required"}`))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -29,7 +29,7 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
 				w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS,PATCH")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,Last-Event-ID")
 				w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 			}
 
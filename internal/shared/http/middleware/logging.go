@@ -23,6 +23,19 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Flush - без него обёртка прятала бы http.Flusher исходного writer'а,
+// и потоковые ответы (SSE) копились бы в буфере до конца запроса
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap - доступ к исходному writer'у для http.ResponseController (дедлайны записи и т.п.)
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Logging middleware логирует все HTTP запросы
 func Logging(log logger.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,184 @@
+// Package httpclient - общий исходящий HTTP клиент для внешних API с жёсткими лимитами
+//
+// Один Client на один upstream (все адаптеры Steam делят один экземпляр):
+//   - глобальный token bucket на все запросы клиента;
+//   - интерактивные запросы (из HTTP хендлеров) идут раньше фоновых (poller'ы);
+//   - 429 / 5xx / сетевые ошибки повторяются с экспоненциальной паузой и jitter;
+//   - Retry-After соблюдается и ставит на паузу весь клиент, а не только один запрос.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+// Priority - приоритет запроса в очереди за токенами
+type Priority int
+
+const (
+	// PriorityInteractive - запрос пользователя; по умолчанию
+	PriorityInteractive Priority = iota
+	// PriorityBackground - фоновый опрос; уступает интерактивным
+	PriorityBackground
+)
+
+type priorityKey struct{}
+
+// WithPriority - контекст, запросы с которым получают priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom - приоритет из контекста (PriorityInteractive, если не задан)
+func PriorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// Client - http.Client с бюджетом запросов и повторами
+// Do совместим по сигнатуре с http.Client.Do
+type Client struct {
+	http    *http.Client
+	limiter *limiter
+	cfg     config.RateLimitConfig
+	logger  logger.Logger
+}
+
+// New - создаёт клиент; timeout - на одну попытку, а не на все повторы
+func New(timeout time.Duration, cfg config.RateLimitConfig, log logger.Logger) *Client {
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = time.Second
+	}
+	if cfg.BackoffMax < cfg.BackoffBase {
+		cfg.BackoffMax = cfg.BackoffBase
+	}
+
+	return &Client{
+		http:    &http.Client{Timeout: timeout},
+		limiter: newLimiter(cfg.RequestsPerMinute, cfg.Burst),
+		cfg:     cfg,
+		logger:  log,
+	}
+}
+
+// Do - выполняет запрос с учётом бюджета и повторов
+//
+// Если повторы исчерпаны на 429 / 5xx, возвращается последний ответ как есть -
+// адаптер сам переводит статус в свою ошибку (ErrSteamRateLimited и т.п.).
+// Ошибка - только сетевая или отмена ctx.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	priority := PriorityFrom(ctx)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		if err := c.limiter.wait(ctx, priority); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.http.Do(req)
+		if ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait, retry := c.retryDelay(resp, err, attempt)
+		if !retry || attempt >= c.cfg.MaxRetries || !canRetry(req) {
+			return resp, err
+		}
+
+		reason := "network error"
+		if resp != nil {
+			reason = resp.Status
+			// Тело промежуточного ответа не нужно; вычитываем, чтобы соединение вернулось в пул
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		c.logger.Warnf("%s %s: %s, retry %d/%d in %s",
+			req.Method, req.URL.Path, reason, attempt+1, c.cfg.MaxRetries, wait.Round(time.Millisecond))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay - нужен ли повтор и через сколько
+//   - 429: Retry-After (или backoff) и пауза для всего клиента; Retry-After длиннее BackoffMax -
+//     без повтора: ждать столько внутри запроса нельзя, но пауза всё равно ставится
+//   - 500/502/503/504: Retry-After или backoff
+//   - сетевая ошибка: backoff
+func (c *Client) retryDelay(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		return c.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			wait = c.backoff(attempt)
+		}
+		c.limiter.block(wait)
+		return wait, wait <= c.cfg.BackoffMax
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, wait <= c.cfg.BackoffMax
+		}
+		return c.backoff(attempt), true
+	default:
+		return 0, false
+	}
+}
+
+// backoff - BackoffBase * 2^attempt (не больше BackoffMax), случайно в [половина, целое]
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.cfg.BackoffMax
+	if attempt < 30 {
+		d = min(c.cfg.BackoffBase<<attempt, c.cfg.BackoffMax)
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// canRetry - запрос можно отправить повторно: без тела или с возможностью его перечитать
+func canRetry(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// parseRetryAfter - "120" (секунды) или HTTP дата
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
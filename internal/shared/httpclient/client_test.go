@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"steam-observer/internal/shared/config"
+	"steam-observer/internal/shared/logger"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true}, // Дата в прошлом - можно сразу
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// newTestClient - клиент без бюджета (действует только пауза после 429) и сервер с обработчиком
+func newTestClient(t *testing.T, maxRetries int, handler http.HandlerFunc) (*Client, string) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := config.RateLimitConfig{
+		MaxRetries:  maxRetries,
+		BackoffBase: time.Millisecond,
+		BackoffMax:  time.Second,
+	}
+	return New(5*time.Second, cfg, logger.NewNopLogger()), server.URL
+}
+
+func TestClientRetriesRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	client, url := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("status %d after %d calls, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClientLongRetryAfterBlocksWithoutRetry(t *testing.T) {
+	var calls atomic.Int32
+	client, url := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600") // Больше BackoffMax
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || calls.Load() != 1 {
+		t.Fatalf("status %d after %d calls, want 429 after 1", resp.StatusCode, calls.Load())
+	}
+
+	// Пауза действует на весь клиент: следующий запрос не уходит на сервер
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if _, err := client.Do(req); err != context.DeadlineExceeded {
+		t.Errorf("Do while blocked: error = %v, want context.DeadlineExceeded", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("blocked client reached the server: %d calls", calls.Load())
+	}
+}
+
+func TestClientRetryRewindsBody(t *testing.T) {
+	var bodies []string
+	client, url := newTestClient(t, 2, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader("payload"))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Errorf("bodies = %q, want the payload twice", bodies)
+	}
+}
+
+func TestClientDoesNotRetryUnrewindableBody(t *testing.T) {
+	var calls atomic.Int32
+	client, url := newTestClient(t, 2, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, url, io.NopCloser(strings.NewReader("payload")))
+	req.GetBody = nil
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 1 {
+		t.Errorf("status %d after %d calls, want 502 after 1", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClientBackoffBounds(t *testing.T) {
+	client := New(time.Second, config.RateLimitConfig{BackoffBase: 100 * time.Millisecond, BackoffMax: time.Second}, logger.NewNopLogger())
+
+	for attempt, upper := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		for range 20 {
+			if d := client.backoff(attempt); d < upper/2 || d > upper {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", attempt, d, upper/2, upper)
+			}
+		}
+	}
+	if d := client.backoff(100); d > time.Second {
+		t.Errorf("backoff(100) = %s, want at most BackoffMax", d)
+	}
+}
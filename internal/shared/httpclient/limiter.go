@@ -0,0 +1,114 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter - token bucket с приоритетом и глобальной паузой
+//
+// Фоновый запрос не берёт токен, пока есть ждущие интерактивные:
+// пользователь не должен ждать, пока poller выберет бюджет.
+// block ставит паузу для всех - после 429 Steam ограничивает весь IP, а не запрос.
+type limiter struct {
+	mu sync.Mutex
+
+	rate   float64 // токенов в секунду
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	blockedUntil       time.Time
+	interactiveWaiting int
+}
+
+func newLimiter(requestsPerMinute float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:   requestsPerMinute / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait - ждёт токен (или отмену ctx)
+func (l *limiter) wait(ctx context.Context, priority Priority) error {
+	if priority == PriorityInteractive {
+		l.mu.Lock()
+		l.interactiveWaiting++
+		l.mu.Unlock()
+
+		defer func() {
+			l.mu.Lock()
+			l.interactiveWaiting--
+			l.mu.Unlock()
+		}()
+	}
+
+	for {
+		delay, ok := l.reserve(priority)
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve - забирает токен (ok=true) или говорит, сколько подождать до следующей попытки
+func (l *limiter) reserve(priority Priority) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now), false
+	}
+	if l.rate <= 0 {
+		return 0, true // бюджет не ограничен, действует только пауза после 429
+	}
+	l.refill(now)
+
+	switch {
+	case priority == PriorityBackground && l.interactiveWaiting > 0:
+		// Уступаем; очередь опрашиваем с интервалом между токенами
+		return time.Duration(float64(time.Second) / l.rate), false
+	case l.tokens >= 1:
+		l.tokens--
+		return 0, true
+	default:
+		return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+	}
+}
+
+// block - пауза для всех запросов на d (более ранняя пауза не сокращается)
+func (l *limiter) block(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+	// После паузы начинаем с пустого ведра: за время паузы токены не копятся
+	l.tokens = 0
+	l.last = l.blockedUntil
+}
+
+func (l *limiter) refill(now time.Time) {
+	if !now.After(l.last) {
+		return
+	}
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+}
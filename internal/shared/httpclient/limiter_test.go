@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterTokenBucket(t *testing.T) {
+	l := newLimiter(60, 2) // Токен в секунду, ведро на два
+
+	for i := range 2 {
+		if _, ok := l.reserve(PriorityInteractive); !ok {
+			t.Fatalf("reserve %d: bucket should have a token", i+1)
+		}
+	}
+
+	delay, ok := l.reserve(PriorityInteractive)
+	if ok {
+		t.Fatal("reserve from an empty bucket succeeded")
+	}
+	if delay <= 900*time.Millisecond || delay > time.Second {
+		t.Errorf("delay = %s, want about a second", delay)
+	}
+
+	// Через секунду появляется ровно один токен
+	l.last = l.last.Add(-time.Second)
+	if _, ok := l.reserve(PriorityInteractive); !ok {
+		t.Error("no token after a second")
+	}
+	if _, ok := l.reserve(PriorityInteractive); ok {
+		t.Error("more than one token after a second")
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := newLimiter(0, 0)
+	for range 100 {
+		if _, ok := l.reserve(PriorityBackground); !ok {
+			t.Fatal("unlimited limiter refused a request")
+		}
+	}
+}
+
+func TestLimiterBlock(t *testing.T) {
+	l := newLimiter(0, 0)
+
+	l.block(time.Hour)
+	delay, ok := l.reserve(PriorityInteractive)
+	if ok || delay < 59*time.Minute {
+		t.Fatalf("reserve while blocked = %s, %v; want about an hour", delay, ok)
+	}
+
+	// Более короткая пауза не сокращает действующую
+	l.block(time.Minute)
+	if delay, _ := l.reserve(PriorityInteractive); delay < 59*time.Minute {
+		t.Errorf("shorter block cut the pause to %s", delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx, PriorityInteractive); err != context.DeadlineExceeded {
+		t.Errorf("wait while blocked = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLimiterBackgroundYields(t *testing.T) {
+	l := newLimiter(600, 1) // Токен раз в 100 мс
+	if _, ok := l.reserve(PriorityInteractive); !ok {
+		t.Fatal("first reserve failed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order := make(chan Priority, 2)
+	go func() {
+		if err := l.wait(ctx, PriorityInteractive); err == nil {
+			order <- PriorityInteractive
+		}
+	}()
+
+	// Фоновый запрос встаёт в очередь, когда интерактивный уже ждёт
+	for {
+		l.mu.Lock()
+		waiting := l.interactiveWaiting
+		l.mu.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	go func() {
+		if err := l.wait(ctx, PriorityBackground); err == nil {
+			order <- PriorityBackground
+		}
+	}()
+
+	if first, second := <-order, <-order; first != PriorityInteractive || second != PriorityBackground {
+		t.Errorf("order = %v, %v; want interactive first", first, second)
+	}
+}
+
+func TestLimiterBackgroundWithTokens(t *testing.T) {
+	l := newLimiter(60, 5)
+	l.interactiveWaiting = 1
+
+	// Токены есть, но их ждёт интерактивный запрос
+	if _, ok := l.reserve(PriorityBackground); ok {
+		t.Error("background request took a token while an interactive one waits")
+	}
+	if _, ok := l.reserve(PriorityInteractive); !ok {
+		t.Error("interactive request did not get a token")
+	}
+}
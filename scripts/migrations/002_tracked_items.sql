@@ -0,0 +1,20 @@
+-- Отслеживаемые предметы Steam Community Market
+CREATE TABLE IF NOT EXISTS public.tracked_items (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    name TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Пользователь следит за конкретным предметом только один раз
+    CONSTRAINT uq_tracked_items_user_item UNIQUE (user_id, app_id, market_hash_name)
+);
+
+-- Индекс для списка предметов пользователя
+CREATE INDEX IF NOT EXISTS idx_tracked_items_user_id ON public.tracked_items(user_id, created_at DESC);
+
+-- Индекс для поиска всех пользователей, следящих за предметом (poller, алерты)
+CREATE INDEX IF NOT EXISTS idx_tracked_items_item ON public.tracked_items(app_id, market_hash_name);
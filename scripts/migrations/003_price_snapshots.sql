@@ -0,0 +1,17 @@
+-- Снимки цен предметов, которые периодически пишет price poller
+-- Снимок относится к предмету (app_id + market_hash_name), а не к пользователю:
+-- за одним скином могут следить многие, цену при этом запрашиваем один раз
+CREATE TABLE IF NOT EXISTS public.price_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    currency INTEGER NOT NULL,
+    lowest_price BIGINT,  -- в сотых долях валюты, NULL если Steam не вернул
+    median_price BIGINT,  -- в сотых долях валюты, NULL если Steam не вернул
+    volume BIGINT NOT NULL DEFAULT 0,
+    observed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Основной паттерн чтения: история одного предмета за период
+CREATE INDEX IF NOT EXISTS idx_price_snapshots_item_time
+    ON public.price_snapshots(app_id, market_hash_name, observed_at DESC);
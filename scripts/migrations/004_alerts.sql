@@ -0,0 +1,41 @@
+-- Правила алертов на отслеживаемые предметы
+CREATE TABLE IF NOT EXISTS public.alert_rules (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    tracked_item_id TEXT NOT NULL REFERENCES public.tracked_items(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,                      -- price_below | price_above | percent_change | volume_spike
+    threshold DOUBLE PRECISION NOT NULL,
+    window_seconds BIGINT NOT NULL DEFAULT 0,
+    mode TEXT NOT NULL DEFAULT 'once',       -- once | repeat
+    cooldown_seconds BIGINT NOT NULL DEFAULT 3600,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_fired_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_user_id ON public.alert_rules(user_id, created_at DESC);
+
+-- Поиск активных правил по предмету при каждом новом снимке цены
+CREATE INDEX IF NOT EXISTS idx_alert_rules_item ON public.alert_rules(tracked_item_id) WHERE enabled;
+
+-- История срабатываний
+-- Без FK на alert_rules: история переживает удаление правила
+CREATE TABLE IF NOT EXISTS public.fired_alerts (
+    id TEXT PRIMARY KEY,
+    rule_id TEXT NOT NULL,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    tracked_item_id TEXT NOT NULL,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    currency INTEGER NOT NULL,
+    price BIGINT NOT NULL,
+    reference DOUBLE PRECISION NOT NULL,
+    observed DOUBLE PRECISION NOT NULL,
+    message TEXT NOT NULL,
+    fired_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_fired_alerts_user_id ON public.fired_alerts(user_id, fired_at DESC);
@@ -0,0 +1,38 @@
+-- Каналы доставки уведомлений (webhook, telegram, email)
+CREATE TABLE IF NOT EXISTS public.notification_channels (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,                       -- webhook | telegram | email
+    target TEXT NOT NULL,                     -- URL | chat_id | email
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    verification_code TEXT NOT NULL DEFAULT '',
+    verification_expires_at TIMESTAMP NOT NULL,
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_notification_channels_target UNIQUE (user_id, type, target)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_channels_user_id ON public.notification_channels(user_id);
+
+-- Журнал доставок: каждая запись - одно сообщение в один канал
+CREATE TABLE IF NOT EXISTS public.notification_deliveries (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    channel_id TEXT NOT NULL REFERENCES public.notification_channels(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL,                     -- pending | sent | failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user_id
+    ON public.notification_deliveries(user_id, created_at DESC);
+
+-- Возобновление незавершённых доставок после рестарта
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_pending
+    ON public.notification_deliveries(created_at) WHERE status = 'pending';
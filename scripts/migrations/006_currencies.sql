@@ -0,0 +1,19 @@
+-- Курсы валют относительно канонической валюты хранения цен
+-- rate - сколько единиц currency стоит 1 единица base
+CREATE TABLE IF NOT EXISTS public.exchange_rates (
+    currency INTEGER PRIMARY KEY,            -- ID валюты Steam
+    base INTEGER NOT NULL,
+    rate DOUBLE PRECISION NOT NULL CHECK (rate > 0),
+    source TEXT NOT NULL,                    -- static | file | ...
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Персональные настройки пользователя в модуле market
+CREATE TABLE IF NOT EXISTS public.user_preferences (
+    user_id TEXT PRIMARY KEY REFERENCES public.users(id) ON DELETE CASCADE,
+    display_currency INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Валюта ценового порога правила (раньше пороги были в валюте poller'а, по умолчанию USD)
+ALTER TABLE public.alert_rules ADD COLUMN IF NOT EXISTS currency INTEGER NOT NULL DEFAULT 1;
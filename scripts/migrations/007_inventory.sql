@@ -0,0 +1,2 @@
+-- Привязанный Steam аккаунт пользователя (SteamID64) для импорта инвентаря
+ALTER TABLE public.user_preferences ADD COLUMN IF NOT EXISTS steam_id TEXT;
@@ -0,0 +1,17 @@
+-- Сделки пользователя (покупки и продажи) по отслеживаемым предметам
+CREATE TABLE IF NOT EXISTS public.portfolio_lots (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    tracked_item_id TEXT NOT NULL REFERENCES public.tracked_items(id) ON DELETE CASCADE,
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),  -- в сотых долях currency
+    fee BIGINT NOT NULL DEFAULT 0 CHECK (fee >= 0),      -- на весь лот
+    currency INTEGER NOT NULL,
+    executed_at TIMESTAMP NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- FIFO идёт по времени сделки в рамках предмета пользователя
+CREATE INDEX IF NOT EXISTS idx_portfolio_lots_user_item ON public.portfolio_lots(user_id, tracked_item_id, executed_at);
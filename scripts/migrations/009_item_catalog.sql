@@ -0,0 +1,24 @@
+-- Каталог предметов маркета: структура имени + описание из Steam
+CREATE TABLE IF NOT EXISTS public.item_catalog (
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    name TEXT NOT NULL,
+    icon_url TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL DEFAULT 'other',      -- weapon | knife | gloves | sticker | agent | container | ...
+    weapon TEXT NOT NULL DEFAULT '',
+    skin TEXT NOT NULL DEFAULT '',
+    rarity TEXT NOT NULL DEFAULT '',
+    exterior TEXT NOT NULL DEFAULT '',
+    stattrak BOOLEAN NOT NULL DEFAULT FALSE,
+    souvenir BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (app_id, market_hash_name)
+);
+
+-- Поиск канонического имени для введённого вручную (регистр не совпадает)
+CREATE INDEX IF NOT EXISTS idx_item_catalog_name_lower ON public.item_catalog(app_id, lower(market_hash_name));
+
+-- Фильтры каталога
+CREATE INDEX IF NOT EXISTS idx_item_catalog_weapon ON public.item_catalog(app_id, weapon, exterior);
+CREATE INDEX IF NOT EXISTS idx_item_catalog_type ON public.item_catalog(app_id, type, rarity);
@@ -0,0 +1,5 @@
+-- Нечёткий поиск по каталогу (триграммы): опечатки и частичные совпадения
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_item_catalog_name_trgm
+    ON public.item_catalog USING GIN (market_hash_name gin_trgm_ops);
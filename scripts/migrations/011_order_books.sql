@@ -0,0 +1,27 @@
+-- item_nameid - внутренний ID предмета Steam, нужен для itemordershistogram
+-- Берётся со страницы лота один раз и больше не меняется
+CREATE TABLE IF NOT EXISTS public.item_name_ids (
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    item_nameid BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (app_id, market_hash_name)
+);
+
+-- Периодические снимки стакана (верхушка, которую отдаёт Steam)
+CREATE TABLE IF NOT EXISTS public.order_book_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    currency INTEGER NOT NULL,
+    highest_bid BIGINT,           -- NULL = ордеров на покупку нет
+    lowest_ask BIGINT,            -- NULL = лотов на продажу нет
+    bid_orders BIGINT NOT NULL DEFAULT 0,
+    ask_orders BIGINT NOT NULL DEFAULT 0,
+    bids JSONB NOT NULL DEFAULT '[]',  -- [{"price":123,"quantity":4}, ...] по убыванию цены
+    asks JSONB NOT NULL DEFAULT '[]',  -- по возрастанию цены
+    observed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_book_snapshots_item_time
+    ON public.order_book_snapshots(app_id, market_hash_name, observed_at DESC);
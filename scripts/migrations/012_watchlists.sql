@@ -0,0 +1,33 @@
+-- Теги отслеживаемых предметов ("long", "flip", ...), в нижнем регистре
+ALTER TABLE public.tracked_items ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Фильтр /market/tracked?tag=... (оператор @>)
+CREATE INDEX IF NOT EXISTS idx_tracked_items_tags ON public.tracked_items USING GIN (tags);
+
+-- Именованные списки отслеживаемых предметов пользователя
+CREATE TABLE IF NOT EXISTS public.watchlists (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    share_token TEXT,             -- NULL = список не опубликован
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_watchlists_user_name UNIQUE (user_id, name)
+);
+
+-- Публичная ссылка ищет список по токену
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watchlists_share_token
+    ON public.watchlists(share_token) WHERE share_token IS NOT NULL;
+
+-- Предмет может быть в нескольких списках одновременно
+CREATE TABLE IF NOT EXISTS public.watchlist_items (
+    watchlist_id TEXT NOT NULL REFERENCES public.watchlists(id) ON DELETE CASCADE,
+    tracked_item_id TEXT NOT NULL REFERENCES public.tracked_items(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (watchlist_id, tracked_item_id)
+);
+
+-- Списки, в которых состоит предмет (watchlist_ids в ответе /market/tracked)
+CREATE INDEX IF NOT EXISTS idx_watchlist_items_item ON public.watchlist_items(tracked_item_id);
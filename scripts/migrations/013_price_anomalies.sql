@@ -0,0 +1,22 @@
+-- Аномалии в истории цен (общие для всех пользователей, как и снимки)
+CREATE TABLE IF NOT EXISTS public.price_anomalies (
+    id BIGSERIAL PRIMARY KEY,
+    snapshot_id BIGINT NOT NULL REFERENCES public.price_snapshots(id) ON DELETE CASCADE,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    detector TEXT NOT NULL,              -- zscore | mad | volume
+    severity TEXT NOT NULL,              -- low | medium | high
+    direction TEXT NOT NULL,             -- up | down
+    score DOUBLE PRECISION NOT NULL,
+    value DOUBLE PRECISION NOT NULL,     -- Цена в сотых долях currency (volume - штуки)
+    baseline DOUBLE PRECISION NOT NULL,
+    currency INTEGER NOT NULL,
+    observed_at TIMESTAMP NOT NULL,
+    detected_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Повторный прогон по истории не задваивает аномалии
+    CONSTRAINT uq_price_anomalies_snapshot_detector UNIQUE (snapshot_id, detector)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_anomalies_item_time
+    ON public.price_anomalies(app_id, market_hash_name, observed_at DESC);
@@ -0,0 +1,17 @@
+-- Метрики ликвидности предмета по истории продаж Steam
+-- Одна строка на предмет: пересчитываются фоновым воркером и перезаписываются
+CREATE TABLE IF NOT EXISTS public.item_liquidity (
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    source TEXT NOT NULL,              -- price_history | snapshots
+    days INTEGER NOT NULL,             -- Сколько дней с данными вошло в расчёт
+    sales BIGINT NOT NULL,             -- Продаж за эти дни
+    daily_volume DOUBLE PRECISION NOT NULL,
+    volume_7d DOUBLE PRECISION NOT NULL,
+    volume_trend DOUBLE PRECISION NOT NULL,
+    active_days INTEGER NOT NULL,
+    time_to_sell_hours DOUBLE PRECISION, -- NULL = продаж не было
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    computed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (app_id, market_hash_name)
+);
@@ -0,0 +1,44 @@
+-- История продаж Steam (pricehistory), загруженная задачами backfill
+-- Покрывает период до начала собственных снимков: без неё графики начинаются с момента отслеживания
+CREATE TABLE IF NOT EXISTS public.price_history (
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    observed_at TIMESTAMP NOT NULL,  -- Начало периода Steam: час за последний месяц, день - раньше
+    currency INTEGER NOT NULL,
+    price BIGINT NOT NULL,           -- Медианная цена за период, в сотых долях валюты
+    volume BIGINT NOT NULL,          -- Продаж за период
+    PRIMARY KEY (app_id, market_hash_name, observed_at)
+);
+
+-- Задачи загрузки истории: одна запись на запуск
+-- Незавершённые задачи после рестарта продолжаются с cursor_at
+CREATE TABLE IF NOT EXISTS public.price_backfill_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    app_id INTEGER NOT NULL,
+    market_hash_name TEXT NOT NULL,
+    status TEXT NOT NULL,                   -- pending | running | done | failed
+    trigger TEXT NOT NULL,                  -- tracked | admin
+    requested_by TEXT NOT NULL DEFAULT '',  -- ID администратора для trigger = admin
+    cursor_at TIMESTAMP,                    -- Последняя сохранённая точка; NULL - ещё ничего не сохранено
+    points BIGINT NOT NULL DEFAULT 0,       -- Сохранено точек
+    total_points INTEGER NOT NULL DEFAULT 0, -- Точек в ответе Steam на последней попытке
+    attempts INTEGER NOT NULL DEFAULT 0,    -- Неудачных попыток
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- Не больше одной незавершённой задачи на предмет
+CREATE UNIQUE INDEX IF NOT EXISTS uq_price_backfill_jobs_active
+    ON public.price_backfill_jobs(app_id, market_hash_name) WHERE status IN ('pending', 'running');
+
+-- Статус предмета: последняя задача
+CREATE INDEX IF NOT EXISTS idx_price_backfill_jobs_item
+    ON public.price_backfill_jobs(app_id, market_hash_name, created_at DESC);
+
+-- Очередь воркера
+CREATE INDEX IF NOT EXISTS idx_price_backfill_jobs_queue
+    ON public.price_backfill_jobs(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+-- Неудачные попытки подтверждения канала: после лимита код сбрасывается
+ALTER TABLE public.notification_channels ADD COLUMN IF NOT EXISTS verification_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Повторные отправки кода подтверждения: пауза между отправками и лимит в час
+ALTER TABLE public.notification_channels ADD COLUMN IF NOT EXISTS verification_sent_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE public.notification_channels ADD COLUMN IF NOT EXISTS verification_resends INTEGER NOT NULL DEFAULT 0;